@@ -0,0 +1,130 @@
+package cmd
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/yourusername/things3-cli/pkg/formatter"
+	"github.com/yourusername/things3-cli/pkg/util"
+)
+
+var aliasNamePattern = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// aliasCmd groups the alias subcommands
+var aliasCmd = &cobra.Command{
+	Use:   "alias",
+	Short: "Name Things IDs so they can be used as @alias",
+	Long: `Register short names for the IDs of to-dos, projects, headings, and areas.
+Anywhere an ID is expected (--id, --list-id, --to-id, MCP tools, json payloads)
+the alias can be given as @name instead. A registered @name passed as a list or
+area name is used as that ID too.`,
+}
+
+// aliasSetCmd registers or replaces an alias
+var aliasSetCmd = &cobra.Command{
+	Use:   "set NAME ID",
+	Short: "Register an alias for a Things ID",
+	Long: `Register an alias for a Things ID, replacing any existing alias of that name.
+
+Example:
+  things alias set work-proj "ABC123-ID"
+  things update --id @work-proj --when today`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name := strings.TrimPrefix(args[0], "@")
+		id := strings.TrimSpace(args[1])
+		if !aliasNamePattern.MatchString(name) {
+			formatter.PrintError("Alias names may only contain letters, digits, '_' and '-'", "INVALID_ARGUMENTS", "")
+			return nil
+		}
+		if id == "" || strings.HasPrefix(id, "@") {
+			formatter.PrintError("Alias target must be a Things ID", "INVALID_ARGUMENTS", "")
+			return nil
+		}
+
+		config, err := util.LoadConfig()
+		if err != nil {
+			formatter.PrintError("Failed to load config", "CONFIG_ERROR", err.Error())
+			return nil
+		}
+		if config.Aliases == nil {
+			config.Aliases = make(map[string]string)
+		}
+		config.Aliases[name] = id
+
+		if err := util.SaveConfig(config); err != nil {
+			formatter.PrintError("Failed to save config", "CONFIG_ERROR", err.Error())
+			return nil
+		}
+
+		formatter.PrintSuccess(map[string]interface{}{"alias": "@" + name, "id": id})
+		return nil
+	},
+}
+
+// aliasRemoveCmd deletes an alias
+var aliasRemoveCmd = &cobra.Command{
+	Use:   "remove NAME",
+	Short: "Remove an alias",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name := strings.TrimPrefix(args[0], "@")
+
+		config, err := util.LoadConfig()
+		if err != nil {
+			formatter.PrintError("Failed to load config", "CONFIG_ERROR", err.Error())
+			return nil
+		}
+		if _, ok := config.Aliases[name]; !ok {
+			formatter.PrintError("Alias not found: @"+name, "NOT_FOUND", "")
+			return nil
+		}
+		delete(config.Aliases, name)
+
+		if err := util.SaveConfig(config); err != nil {
+			formatter.PrintError("Failed to save config", "CONFIG_ERROR", err.Error())
+			return nil
+		}
+
+		formatter.PrintSuccess(map[string]interface{}{"alias": "@" + name, "removed": true})
+		return nil
+	},
+}
+
+// aliasListCmd prints all registered aliases
+var aliasListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List registered aliases",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		config, err := util.LoadConfig()
+		if err != nil {
+			formatter.PrintError("Failed to load config", "CONFIG_ERROR", err.Error())
+			return nil
+		}
+
+		names := make([]string, 0, len(config.Aliases))
+		for name := range config.Aliases {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		aliases := make([]map[string]string, 0, len(names))
+		for _, name := range names {
+			aliases = append(aliases, map[string]string{"alias": "@" + name, "id": config.Aliases[name]})
+		}
+
+		formatter.PrintSuccess(map[string]interface{}{
+			"count":   len(aliases),
+			"aliases": aliases,
+		})
+		return nil
+	},
+}
+
+func init() {
+	aliasCmd.AddCommand(aliasSetCmd)
+	aliasCmd.AddCommand(aliasRemoveCmd)
+	aliasCmd.AddCommand(aliasListCmd)
+}
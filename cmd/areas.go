@@ -0,0 +1,187 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/yourusername/things3-cli/pkg/formatter"
+	"github.com/yourusername/things3-cli/pkg/things"
+)
+
+// areasCmd groups the area management subcommands
+var areasCmd = &cobra.Command{
+	Use:   "areas",
+	Short: "List, create, rename, and delete areas",
+	Long: `Manage areas of responsibility. The URL scheme cannot create or change
+areas, so add, rename, and delete use AppleScript.`,
+}
+
+// areasListCmd prints all areas
+var areasListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List areas in app order",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		db, err := things.OpenDB()
+		if err != nil {
+			formatter.PrintError("Failed to open Things database", "DATABASE_ERROR", err.Error())
+			return nil
+		}
+		areas, err := db.ListAreas()
+		if err != nil {
+			formatter.PrintError("Failed to query Things database", "DATABASE_ERROR", err.Error())
+			return nil
+		}
+		if areas == nil {
+			areas = []things.Area{}
+		}
+		areas = things.PageOf(listPage(), areas)
+
+		formatter.PrintSuccess(map[string]interface{}{
+			"count": len(areas),
+			"areas": areas,
+		})
+		return nil
+	},
+}
+
+// areasAddCmd creates an area
+var areasAddCmd = &cobra.Command{
+	Use:   "add",
+	Short: "Create an area",
+	Long: `Create a new area.
+
+Example:
+  things areas add --title "Health"`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		title, _ := cmd.Flags().GetString("title")
+		title = strings.TrimSpace(title)
+		if title == "" {
+			formatter.PrintError("Area title (--title) is required", "INVALID_ARGUMENTS", "")
+			return nil
+		}
+
+		id, err := things.CreateArea(title)
+		if err != nil {
+			if heldByDryRun(err) {
+				return nil
+			}
+			formatter.PrintError("Failed to create area", "THINGS_ERROR", err.Error())
+			return nil
+		}
+
+		formatter.PrintSuccess(things.Area{ID: id, Title: title})
+		return nil
+	},
+}
+
+// areasRenameCmd changes the title of an area
+var areasRenameCmd = &cobra.Command{
+	Use:   "rename",
+	Short: "Rename an area",
+	Long: `Change the title of an area, chosen by ID, @alias, or current title.
+
+Example:
+  things areas rename --id "Health" --title "Health & Fitness"`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ref, _ := cmd.Flags().GetString("id")
+		title, _ := cmd.Flags().GetString("title")
+		title = strings.TrimSpace(title)
+		if ref == "" || title == "" {
+			formatter.PrintError("Area (--id) and new title (--title) are required", "INVALID_ARGUMENTS", "")
+			return nil
+		}
+
+		area, ok := lookupArea(ref)
+		if !ok {
+			return nil
+		}
+		if err := things.RenameArea(area.ID, title); err != nil {
+			if heldByDryRun(err) {
+				return nil
+			}
+			formatter.PrintError("Failed to rename area", "THINGS_ERROR", err.Error())
+			return nil
+		}
+
+		formatter.PrintSuccess(map[string]interface{}{
+			"id":             area.ID,
+			"title":          title,
+			"previous_title": area.Title,
+		})
+		return nil
+	},
+}
+
+// areasDeleteCmd deletes an area
+var areasDeleteCmd = &cobra.Command{
+	Use:   "delete",
+	Short: "Delete an area",
+	Long: `Delete an area, chosen by ID, @alias, or title. Things moves the projects and
+to-dos in the area to the Trash along with it, so this asks first unless --yes
+is given.
+
+Example:
+  things areas delete --id "Old Job" --yes`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ref, _ := cmd.Flags().GetString("id")
+		if ref == "" {
+			formatter.PrintError("Area (--id) is required", "INVALID_ARGUMENTS", "")
+			return nil
+		}
+
+		area, ok := lookupArea(ref)
+		if !ok {
+			return nil
+		}
+		question := fmt.Sprintf("Delete area %q and move everything in it to the Trash?", area.Title)
+		if !confirmDestructive(question, "Deleting an area") {
+			return nil
+		}
+		if err := things.DeleteArea(area.ID); err != nil {
+			if heldByDryRun(err) {
+				return nil
+			}
+			formatter.PrintError("Failed to delete area", "THINGS_ERROR", err.Error())
+			return nil
+		}
+
+		formatter.PrintSuccess(map[string]interface{}{
+			"id":      area.ID,
+			"title":   area.Title,
+			"deleted": true,
+		})
+		return nil
+	},
+}
+
+// lookupArea finds an area by ID, @alias, or title.
+// Errors are printed and reported through the boolean result.
+func lookupArea(ref string) (*things.Area, bool) {
+	db, err := things.OpenDB()
+	if err != nil {
+		formatter.PrintError("Failed to open Things database", "DATABASE_ERROR", err.Error())
+		return nil, false
+	}
+	area, err := db.FindArea(ref)
+	if err != nil {
+		printLookupError("Area", ref, err)
+		return nil, false
+	}
+	return area, true
+}
+
+func init() {
+	pageable(areasListCmd)
+	areasAddCmd.Flags().String("title", "", "Area title (required)")
+
+	areasRenameCmd.Flags().String("id", "", "Area ID, @alias, or title (required)")
+	areasRenameCmd.Flags().String("title", "", "New title (required)")
+
+	areasDeleteCmd.Flags().String("id", "", "Area ID, @alias, or title (required)")
+
+	areasCmd.AddCommand(areasListCmd)
+	areasCmd.AddCommand(areasAddCmd)
+	areasCmd.AddCommand(areasRenameCmd)
+	areasCmd.AddCommand(areasDeleteCmd)
+}
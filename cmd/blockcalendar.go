@@ -0,0 +1,101 @@
+package cmd
+
+import (
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/yourusername/things3-cli/pkg/formatter"
+	"github.com/yourusername/things3-cli/pkg/things"
+	"github.com/yourusername/things3-cli/pkg/util"
+)
+
+// blockCalendarCmd reserves calendar time for a day's planned to-dos
+var blockCalendarCmd = &cobra.Command{
+	Use:   "block-calendar",
+	Short: "Block out calendar time for a day's to-dos with estimates",
+	Long: `Turn the to-dos scheduled for a day into tentative Calendar.app events, so
+planned work shows up when colleagues check your availability. Only items with
+a time estimate tag such as 15m, 1h, or 1h30m get a block; blocks run back to
+back from --start in Today order. Each event links back to its to-do.
+
+The calendar defaults to focus_calendar from the config. Uses AppleScript.
+
+Examples:
+  things block-calendar --day tomorrow --calendar "Work"
+  things block-calendar --day today --start 13:30 --dry-run`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		dayFlag, _ := cmd.Flags().GetString("day")
+		now := util.Now()
+		day, ok := util.ParseDate(dayFlag, now)
+		if !ok {
+			formatter.PrintError("Invalid day: "+dayFlag, "INVALID_ARGUMENTS", "")
+			return nil
+		}
+
+		startFlag, _ := cmd.Flags().GetString("start")
+		clock, err := time.Parse("15:04", startFlag)
+		if err != nil {
+			formatter.PrintError("Invalid start time (use HH:MM): "+startFlag, "INVALID_ARGUMENTS", "")
+			return nil
+		}
+		start := time.Date(day.Year(), day.Month(), day.Day(), clock.Hour(), clock.Minute(), 0, 0, day.Location())
+
+		calendar, _ := cmd.Flags().GetString("calendar")
+		if calendar == "" {
+			config, err := util.LoadConfig()
+			if err != nil {
+				formatter.PrintError("Failed to load config", "CONFIG_ERROR", err.Error())
+				return nil
+			}
+			calendar = config.FocusCalendar
+		}
+		if calendar == "" && !dryRunMode {
+			formatter.PrintError("Provide --calendar or set focus_calendar in the config", "INVALID_ARGUMENTS", "")
+			return nil
+		}
+
+		db, err := things.OpenDB()
+		if err != nil {
+			formatter.PrintError("Failed to open Things database", "DATABASE_ERROR", err.Error())
+			return nil
+		}
+
+		// Today also holds to-dos carried over from earlier days; other days
+		// only have what was scheduled for them.
+		var items []things.Item
+		if day.Format(util.DateLayout) == now.Format(util.DateLayout) {
+			items, err = db.Today(day)
+		} else {
+			items, err = db.ScheduledOn(day)
+		}
+		if err != nil {
+			formatter.PrintError("Failed to query Things database", "DATABASE_ERROR", err.Error())
+			return nil
+		}
+
+		blocks := things.PlanBlocks(items, start)
+		report := map[string]interface{}{
+			"date":     day.Format(util.DateLayout),
+			"calendar": calendar,
+			"count":    len(blocks),
+			"skipped":  len(items) - len(blocks),
+			"blocks":   blocks,
+			"dry_run":  dryRunMode,
+		}
+		if !dryRunMode {
+			if err := things.AddCalendarBlocks(calendar, blocks); err != nil {
+				formatter.PrintError("Failed to create calendar events", "THINGS_ERROR", err.Error())
+				return nil
+			}
+		}
+
+		formatter.PrintSuccess(report)
+		return nil
+	},
+}
+
+func init() {
+	blockCalendarCmd.Flags().String("day", "today", "Day to plan (today, tomorrow, weekday, or date)")
+	blockCalendarCmd.Flags().String("start", "09:00", "Start time of the first block (HH:MM)")
+	blockCalendarCmd.Flags().String("calendar", "", "Calendar to add events to (defaults to focus_calendar)")
+}
@@ -0,0 +1,206 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/yourusername/things3-cli/pkg/formatter"
+	"github.com/yourusername/things3-cli/pkg/things"
+	"github.com/yourusername/things3-cli/pkg/util"
+)
+
+// bulkCmd groups commands that update every item matching a filter
+var bulkCmd = &cobra.Command{
+	Use:   "bulk",
+	Short: "Update all items matching a search filter",
+}
+
+// bulkTagCmd adds and removes tags on every item matching a filter
+var bulkTagCmd = &cobra.Command{
+	Use:   "tag",
+	Short: "Add or remove tags on all items matching a filter",
+	Long: `Add or remove tags on every to-do and project matching a filter. The filter
+uses the search query language: plain words, the status:, completed:,
+project:, and when: predicates, and overdue, joined by spaces or AND. Matching items are read from the
+local Things database and updated in one json payload; items whose tags would
+not change are skipped. Asks before changing anything unless --yes is given.
+Requires an auth token.
+
+Examples:
+  things bulk tag --filter 'project:"Website" AND status:open' --add launch
+  things bulk tag --filter 'status:open invoice' --remove waiting`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		filter, _ := cmd.Flags().GetString("filter")
+		if filter == "" {
+			formatter.PrintError("Filter (--filter) is required", "INVALID_ARGUMENTS", "")
+			return nil
+		}
+
+		add := tagFlagValues(cmd, "add")
+		remove := tagFlagValues(cmd, "remove")
+		if len(add) == 0 && len(remove) == 0 {
+			formatter.PrintError("Provide --add or --remove", "INVALID_ARGUMENTS", "")
+			return nil
+		}
+
+		items, ok := bulkMatches(filter)
+		if !ok {
+			return nil
+		}
+
+		var ops []things.JSONOperation
+		modified := []string{}
+		for _, item := range items {
+			tags := editTags(item.Tags, add, remove)
+			if sameTags(item.Tags, tags) {
+				continue
+			}
+			if tags == nil {
+				tags = []string{}
+			}
+			ops = append(ops, things.NewUpdateOperation(item.Type, item.ID, map[string]interface{}{"tags": tags}))
+			modified = append(modified, item.ID)
+		}
+
+		report := map[string]interface{}{
+			"matched":  len(items),
+			"modified": len(modified),
+			"ids":      modified,
+		}
+		if len(ops) > 0 {
+			question := fmt.Sprintf("Change the tags of %d items matching %q?", len(ops), filter)
+			if !confirmDestructive(question, "Changing tags on matching items") {
+				return nil
+			}
+			result, ok := executeJSONOperations(cmd, ops)
+			if !ok {
+				return nil
+			}
+			report["result"] = result
+		}
+
+		formatter.PrintSuccess(report)
+		return nil
+	},
+}
+
+// bulkScheduleCmd reschedules every item matching a filter
+var bulkScheduleCmd = &cobra.Command{
+	Use:   "schedule",
+	Short: "Reschedule all items matching a filter",
+	Long: `Set the when date on every to-do and project matching a filter, in one json
+payload. The filter uses the search query language; "overdue" selects open
+items whose deadline has passed. Use --dry-run to list what would change.
+Asks before changing anything unless --yes is given. Requires an auth token.
+
+Examples:
+  things bulk schedule --filter overdue --when today
+  things bulk schedule --filter 'project:"Website" AND status:open' --when "next monday" --dry-run`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		filter, _ := cmd.Flags().GetString("filter")
+		if filter == "" {
+			formatter.PrintError("Filter (--filter) is required", "INVALID_ARGUMENTS", "")
+			return nil
+		}
+
+		when, _ := cmd.Flags().GetString("when")
+		if when == "" {
+			formatter.PrintError("Schedule (--when) is required", "INVALID_ARGUMENTS", "")
+			return nil
+		}
+
+		items, ok := bulkMatches(filter)
+		if !ok {
+			return nil
+		}
+
+		target := util.ResolveWhen(when, util.Now())
+		changes := make([]map[string]interface{}, 0, len(items))
+		ops := make([]things.JSONOperation, 0, len(items))
+		for _, item := range items {
+			from := item.When
+			if item.StartDate != "" {
+				from = item.StartDate
+			}
+			changes = append(changes, map[string]interface{}{
+				"id":       item.ID,
+				"title":    item.Title,
+				"deadline": item.Deadline,
+				"from":     from,
+				"to":       target,
+			})
+			ops = append(ops, things.NewUpdateOperation(item.Type, item.ID, map[string]interface{}{"when": when}))
+		}
+
+		report := map[string]interface{}{
+			"matched": len(items),
+			"items":   changes,
+		}
+		if dryRunMode || len(ops) == 0 {
+			report["dry_run"] = dryRunMode
+			formatter.PrintSuccess(report)
+			return nil
+		}
+
+		question := fmt.Sprintf("Reschedule %d items matching %q to %s?", len(ops), filter, when)
+		if !confirmDestructive(question, "Rescheduling matching items") {
+			return nil
+		}
+		result, ok := executeJSONOperations(cmd, ops)
+		if !ok {
+			return nil
+		}
+		report["result"] = result
+		formatter.PrintSuccess(report)
+		return nil
+	},
+}
+
+// bulkMatches returns the to-dos and projects matching a filter.
+// Errors are printed and reported through the boolean result.
+func bulkMatches(filter string) ([]things.Item, bool) {
+	query, err := things.ParseQuery(filter)
+	if err != nil {
+		formatter.PrintError("Invalid filter", "INVALID_ARGUMENTS", err.Error())
+		return nil, false
+	}
+
+	db, err := things.OpenDB()
+	if err != nil {
+		formatter.PrintError("Failed to open Things database", "DATABASE_ERROR", err.Error())
+		return nil, false
+	}
+	items, err := db.Search(query)
+	if err != nil {
+		printLookupError("Project", query.Project, err)
+		return nil, false
+	}
+	return items, true
+}
+
+// sameTags reports whether two tag lists hold the same tags in any order
+func sameTags(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for _, tag := range a {
+		if !util.TagsContain(b, tag) {
+			return false
+		}
+	}
+	return true
+}
+
+func init() {
+	bulkTagCmd.Flags().String("filter", "", "Search filter selecting the items (required)")
+	bulkTagCmd.Flags().StringArray("add", []string{}, "Tag to add (repeat flag or comma-separate)")
+	bulkTagCmd.Flags().StringArray("remove", []string{}, "Tag to remove (repeat flag or comma-separate)")
+	bulkTagCmd.Flags().String("auth-token", "", "Things auth token (overrides config/ENV)")
+
+	bulkScheduleCmd.Flags().String("filter", "", "Search filter selecting the items (required)")
+	bulkScheduleCmd.Flags().String("when", "", "When to schedule (today, evening/tonight, tomorrow, anytime, someday, or date)")
+	bulkScheduleCmd.Flags().String("auth-token", "", "Things auth token (overrides config/ENV)")
+
+	bulkCmd.AddCommand(bulkTagCmd)
+	bulkCmd.AddCommand(bulkScheduleCmd)
+}
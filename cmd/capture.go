@@ -0,0 +1,422 @@
+package cmd
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"net/url"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"sort"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/yourusername/things3-cli/pkg/formatter"
+	"github.com/yourusername/things3-cli/pkg/things"
+	"github.com/yourusername/things3-cli/pkg/util"
+)
+
+// captureSettleTime is how long a capture file must be unmodified before it is
+// imported, so files still being written or synced are left alone.
+const captureSettleTime = 2 * time.Second
+
+// captureCmd creates a to-do from the clipboard and groups the other capture subcommands
+var captureCmd = &cobra.Command{
+	Use:   "capture",
+	Short: "Capture to-dos into the Inbox from the clipboard or outside sources",
+	Long: `Create a to-do in the Inbox from the text on the clipboard. The first line
+becomes the title and the rest the notes. With --url-mode, a link on the
+clipboard is put at the top of the notes, and a clipboard holding only a link
+is titled with its address.
+
+The subcommands capture from a synced folder or a screenshot instead.
+
+Examples:
+  things capture
+  things capture --url-mode --when today --tags reading`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		text, err := things.ReadClipboard()
+		if err != nil {
+			formatter.PrintError("Failed to read the clipboard", "THINGS_ERROR", err.Error())
+			return nil
+		}
+
+		urlMode, _ := cmd.Flags().GetBool("url-mode")
+		title, notes := things.SplitClipboard(text, urlMode)
+		if title == "" {
+			formatter.PrintError("The clipboard has no text to capture", "INVALID_ARGUMENTS", "")
+			return nil
+		}
+		title = captureTitle(title, title)
+
+		params := map[string]string{"title": title}
+		if notes != "" {
+			params["notes"] = notes
+		}
+		addStringParam(cmd, params, "when", "when")
+		addStringParam(cmd, params, "tags", "tags")
+		addStringParam(cmd, params, "list", "list")
+		return runAction("add", params, things.ExecuteOptions{})
+	},
+}
+
+// captureWatchCmd imports capture files dropped into a folder
+var captureWatchCmd = &cobra.Command{
+	Use:   "watch",
+	Short: "Import capture files dropped into a synced folder",
+	Long: `Watch a folder, typically one synced by Dropbox or iCloud, for small capture
+files written by phones or other machines. Each file becomes one Inbox to-do:
+
+  .txt   first line in quick-add syntax (#tag @when !deadline //notes);
+         any further lines become the notes
+  .json  {"title", "notes", "when", "deadline", "tags", "checklist"}
+
+Imported files are moved to an archive folder. Files that cannot be parsed are
+moved to a "failed" folder; files Things rejected are retried on the next scan.
+A file that was imported but could not be archived is not imported again; only
+the move to the archive is retried. With --dry-run each file is reported once
+under "held" with the URL that would have been opened, and no file is moved.
+The folder defaults to capture_dir from the config.
+
+Examples:
+  things capture watch --dir ~/Dropbox/things-capture
+  things capture watch --once`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		dir, _ := cmd.Flags().GetString("dir")
+		if dir == "" {
+			config, err := util.LoadConfig()
+			if err != nil {
+				formatter.PrintError("Failed to load config", "CONFIG_ERROR", err.Error())
+				return nil
+			}
+			dir = config.CaptureDir
+		}
+		if dir == "" {
+			formatter.PrintError("Provide --dir or set capture_dir in the config", "INVALID_ARGUMENTS", "")
+			return nil
+		}
+		dir, err := util.ExpandHomePath(dir)
+		if err != nil {
+			formatter.PrintError("Invalid capture folder", "FILE_ERROR", err.Error())
+			return nil
+		}
+		if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+			formatter.PrintError("Capture folder not found: "+dir, "FILE_ERROR", "")
+			return nil
+		}
+
+		archive, _ := cmd.Flags().GetString("archive")
+		if archive == "" {
+			archive = filepath.Join(dir, "archive")
+		} else if archive, err = util.ExpandHomePath(archive); err != nil {
+			formatter.PrintError("Invalid archive folder", "FILE_ERROR", err.Error())
+			return nil
+		}
+
+		client, err := things.NewClient()
+		if err != nil {
+			formatter.PrintError("Failed to initialize Things client", "CLIENT_ERROR", err.Error())
+			return nil
+		}
+		client.Confirm = confirmMassMutation
+
+		watcher := &captureWatcher{client: client, dir: dir, archive: archive, failed: filepath.Join(dir, "failed"), handled: make(map[string]string)}
+
+		if once, _ := cmd.Flags().GetBool("once"); once {
+			formatter.PrintSuccess(watcher.scan())
+			return nil
+		}
+
+		interval, _ := cmd.Flags().GetDuration("interval")
+		if interval < time.Second {
+			interval = time.Second
+		}
+
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+
+		log.Printf("Watching %s for capture files every %s", dir, interval)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			report := watcher.scan()
+			for _, entry := range report.Imported {
+				if !formatter.PrintRecord(map[string]interface{}{"event": "imported", "entry": entry}) {
+					log.Printf("Imported %s as %q (%s)", entry.File, entry.Title, entry.ThingsID)
+				}
+			}
+			for _, entry := range report.Held {
+				if !formatter.PrintRecord(map[string]interface{}{"event": "held", "entry": entry}) {
+					log.Printf("Dry run: would import %s as %q: %s", entry.File, entry.Title, entry.URL)
+				}
+			}
+			for _, entry := range report.Failed {
+				if !formatter.PrintRecord(map[string]interface{}{"event": "failed", "entry": entry}) {
+					log.Printf("Failed to import %s: %s", entry.File, entry.Error)
+				}
+			}
+
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-ticker.C:
+			}
+		}
+	},
+}
+
+// captureScreenshotCmd creates a to-do from the text in a screenshot
+var captureScreenshotCmd = &cobra.Command{
+	Use:   "screenshot",
+	Short: "Create a to-do from the text in an interactive screenshot",
+	Long: `Take an interactive screenshot (select a window or drag a region), recognize
+its text with the macOS Vision framework, and create a to-do with the text and
+a link to the image in its notes. The title defaults to the first line of
+text. With --shortcut, the named Shortcuts shortcut does the recognition
+instead; it receives the image and should output text.
+
+Screenshots are kept in the screenshots folder next to the config file unless
+--dir is given.
+
+Examples:
+  things capture screenshot
+  things capture screenshot --title "Error from CI" --tags work --when today
+  things capture screenshot --shortcut "Extract Text"`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		dir, _ := cmd.Flags().GetString("dir")
+		if dir != "" {
+			var err error
+			if dir, err = util.ExpandHomePath(dir); err != nil {
+				formatter.PrintError("Invalid screenshots folder", "FILE_ERROR", err.Error())
+				return nil
+			}
+		}
+		path, err := things.ScreenshotPath(dir)
+		if err != nil {
+			formatter.PrintError("Failed to prepare screenshot", "FILE_ERROR", err.Error())
+			return nil
+		}
+
+		if err := things.CaptureScreenshot(path); err != nil {
+			if errors.Is(err, things.ErrCaptureCanceled) {
+				formatter.PrintError("Screenshot canceled", "INVALID_ARGUMENTS", "")
+				return nil
+			}
+			formatter.PrintError("Failed to take screenshot", "THINGS_ERROR", err.Error())
+			return nil
+		}
+
+		var text string
+		if shortcut, _ := cmd.Flags().GetString("shortcut"); shortcut != "" {
+			text, err = things.RecognizeTextWithShortcut(shortcut, path)
+		} else {
+			text, err = things.RecognizeText(path)
+		}
+		if err != nil {
+			formatter.PrintError("Failed to recognize text", "THINGS_ERROR", err.Error())
+			return nil
+		}
+
+		title, _ := cmd.Flags().GetString("title")
+		if title == "" {
+			title = captureTitle(text, "Screenshot "+time.Now().Format("2006-01-02 15:04"))
+		}
+
+		link := (&url.URL{Scheme: "file", Path: path}).String()
+		notes := "Screenshot: " + link
+		if text != "" {
+			notes = text + "\n\n" + notes
+		}
+
+		params := map[string]string{"title": title, "notes": notes}
+		addStringParam(cmd, params, "when", "when")
+		addStringParam(cmd, params, "tags", "tags")
+		addStringParam(cmd, params, "list", "list")
+		return runAction("add", params, things.ExecuteOptions{})
+	},
+}
+
+// captureTitle picks a to-do title from captured text: its first non-empty
+// line, shortened to a reasonable length, or fallback if there is none
+func captureTitle(text, fallback string) string {
+	const maxTitle = 80
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		if runes := []rune(line); len(runes) > maxTitle {
+			line = strings.TrimSpace(string(runes[:maxTitle-1])) + "…"
+		}
+		return line
+	}
+	return fallback
+}
+
+// captureWatcher imports capture files from one folder
+type captureWatcher struct {
+	client  *things.Client
+	dir     string
+	archive string
+	failed  string
+
+	// handled holds the content hash of each file that was dealt with but is
+	// still in the folder, by name: added to Things but not archived, or held
+	// back by --dry-run. Such files are not imported or reported again.
+	handled map[string]string
+}
+
+type captureEntry struct {
+	File     string `json:"file"`
+	Title    string `json:"title,omitempty"`
+	ThingsID string `json:"things_id,omitempty"`
+	URL      string `json:"url,omitempty"`
+	Error    string `json:"error,omitempty"`
+}
+
+type captureReport struct {
+	Imported []captureEntry `json:"imported"`
+	Failed   []captureEntry `json:"failed"`
+	// Held lists the files --dry-run kept from Things
+	Held []captureEntry `json:"held,omitempty"`
+}
+
+// scan imports every settled capture file currently in the folder
+func (w *captureWatcher) scan() captureReport {
+	report := captureReport{Imported: []captureEntry{}, Failed: []captureEntry{}}
+
+	entries, err := os.ReadDir(w.dir)
+	if err != nil {
+		report.Failed = append(report.Failed, captureEntry{File: w.dir, Error: err.Error()})
+		return report
+	}
+
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		if entry.Type().IsRegular() && things.IsCaptureFile(entry.Name()) {
+			names = append(names, entry.Name())
+		}
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		entry, imported, done := w.importFile(name)
+		if !done {
+			continue
+		}
+		switch {
+		case imported:
+			report.Imported = append(report.Imported, entry)
+		case entry.URL != "":
+			report.Held = append(report.Held, entry)
+		default:
+			report.Failed = append(report.Failed, entry)
+		}
+	}
+	return report
+}
+
+// importFile imports one capture file. done is false when the file was left
+// untouched because it is still being written.
+func (w *captureWatcher) importFile(name string) (entry captureEntry, imported bool, done bool) {
+	entry = captureEntry{File: name}
+	path := filepath.Join(w.dir, name)
+
+	info, err := os.Stat(path)
+	if err != nil || time.Since(info.ModTime()) < captureSettleTime {
+		return entry, false, false
+	}
+	if info.Size() > things.MaxCaptureFileSize {
+		entry.Error = fmt.Sprintf("file is larger than %d bytes", things.MaxCaptureFileSize)
+		w.moveTo(w.failed, name)
+		return entry, false, true
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		entry.Error = err.Error()
+		return entry, false, true
+	}
+	sum := sha256.Sum256(data)
+	hash := hex.EncodeToString(sum[:])
+	if w.handled[name] == hash {
+		// Already in Things; only the move to the archive is left to retry
+		if !dryRunMode && w.moveTo(w.archive, name) == nil {
+			delete(w.handled, name)
+		}
+		return entry, false, false
+	}
+
+	params, err := things.ParseCaptureFile(name, data)
+	if err != nil {
+		entry.Error = err.Error()
+		if dryRunMode {
+			w.handled[name] = hash
+		}
+		w.moveTo(w.failed, name)
+		return entry, false, true
+	}
+	entry.Title = params["title"]
+
+	callback, err := w.client.Execute("add", params, things.ExecuteOptions{})
+	var run *things.DryRunError
+	if errors.As(err, &run) {
+		w.handled[name] = hash
+		entry.URL = run.URL
+		return entry, false, true
+	}
+	if err != nil {
+		// Left in place so the next scan retries it
+		entry.Error = err.Error()
+		return entry, false, true
+	}
+	entry.ThingsID = things.NormalizeResponse("add", callback).ThingsID
+
+	if err := w.moveTo(w.archive, name); err != nil {
+		w.handled[name] = hash
+		entry.Error = "imported but not archived: " + err.Error()
+	}
+	return entry, true, true
+}
+
+// moveTo moves a capture file into dir, prefixing a timestamp to keep names
+// unique. Under --dry-run the folder is left as it is.
+func (w *captureWatcher) moveTo(dir, name string) error {
+	if dryRunMode {
+		return nil
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	target := filepath.Join(dir, time.Now().Format("20060102-150405")+"-"+name)
+	return os.Rename(filepath.Join(w.dir, name), target)
+}
+
+func init() {
+	captureWatchCmd.Flags().String("dir", "", "Folder to watch (defaults to capture_dir from the config)")
+	captureWatchCmd.Flags().String("archive", "", "Folder for imported files (defaults to DIR/archive)")
+	captureWatchCmd.Flags().Duration("interval", 10*time.Second, "How often to scan the folder")
+	captureWatchCmd.Flags().Bool("once", false, "Scan once, print a report, and exit")
+
+	captureScreenshotCmd.Flags().String("title", "", "To-do title (defaults to the first line of recognized text)")
+	captureScreenshotCmd.Flags().String("when", "", "When to schedule (today, evening/tonight, tomorrow, anytime, someday, or date)")
+	captureScreenshotCmd.Flags().String("tags", "", "Comma-separated tags")
+	captureScreenshotCmd.Flags().String("list", "", "Project or area to add to (defaults to the Inbox)")
+	captureScreenshotCmd.Flags().String("dir", "", "Folder to keep screenshots in")
+	captureScreenshotCmd.Flags().String("shortcut", "", "Shortcuts shortcut to recognize text with instead of Vision")
+
+	captureCmd.Flags().Bool("url-mode", false, "Put a link from the clipboard at the top of the notes")
+	captureCmd.Flags().String("when", "", "When to schedule (today, evening/tonight, tomorrow, anytime, someday, or date)")
+	captureCmd.Flags().String("tags", "", "Comma-separated tags")
+	captureCmd.Flags().String("list", "", "Project or area to add to (defaults to the Inbox)")
+
+	captureCmd.AddCommand(captureWatchCmd)
+	captureCmd.AddCommand(captureScreenshotCmd)
+}
@@ -0,0 +1,192 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/yourusername/things3-cli/pkg/formatter"
+	"github.com/yourusername/things3-cli/pkg/things"
+)
+
+// checklistCmd groups the checklist subcommands
+var checklistCmd = &cobra.Command{
+	Use:   "checklist",
+	Short: "List, add, and check off checklist items of a to-do",
+}
+
+// checklistListCmd prints the checklist of a to-do
+var checklistListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the checklist items of a to-do",
+	Long: `List the checklist of a to-do from the local Things database. Items are
+numbered from 1 in display order; use these numbers with checklist check.
+
+Example:
+  things checklist list --id "THINGS-ID"`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		item, checklist, ok := loadChecklist(cmd)
+		if !ok {
+			return nil
+		}
+		checklist = things.PageOf(listPage(), checklist)
+
+		formatter.PrintSuccess(map[string]interface{}{
+			"id":        item.ID,
+			"title":     item.Title,
+			"count":     len(checklist),
+			"checklist": checklist,
+		})
+		return nil
+	},
+}
+
+// checklistAddCmd appends items to the checklist of a to-do
+var checklistAddCmd = &cobra.Command{
+	Use:   "add",
+	Short: "Append items to the checklist of a to-do",
+	Long: `Append one or more items to the end of a to-do's checklist. Requires an auth token.
+
+Examples:
+  things checklist add --id "THINGS-ID" --item "Book venue"
+  things checklist add --id "THINGS-ID" --item "Draft agenda" --item "Send invites"`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		id, _ := cmd.Flags().GetString("id")
+		if id == "" {
+			formatter.PrintError("To-do ID (--id) is required", "INVALID_ARGUMENTS", "")
+			return nil
+		}
+
+		entries, _ := cmd.Flags().GetStringArray("item")
+		var titles []string
+		for _, entry := range entries {
+			if title := strings.TrimSpace(entry); title != "" {
+				titles = append(titles, title)
+			}
+		}
+		if len(titles) == 0 {
+			formatter.PrintError("At least one --item is required", "INVALID_ARGUMENTS", "")
+			return nil
+		}
+
+		if _, ok := lookupToDo(id); !ok {
+			return nil
+		}
+
+		params := map[string]string{
+			"id":                     id,
+			"append-checklist-items": strings.Join(titles, "\n"),
+		}
+		addStringParam(cmd, params, "auth-token", "auth-token")
+		return runAction("update", params, things.ExecuteOptions{RequiresAuth: true, UseAuthIfAvailable: true})
+	},
+}
+
+// checklistCheckCmd marks a checklist item as completed
+var checklistCheckCmd = &cobra.Command{
+	Use:   "check",
+	Short: "Check off an item in the checklist of a to-do",
+	Long: `Mark a checklist item as completed, or reopen it with --uncheck. The item is
+chosen by its number from checklist list. The URL scheme cannot address single
+checklist items, so the whole checklist is read from the database and rewritten
+with the new state. Requires an auth token.
+
+Examples:
+  things checklist check --id "THINGS-ID" --item-index 2
+  things checklist check --id "THINGS-ID" --item-index 2 --uncheck`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		index, _ := cmd.Flags().GetInt("item-index")
+		uncheck, _ := cmd.Flags().GetBool("uncheck")
+
+		item, checklist, ok := loadChecklist(cmd)
+		if !ok {
+			return nil
+		}
+		if len(checklist) == 0 {
+			formatter.PrintError("To-do has no checklist items: "+item.ID, "NOT_FOUND", "")
+			return nil
+		}
+		if index < 1 || index > len(checklist) {
+			formatter.PrintError(
+				fmt.Sprintf("Item index must be between 1 and %d", len(checklist)),
+				"INVALID_ARGUMENTS",
+				"",
+			)
+			return nil
+		}
+
+		// Only the chosen item changes; the others, canceled ones included,
+		// are written back as they are
+		entries := make([]things.JSONOperation, len(checklist))
+		for i, entry := range checklist {
+			if entry.Index == index {
+				entries[i] = things.NewChecklistEntry(entry.Title, !uncheck)
+			} else {
+				entries[i] = things.ExistingChecklistEntry(entry)
+			}
+		}
+
+		op := things.NewUpdateOperation("to-do", item.ID, map[string]interface{}{
+			"checklist-items": entries,
+		})
+		return runJSONOperations(cmd, []things.JSONOperation{op})
+	},
+}
+
+// lookupToDo reads an item from the database and checks that it is a to-do.
+// Errors are printed and reported through the boolean result.
+func lookupToDo(id string) (*things.Item, bool) {
+	items, ok := lookupItems([]string{id})
+	if !ok {
+		return nil, false
+	}
+	if items[0].Type != "to-do" {
+		formatter.PrintError("Only to-dos have checklists: "+id, "INVALID_ARGUMENTS", "")
+		return nil, false
+	}
+	return &items[0], true
+}
+
+// loadChecklist reads the to-do named by --id and its checklist
+func loadChecklist(cmd *cobra.Command) (*things.Item, []things.ChecklistItem, bool) {
+	id, _ := cmd.Flags().GetString("id")
+	if id == "" {
+		formatter.PrintError("To-do ID (--id) is required", "INVALID_ARGUMENTS", "")
+		return nil, nil, false
+	}
+
+	item, ok := lookupToDo(id)
+	if !ok {
+		return nil, nil, false
+	}
+
+	db, err := things.OpenDB()
+	if err != nil {
+		formatter.PrintError("Failed to open Things database", "DATABASE_ERROR", err.Error())
+		return nil, nil, false
+	}
+	checklist, err := db.Checklist(item.ID)
+	if err != nil {
+		formatter.PrintError("Failed to query Things database", "DATABASE_ERROR", err.Error())
+		return nil, nil, false
+	}
+	return item, checklist, true
+}
+
+func init() {
+	pageable(checklistListCmd)
+	checklistListCmd.Flags().String("id", "", "To-do ID (required)")
+
+	checklistAddCmd.Flags().String("id", "", "To-do ID (required)")
+	checklistAddCmd.Flags().StringArray("item", []string{}, "Checklist item to append (repeat flag)")
+	checklistAddCmd.Flags().String("auth-token", "", "Things auth token (overrides config/ENV)")
+
+	checklistCheckCmd.Flags().String("id", "", "To-do ID (required)")
+	checklistCheckCmd.Flags().Int("item-index", 0, "Number of the item as shown by checklist list (required)")
+	checklistCheckCmd.Flags().Bool("uncheck", false, "Mark the item as not completed instead")
+	checklistCheckCmd.Flags().String("auth-token", "", "Things auth token (overrides config/ENV)")
+
+	checklistCmd.AddCommand(checklistListCmd)
+	checklistCmd.AddCommand(checklistAddCmd)
+	checklistCmd.AddCommand(checklistCheckCmd)
+}
@@ -1,8 +1,8 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
-	"os"
 	"strings"
 
 	"github.com/spf13/cobra"
@@ -41,30 +41,73 @@ func addStringArrayParam(cmd *cobra.Command, params map[string]string, flagName,
 	}
 }
 
+// addCancelReason records --reason in the notes of an item being canceled.
+// It prints an error and returns false when --reason is used without --canceled.
+func addCancelReason(cmd *cobra.Command, params map[string]string) bool {
+	reason, _ := cmd.Flags().GetString("reason")
+	if strings.TrimSpace(reason) == "" {
+		return true
+	}
+	if params["canceled"] != "true" {
+		formatter.PrintError("--reason requires --canceled", "INVALID_ARGUMENTS", "")
+		return false
+	}
+	things.AppendCancelReason(params, reason)
+	return true
+}
+
 func runAction(action string, params map[string]string, opts things.ExecuteOptions) error {
+	result, ok := executeAction(action, params, opts)
+	if !ok {
+		return nil
+	}
+
+	formatter.PrintActionResult(result, things.SummarizeAction(action, params, result))
+	return nil
+}
+
+// executeAction runs a Things action, printing any error and reporting success through the boolean result
+func executeAction(action string, params map[string]string, opts things.ExecuteOptions) (things.ActionResult, bool) {
 	client, err := things.NewClient()
 	if err != nil {
 		formatter.PrintError("Failed to initialize Things client", "CLIENT_ERROR", err.Error())
-		return nil
+		return things.ActionResult{}, false
 	}
 
+	client.Confirm = confirmMassMutation
+
 	callback, err := client.Execute(action, params, opts)
 	if err != nil {
+		if dryRunErr, ok := err.(*things.DryRunError); ok {
+			printDryRun(dryRunErr)
+			return things.ActionResult{}, false
+		}
+		if confirmErr, ok := err.(*things.ConfirmationError); ok {
+			formatter.PrintError(confirmErr.Error(), "CONFIRMATION_REQUIRED", "Re-run with --yes to proceed")
+			return things.ActionResult{}, false
+		}
 		if cbErr, ok := err.(*things.CallbackError); ok {
 			code := cbErr.Code
 			if code == "" {
 				code = "THINGS_ERROR"
 			}
 			formatter.PrintError(cbErr.Message, code, "")
-			return nil
+			formatter.SetExitCode(formatter.ExitThingsError)
+			return things.ActionResult{}, false
+		}
+		if errors.Is(err, things.ErrAuthRequired) {
+			formatter.PrintError("Auth token required", "AUTH_REQUIRED", err.Error())
+			return things.ActionResult{}, false
+		}
+		if errors.Is(err, things.ErrTimeout) {
+			formatter.PrintError("Things did not respond in time", "TIMEOUT", err.Error())
+			return things.ActionResult{}, false
 		}
 		formatter.PrintError(fmt.Sprintf("Failed to execute Things action: %v", err), "THINGS_ERROR", err.Error())
-		return nil
+		return things.ActionResult{}, false
 	}
 
-	result := things.NormalizeResponse(action, callback)
-	formatter.PrintSuccess(result)
-	return nil
+	return things.NormalizeResponse(action, callback), true
 }
 
 // addCmd creates a new to-do in Things
@@ -75,6 +118,7 @@ var addCmd = &cobra.Command{
 
 Examples:
   things add --title "Buy milk" --when today --tags "errands"
+  things add --title "Call mom" --when evening
   things add --titles "Buy milk" --titles "Send invoices" --when anytime
   things add --title "Review PR" --checklist-items "Read diff" --checklist-items "Run tests"`,
 	RunE: func(cmd *cobra.Command, args []string) error {
@@ -102,6 +146,9 @@ Examples:
 		addBoolParam(cmd, params, "canceled", "canceled")
 		addBoolParam(cmd, params, "show-quick-entry", "show-quick-entry")
 		addBoolParam(cmd, params, "reveal", "reveal")
+		if !addDelegation(cmd, params, false) {
+			return nil
+		}
 
 		return runAction("add", params, things.ExecuteOptions{})
 	},
@@ -177,6 +224,12 @@ Examples:
 		addBoolParam(cmd, params, "reveal", "reveal")
 		addBoolParam(cmd, params, "duplicate", "duplicate")
 		addStringParam(cmd, params, "auth-token", "auth-token")
+		if !addCancelReason(cmd, params) {
+			return nil
+		}
+		if !addDelegation(cmd, params, true) {
+			return nil
+		}
 
 		return runAction("update", params, things.ExecuteOptions{RequiresAuth: true, UseAuthIfAvailable: true})
 	},
@@ -215,6 +268,9 @@ Examples:
 		addBoolParam(cmd, params, "reveal", "reveal")
 		addBoolParam(cmd, params, "duplicate", "duplicate")
 		addStringParam(cmd, params, "auth-token", "auth-token")
+		if !addCancelReason(cmd, params) {
+			return nil
+		}
 
 		return runAction("update-project", params, things.ExecuteOptions{RequiresAuth: true, UseAuthIfAvailable: true})
 	},
@@ -249,9 +305,42 @@ var searchCmd = &cobra.Command{
 	Short: "Search in Things",
 	Long: `Search Things using a query string.
 
-Example:
-  things search --query "project"`,
+Queries with predicates are answered from the local database instead, and can
+reach completed and canceled items in the Logbook:
+
+  status:open|completed|canceled|logged   logged is completed or canceled
+  completed:2024-*                        completion date (YYYY-MM-DD glob or prefix)
+  project:"Website"                       project title, ID, or @alias
+  when:evening                            list: inbox, today, evening, upcoming, anytime, someday
+  overdue                                 open items whose deadline has passed
+
+Terms may be joined with AND and values containing spaces quoted. With
+--count only the number of matching items is printed, always counted in the
+local database. --limit and --offset page through database results and are
+rejected for queries without predicates, which open in Things; --limit
+defaults to 10 for --semantic.
+
+With --semantic, open to-dos and projects are ranked by meaning instead, using
+embeddings from a local Ollama server (see embedding_url and embedding_model in
+the config). Embeddings are kept in a side index next to the config file and
+only recomputed for items that changed.
+
+Examples:
+  things search --query "project"
+  things search --query "renewal status:logged completed:2024-*"
+  things search --query "when:evening status:open"
+  things search --semantic "things I promised the landlord" --limit 5
+  things search --query "status:open overdue" --count`,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		countOnly, _ := cmd.Flags().GetBool("count")
+		if cmd.Flags().Changed("semantic") {
+			if countOnly {
+				formatter.PrintError("--count cannot be used with --semantic", "INVALID_ARGUMENTS", "")
+				return nil
+			}
+			return runSemanticSearch(cmd)
+		}
+
 		params := make(map[string]string)
 		addStringParam(cmd, params, "query", "query")
 		if len(params) == 0 {
@@ -259,39 +348,123 @@ Example:
 			return nil
 		}
 
+		query, err := things.ParseQuery(params["query"])
+		if err != nil {
+			formatter.PrintError("Invalid search query", "INVALID_ARGUMENTS", err.Error())
+			return nil
+		}
+		if countOnly {
+			return runSearchCount(query)
+		}
+		if query.HasPredicates() {
+			return runArchiveSearch(params["query"], query)
+		}
+		if pageLimit > 0 || pageOffset > 0 {
+			formatter.PrintError("--limit and --offset only page database searches", "INVALID_ARGUMENTS", "A query without predicates opens the search in Things; add one such as status:open to search the database")
+			return nil
+		}
+
 		return runAction("search", params, things.ExecuteOptions{})
 	},
 }
 
+// runArchiveSearch prints the database items matching a query with predicates
+func runArchiveSearch(raw string, query things.Query) error {
+	db, err := things.OpenDB()
+	if err != nil {
+		formatter.PrintError("Failed to open Things database", "DATABASE_ERROR", err.Error())
+		return nil
+	}
+
+	items, err := db.Paged(listPage()).Search(query)
+	if err != nil {
+		formatter.PrintError("Failed to query Things database", "DATABASE_ERROR", err.Error())
+		return nil
+	}
+
+	formatter.PrintSuccess(map[string]interface{}{
+		"query": raw,
+		"count": len(items),
+		"items": items,
+	})
+	return nil
+}
+
+// runSearchCount prints the number of database items matching a query
+func runSearchCount(query things.Query) error {
+	db, err := things.OpenDB()
+	if err != nil {
+		formatter.PrintError("Failed to open Things database", "DATABASE_ERROR", err.Error())
+		return nil
+	}
+
+	count, err := db.SearchCount(query)
+	if err != nil {
+		formatter.PrintError("Failed to query Things database", "DATABASE_ERROR", err.Error())
+		return nil
+	}
+
+	formatter.PrintCount(count)
+	return nil
+}
+
+// semanticLimit is the number of semantic results printed without --limit
+const semanticLimit = 10
+
+// runSemanticSearch prints the open items closest in meaning to --semantic
+func runSemanticSearch(cmd *cobra.Command) error {
+	query, _ := cmd.Flags().GetString("semantic")
+	limit := pageLimit
+	if !cmd.Flags().Changed("limit") {
+		limit = semanticLimit
+	}
+	if strings.TrimSpace(query) == "" {
+		formatter.PrintError("Semantic query cannot be empty", "INVALID_ARGUMENTS", "")
+		return nil
+	}
+
+	db, err := things.OpenDB()
+	if err != nil {
+		formatter.PrintError("Failed to open Things database", "DATABASE_ERROR", err.Error())
+		return nil
+	}
+
+	results, err := things.SemanticSearch(db, things.NewEmbedder(), query)
+	if err != nil {
+		formatter.PrintError("Semantic search failed", "EMBEDDING_ERROR", err.Error())
+		return nil
+	}
+	if pageOffset >= len(results) {
+		results = results[:0]
+	} else {
+		results = results[pageOffset:]
+	}
+	if limit > 0 && len(results) > limit {
+		results = results[:limit]
+	}
+
+	formatter.PrintSuccess(map[string]interface{}{
+		"query":   query,
+		"count":   len(results),
+		"results": results,
+	})
+	return nil
+}
+
 // jsonCmd sends JSON payloads to Things
 var jsonCmd = &cobra.Command{
 	Use:   "json",
 	Short: "Send a JSON payload to Things",
 	Long: `Send JSON data to Things for batch creation or updates.
 
+Check a payload first with things json validate.
+
 Examples:
   things json --file payload.json
   things json --data '{"items":[]}'`,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		data, _ := cmd.Flags().GetString("data")
-		filePath, _ := cmd.Flags().GetString("file")
-
-		if filePath != "" {
-			expanded, err := util.ExpandHomePath(filePath)
-			if err != nil {
-				formatter.PrintError("Invalid file path", "INVALID_ARGUMENTS", err.Error())
-				return nil
-			}
-			payload, err := os.ReadFile(expanded)
-			if err != nil {
-				formatter.PrintError("Failed to read JSON file", "FILE_ERROR", err.Error())
-				return nil
-			}
-			data = string(payload)
-		}
-
-		if strings.TrimSpace(data) == "" {
-			formatter.PrintError("Provide --data or --file", "INVALID_ARGUMENTS", "")
+		data, ok := readJSONPayload(cmd)
+		if !ok {
 			return nil
 		}
 
@@ -380,6 +553,24 @@ var configShowCmd = &cobra.Command{
 			"callback_port":         config.CallbackPort,
 			"timeout_sec":           config.CallbackTimeoutSeconds,
 			"output_format":         config.OutputFormat,
+			"tag_synonyms":          config.TagSynonyms,
+			"name_prefixes":         config.NamePrefixes,
+			"timezone":              config.Timezone,
+			"date_format":           config.DateFormat,
+			"first_day_of_week":     util.WeekStart().String(),
+			"safe_mode_threshold":   config.SafeModeThreshold,
+			"mcp_tool_guidance":     config.MCPToolGuidance,
+			"mcp_auth_token_set":    config.MCPAuthToken != "",
+			"mcp_tools":             config.MCPTools,
+			"embedding_url":         config.EmbeddingURL,
+			"embedding_model":       config.EmbeddingModel,
+			"template_schedules":    config.TemplateSchedules,
+			"aliases":               config.Aliases,
+			"capture_dir":           config.CaptureDir,
+			"reading_list_project":  config.ReadingListProject,
+			"focus_calendar":        config.FocusCalendar,
+			"completion_sound":      config.CompletionSound,
+			"completion_shortcut":   config.CompletionShortcut,
 			"config_path":           configPath,
 			"last_updated":          config.LastUpdated,
 		}
@@ -392,22 +583,82 @@ var configShowCmd = &cobra.Command{
 var serveCmd = &cobra.Command{
 	Use:   "serve",
 	Short: "Start the MCP server",
-	Long:  `Start a Model Context Protocol (MCP) server over Streamable HTTP, exposing Things 3 actions as tools for AI assistants.`,
+	Long: `Start a Model Context Protocol (MCP) server over Streamable HTTP, exposing Things 3 actions as tools for AI assistants.
+
+With --guest, only things_summary and things_project_progress are registered.
+They return counts, percentages, dates, and project titles, never to-do details,
+so a team-facing assistant can answer "how's the project going?" without
+reading or changing personal tasks.
+
+With --auth-token, or mcp_auth_token in the config, every MCP request must
+send "Authorization: Bearer <token>". Without one, anything that can reach the
+port can read and change Things.
+
+To reach the server from other machines, serve HTTPS with --tls-cert and
+--tls-key, and add --tls-client-ca to accept only clients with a certificate
+signed by that CA (mutual TLS).
+
+With --tools, or mcp_tools in the config, only the named tools are registered,
+for example --tools get_today,search,show for a read-only server. Names may
+leave off the things_ prefix.
+
+Every flag except --guest can also be set with a THINGS_MCP_ environment
+variable, such as THINGS_MCP_HOST=127.0.0.1, THINGS_MCP_PORT, THINGS_MCP_PATH,
+THINGS_MCP_AUTH_TOKEN, or THINGS_MCP_TOOLS. Flags win over the environment,
+which wins over the config.
+
+GET /healthz reports whether the server is up, without an auth token. On
+SIGINT or SIGTERM the server finishes tool calls already running, for up to
+30 seconds, before exiting.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		port, _ := cmd.Flags().GetInt("port")
-		return thingsmcp.Serve(port)
+		port, err := servePort(cmd)
+		if err != nil {
+			return err
+		}
+		guest, _ := cmd.Flags().GetBool("guest")
+		config, err := util.LoadConfig()
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+		opts := thingsmcp.ServerOptions{
+			Guest:       guest,
+			Host:        serveString(cmd, "host"),
+			Port:        port,
+			Path:        serveString(cmd, "path"),
+			AuthToken:   serveString(cmd, "auth-token"),
+			Tools:       serveList(cmd, "tools"),
+			TLSCert:     serveString(cmd, "tls-cert"),
+			TLSKey:      serveString(cmd, "tls-key"),
+			TLSClientCA: serveString(cmd, "tls-client-ca"),
+		}
+		if opts.AuthToken == "" {
+			opts.AuthToken = config.MCPAuthToken
+		}
+		if len(opts.Tools) == 0 {
+			opts.Tools = config.MCPTools
+		}
+		return thingsmcp.Serve(opts)
 	},
 }
 
 func init() {
+	serveCmd.Flags().String("host", "", "Address to listen on, such as 127.0.0.1 for this machine only (default all interfaces)")
 	serveCmd.Flags().Int("port", 8080, "Port to listen on")
+	serveCmd.Flags().String("path", "/mcp", "Path of the MCP endpoint")
+	serveCmd.Flags().Bool("guest", false, "Expose only summary tools with no item-level reads or writes")
+	serveCmd.Flags().String("auth-token", "", "Bearer token required on /mcp (overrides mcp_auth_token in the config)")
+	serveCmd.Flags().String("tls-cert", "", "PEM certificate file for serving HTTPS (requires --tls-key)")
+	serveCmd.Flags().String("tls-key", "", "PEM private key file for --tls-cert")
+	serveCmd.Flags().String("tls-client-ca", "", "PEM CA file; require client certificates signed by it (mutual TLS)")
+	serveCmd.Flags().StringSlice("tools", nil, "Register only these tools, comma-separated (overrides mcp_tools in the config)")
 
 	addCmd.Flags().String("title", "", "To-do title")
 	addCmd.Flags().StringArray("titles", []string{}, "Multiple to-do titles (repeat flag)")
 	addCmd.Flags().String("notes", "", "Notes for the to-do")
-	addCmd.Flags().String("when", "", "When to schedule (today, tonight, anytime, someday, or date)")
-	addCmd.Flags().String("deadline", "", "Deadline date (YYYY-MM-DD)")
+	addCmd.Flags().String("when", "", "When to schedule (today, evening/tonight, tomorrow, anytime, someday, date, or phrase like \"next monday\" or \"+3d\")")
+	addCmd.Flags().String("deadline", "", "Deadline date (YYYY-MM-DD, phrase like \"end of month\", or offset like \"+1w\")")
 	addCmd.Flags().String("tags", "", "Comma-separated tags")
+	addCmd.Flags().String("delegate", "", "Delegate to a person from Contacts: tags it waiting and adds a mailto: link to the notes")
 	addCmd.Flags().String("list", "", "List name or project title")
 	addCmd.Flags().String("list-id", "", "List or project ID")
 	addCmd.Flags().String("heading", "", "Heading title")
@@ -423,8 +674,8 @@ func init() {
 
 	addProjectCmd.Flags().String("title", "", "Project title")
 	addProjectCmd.Flags().String("notes", "", "Project notes")
-	addProjectCmd.Flags().String("when", "", "When to schedule (today, tonight, anytime, someday, or date)")
-	addProjectCmd.Flags().String("deadline", "", "Deadline date (YYYY-MM-DD)")
+	addProjectCmd.Flags().String("when", "", "When to schedule (today, evening/tonight, tomorrow, anytime, someday, date, or phrase like \"next monday\" or \"+3d\")")
+	addProjectCmd.Flags().String("deadline", "", "Deadline date (YYYY-MM-DD, phrase like \"end of month\", or offset like \"+1w\")")
 	addProjectCmd.Flags().String("tags", "", "Comma-separated tags")
 	addProjectCmd.Flags().String("area", "", "Area name")
 	addProjectCmd.Flags().String("area-id", "", "Area ID")
@@ -440,7 +691,7 @@ func init() {
 	updateCmd.Flags().String("notes", "", "Replace notes")
 	updateCmd.Flags().String("prepend-notes", "", "Prepend notes")
 	updateCmd.Flags().String("append-notes", "", "Append notes")
-	updateCmd.Flags().String("when", "", "Update schedule")
+	updateCmd.Flags().String("when", "", "Update schedule (today, evening/tonight, tomorrow, anytime, someday, or date)")
 	updateCmd.Flags().String("deadline", "", "Update deadline")
 	updateCmd.Flags().String("tags", "", "Replace tags")
 	updateCmd.Flags().String("add-tags", "", "Add tags")
@@ -453,6 +704,8 @@ func init() {
 	updateCmd.Flags().String("heading-id", "", "Move to heading by ID")
 	updateCmd.Flags().Bool("completed", false, "Mark as completed")
 	updateCmd.Flags().Bool("canceled", false, "Mark as canceled")
+	updateCmd.Flags().String("delegate", "", "Delegate to a person from Contacts: tags it waiting and adds a mailto: link to the notes")
+	updateCmd.Flags().String("reason", "", "Why the to-do is canceled, recorded in its notes (with --canceled)")
 	updateCmd.Flags().Bool("reveal", false, "Reveal the updated to-do")
 	updateCmd.Flags().Bool("duplicate", false, "Duplicate the to-do")
 	updateCmd.Flags().String("creation-date", "", "Set creation date (ISO 8601)")
@@ -465,7 +718,7 @@ func init() {
 	updateProjectCmd.Flags().String("notes", "", "Replace notes")
 	updateProjectCmd.Flags().String("prepend-notes", "", "Prepend notes")
 	updateProjectCmd.Flags().String("append-notes", "", "Append notes")
-	updateProjectCmd.Flags().String("when", "", "Update schedule")
+	updateProjectCmd.Flags().String("when", "", "Update schedule (today, evening/tonight, tomorrow, anytime, someday, or date)")
 	updateProjectCmd.Flags().String("deadline", "", "Update deadline")
 	updateProjectCmd.Flags().String("tags", "", "Replace tags")
 	updateProjectCmd.Flags().String("add-tags", "", "Add tags")
@@ -473,6 +726,7 @@ func init() {
 	updateProjectCmd.Flags().String("area-id", "", "Move to area by ID")
 	updateProjectCmd.Flags().Bool("completed", false, "Mark as completed")
 	updateProjectCmd.Flags().Bool("canceled", false, "Mark as canceled")
+	updateProjectCmd.Flags().String("reason", "", "Why the project is canceled, recorded in its notes (with --canceled)")
 	updateProjectCmd.Flags().Bool("reveal", false, "Reveal the updated project")
 	updateProjectCmd.Flags().Bool("duplicate", false, "Duplicate the project")
 	updateProjectCmd.Flags().String("creation-date", "", "Set creation date (ISO 8601)")
@@ -482,7 +736,10 @@ func init() {
 	showCmd.Flags().String("id", "", "Item ID to show")
 	showCmd.Flags().String("query", "", "List query (Inbox, Today, Upcoming, etc)")
 
+	pageable(searchCmd)
 	searchCmd.Flags().String("query", "", "Search query")
+	searchCmd.Flags().String("semantic", "", "Rank open items by meaning using local embeddings")
+	searchCmd.Flags().Bool("count", false, "Print only the number of matching items")
 
 	jsonCmd.Flags().String("data", "", "JSON payload string")
 	jsonCmd.Flags().String("file", "", "Path to JSON payload file")
@@ -500,14 +757,51 @@ func init() {
 func GetCommands() []*cobra.Command {
 	return []*cobra.Command{
 		addCmd,
+		quickCmd,
 		addProjectCmd,
 		updateCmd,
 		updateProjectCmd,
+		projectCmd,
+		areasCmd,
+		headingCmd,
+		moveCmd,
+		scheduleCmd,
+		snoozeCmd,
+		deadlineCmd,
+		tagCmd,
+		bulkCmd,
+		duplicateCmd,
+		logCmd,
+		checklistCmd,
+		notesCmd,
+		linkCmd,
+		templateCmd,
+		rolloverCmd,
+		inboxCmd,
+		captureCmd,
+		importCmd,
+		todayCmd,
+		blockCalendarCmd,
+		weekCmd,
+		focusCmd,
+		reviewCmd,
+		waitingCmd,
+		pinCmd,
+		unpinCmd,
 		showCmd,
+		reportCmd,
+		releaseNotesCmd,
+		openCmd,
 		searchCmd,
+		similarCmd,
 		jsonCmd,
+		deleteCmd,
+		trashCmd,
+		undoCmd,
+		aliasCmd,
 		versionCmd,
 		configCmd,
+		selftestCmd,
 		serveCmd,
 	}
 }
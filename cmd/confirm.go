@@ -0,0 +1,67 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/yourusername/things3-cli/pkg/formatter"
+	"golang.org/x/term"
+)
+
+// confirmed is set once the user approved a destructive command, so the
+// safe-mode check for the same changes doesn't ask again
+var confirmed bool
+
+// confirmDestructive asks before deleting, trashing, or changing many items.
+// It returns true when --yes or --dry-run was given or the user agreed on a
+// terminal.
+// Otherwise it prints a CONFIRMATION_REQUIRED error naming what was held
+// back; without a terminal --yes is the only way to proceed.
+func confirmDestructive(question, what string) bool {
+	// --dry-run changes nothing, so there is nothing to confirm
+	if assumeYes || confirmed || dryRunMode {
+		return true
+	}
+	if !isInteractive() {
+		formatter.PrintError(what+" requires confirmation", "CONFIRMATION_REQUIRED", "Not running in a terminal; re-run with --yes to proceed")
+		return false
+	}
+	if !promptYesNo(question) {
+		formatter.PrintError(what+" was not confirmed", "CONFIRMATION_REQUIRED", "Re-run with --yes to skip the prompt")
+		return false
+	}
+	confirmed = true
+	return true
+}
+
+// confirmMassMutation approves actions above the safe-mode threshold when --yes
+// was given, or after asking on an interactive terminal
+func confirmMassMutation(count int) bool {
+	if assumeYes || confirmed {
+		return true
+	}
+	return promptYesNo(fmt.Sprintf("This will modify %d items. Continue?", count))
+}
+
+// stdinReader is shared by all prompts so buffered answers aren't lost between questions
+var stdinReader = bufio.NewReader(os.Stdin)
+
+// promptYesNo asks a question on stderr and reads the answer from stdin.
+// It returns false without asking when stdin is not a terminal.
+func promptYesNo(question string) bool {
+	if !isInteractive() {
+		return false
+	}
+
+	fmt.Fprintf(os.Stderr, "%s [y/N] ", question)
+	answer, _ := stdinReader.ReadString('\n')
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	return answer == "y" || answer == "yes"
+}
+
+// isInteractive reports whether stdin is a terminal that can answer prompts
+func isInteractive() bool {
+	return term.IsTerminal(int(os.Stdin.Fd()))
+}
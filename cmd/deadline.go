@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/yourusername/things3-cli/pkg/formatter"
+	"github.com/yourusername/things3-cli/pkg/things"
+)
+
+// deadlineCmd sets or clears the deadline of a to-do or project
+var deadlineCmd = &cobra.Command{
+	Use:   "deadline",
+	Short: "Set or clear the deadline of a to-do or project",
+	Long: `Set the deadline of an item, or remove it with --clear. Requires an auth token.
+
+Examples:
+  things deadline --id "THINGS-ID" --date 2024-06-01
+  things deadline --id "THINGS-ID" --clear`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		id, _ := cmd.Flags().GetString("id")
+		if id == "" {
+			formatter.PrintError("Item ID (--id) is required", "INVALID_ARGUMENTS", "")
+			return nil
+		}
+
+		date, _ := cmd.Flags().GetString("date")
+		clear, _ := cmd.Flags().GetBool("clear")
+		if date == "" && !clear {
+			formatter.PrintError("Provide --date or --clear", "INVALID_ARGUMENTS", "")
+			return nil
+		}
+		if date != "" && clear {
+			formatter.PrintError("Use either --date or --clear, not both", "INVALID_ARGUMENTS", "")
+			return nil
+		}
+
+		items, ok := lookupItems([]string{id})
+		if !ok {
+			return nil
+		}
+
+		// An empty deadline parameter is how the URL scheme clears the field,
+		// so it is always sent rather than omitted.
+		params := map[string]string{"id": id, "deadline": date}
+		addStringParam(cmd, params, "auth-token", "auth-token")
+
+		action := "update"
+		if items[0].Type == "project" {
+			action = "update-project"
+		}
+		return runAction(action, params, things.ExecuteOptions{RequiresAuth: true, UseAuthIfAvailable: true})
+	},
+}
+
+func init() {
+	deadlineCmd.Flags().String("id", "", "To-do or project ID (required)")
+	deadlineCmd.Flags().String("date", "", "Deadline date (YYYY-MM-DD or phrase like \"next friday\")")
+	deadlineCmd.Flags().Bool("clear", false, "Remove the deadline")
+	deadlineCmd.Flags().String("auth-token", "", "Things auth token (overrides config/ENV)")
+}
@@ -0,0 +1,115 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/yourusername/things3-cli/pkg/formatter"
+	"github.com/yourusername/things3-cli/pkg/things"
+)
+
+// deleteCmd moves to-dos and projects to the Trash
+var deleteCmd = &cobra.Command{
+	Use:   "delete [ID...]",
+	Short: "Move to-dos or projects to the Trash",
+	Long: `Move one or more to-dos or projects to the Trash. IDs can be given with
+repeated --id flags or as arguments. The URL scheme cannot delete, so this uses
+AppleScript. Projects go to the Trash with their to-dos, and everything can be
+restored from the Trash in Things. This asks first unless --yes is given.
+
+Examples:
+  things delete --id "THINGS-ID"
+  things delete --yes ID-1 ID-2`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ids := collectIDs(cmd, args)
+		if len(ids) == 0 {
+			formatter.PrintError("At least one item ID (--id) is required", "INVALID_ARGUMENTS", "")
+			return nil
+		}
+
+		items, ok := lookupItems(ids)
+		if !ok {
+			return nil
+		}
+
+		question := fmt.Sprintf("Move %d items to the Trash?", len(items))
+		if len(items) == 1 {
+			question = fmt.Sprintf("Move the %s %q to the Trash?", items[0].Type, items[0].Title)
+		}
+		if !confirmDestructive(question, "Moving items to the Trash") {
+			return nil
+		}
+
+		trashed := make([]string, len(items))
+		for i, item := range items {
+			trashed[i] = item.ID
+		}
+		if err := things.TrashItems(trashed); err != nil {
+			if heldByDryRun(err) {
+				return nil
+			}
+			formatter.PrintError("Failed to move items to the Trash", "THINGS_ERROR", err.Error())
+			return nil
+		}
+
+		formatter.PrintSuccess(map[string]interface{}{
+			"count":       len(trashed),
+			"trashed_ids": trashed,
+		})
+		return nil
+	},
+}
+
+// trashCmd groups the Trash subcommands
+var trashCmd = &cobra.Command{
+	Use:   "trash",
+	Short: "Manage the Trash",
+}
+
+// trashEmptyCmd permanently deletes everything in the Trash
+var trashEmptyCmd = &cobra.Command{
+	Use:   "empty",
+	Short: "Permanently delete everything in the Trash",
+	Long: `Empty the Trash through AppleScript. The items cannot be restored
+afterwards, so this asks first unless --yes is given.
+
+Example:
+  things trash empty --yes`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		db, err := things.OpenDB()
+		if err != nil {
+			formatter.PrintError("Failed to open Things database", "DATABASE_ERROR", err.Error())
+			return nil
+		}
+		count, err := db.TrashCount()
+		if err != nil {
+			formatter.PrintError("Failed to query Things database", "DATABASE_ERROR", err.Error())
+			return nil
+		}
+		if count == 0 {
+			formatter.PrintSuccess(map[string]interface{}{"deleted": 0})
+			return nil
+		}
+
+		question := fmt.Sprintf("Permanently delete the %d items in the Trash? This cannot be undone.", count)
+		if !confirmDestructive(question, "Emptying the Trash") {
+			return nil
+		}
+		if err := things.EmptyTrash(); err != nil {
+			if heldByDryRun(err) {
+				return nil
+			}
+			formatter.PrintError("Failed to empty the Trash", "THINGS_ERROR", err.Error())
+			return nil
+		}
+
+		formatter.PrintSuccess(map[string]interface{}{"deleted": count})
+		return nil
+	},
+}
+
+func init() {
+	deleteCmd.Flags().StringArray("id", []string{}, "To-do or project ID (repeat flag)")
+
+	trashCmd.AddCommand(trashEmptyCmd)
+}
@@ -0,0 +1,60 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/yourusername/things3-cli/pkg/formatter"
+	"github.com/yourusername/things3-cli/pkg/things"
+)
+
+// duplicateCmd copies a to-do or project, applying any overrides to the copy
+var duplicateCmd = &cobra.Command{
+	Use:   "duplicate",
+	Short: "Duplicate a to-do or project",
+	Long: `Duplicate an item and apply the given overrides to the copy in the same step.
+The original is left untouched. Requires an auth token.
+
+Examples:
+  things duplicate --id "THINGS-ID"
+  things duplicate --id "THINGS-ID" --when today --title "Copy of weekly report"
+  things duplicate --id "PROJECT-ID" --when "next monday" --deadline "end of month"`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		id, _ := cmd.Flags().GetString("id")
+		if id == "" {
+			formatter.PrintError("Item ID (--id) is required", "INVALID_ARGUMENTS", "")
+			return nil
+		}
+
+		items, ok := lookupItems([]string{id})
+		if !ok {
+			return nil
+		}
+
+		// With duplicate set, Things copies the item first and applies the
+		// remaining parameters to the copy.
+		params := map[string]string{"id": id, "duplicate": "true"}
+		addStringParam(cmd, params, "title", "title")
+		addStringParam(cmd, params, "notes", "notes")
+		addStringParam(cmd, params, "when", "when")
+		addStringParam(cmd, params, "deadline", "deadline")
+		addStringParam(cmd, params, "tags", "tags")
+		addBoolParam(cmd, params, "reveal", "reveal")
+		addStringParam(cmd, params, "auth-token", "auth-token")
+
+		action := "update"
+		if items[0].Type == "project" {
+			action = "update-project"
+		}
+		return runAction(action, params, things.ExecuteOptions{RequiresAuth: true, UseAuthIfAvailable: true})
+	},
+}
+
+func init() {
+	duplicateCmd.Flags().String("id", "", "To-do or project ID to duplicate (required)")
+	duplicateCmd.Flags().String("title", "", "Title for the copy")
+	duplicateCmd.Flags().String("notes", "", "Notes for the copy")
+	duplicateCmd.Flags().String("when", "", "Schedule the copy (today, evening, tomorrow, someday, YYYY-MM-DD, or a phrase)")
+	duplicateCmd.Flags().String("deadline", "", "Deadline for the copy")
+	duplicateCmd.Flags().String("tags", "", "Replace the copy's tags (comma-separated)")
+	duplicateCmd.Flags().Bool("reveal", false, "Reveal the copy in Things")
+	duplicateCmd.Flags().String("auth-token", "", "Things auth token (overrides config/ENV)")
+}
@@ -0,0 +1,194 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/yourusername/things3-cli/pkg/formatter"
+	"github.com/yourusername/things3-cli/pkg/things"
+	"github.com/yourusername/things3-cli/pkg/util"
+)
+
+// Values of the persistent flags shared by every command
+var (
+	assumeYes    bool
+	outputFormat string
+	outputPath   string
+	templateText string
+	columnList   string
+	forceJSON    bool
+	forcePlain   bool
+	quietOutput  bool
+	dryRunMode   bool
+	verbose      bool
+	sortField    string
+	sortReverse  bool
+	groupField   string
+	pageLimit    int
+	pageOffset   int
+	timezoneName string
+	dateLayout   string
+	relative     bool
+	linkItems    bool
+)
+
+// RegisterGlobalFlags adds the persistent flags that all commands honor
+func RegisterGlobalFlags(root *cobra.Command) {
+	root.PersistentFlags().BoolVarP(&assumeYes, "yes", "y", false, "Skip confirmation prompts for deleting, trashing, or changing many items")
+	root.PersistentFlags().StringVar(&outputFormat, "format", "", "Output format: "+strings.Join(formatter.OutputFormats(), ", ")+" (default from config output_format)")
+	root.PersistentFlags().StringVar(&templateText, "template", "", "Go template for --format template, run once per item (e.g. '{{.ID}}\\t{{.Title}}')")
+	root.PersistentFlags().BoolVar(&linkItems, "links", false, "Link item titles in markdown output to the items in Things")
+	root.PersistentFlags().StringVar(&columnList, "columns", "", "Comma-separated item fields for table and csv output (e.g. id,title,deadline)")
+	root.PersistentFlags().StringVarP(&outputPath, "output", "o", "", "Write the output to FILE, replacing it only once the command succeeds")
+	root.PersistentFlags().BoolVar(&forceJSON, "json", false, "Print stable JSON, whatever the config or terminal")
+	root.PersistentFlags().BoolVar(&forcePlain, "plain", false, "Print readable plain text, whatever the config or terminal")
+	root.PersistentFlags().BoolVarP(&quietOutput, "quiet", "q", false, "Print nothing on success; errors are still printed")
+
+	root.PersistentFlags().StringVar(&sortField, "sort", "", "Order listed items by "+strings.Join(things.SortFields, ", ")+" (ties broken by ID)")
+	root.PersistentFlags().BoolVar(&sortReverse, "reverse", false, "Reverse the --sort order")
+	root.PersistentFlags().IntVar(&pageLimit, "limit", 0, "Print at most this many items from a listing command (0 for all)")
+	root.PersistentFlags().IntVar(&pageOffset, "offset", 0, "Skip this many items before --limit")
+	root.PersistentFlags().StringVar(&groupField, "group-by", "", "Nest listed items under their "+strings.Join(formatter.GroupFields(), ", "))
+
+	root.PersistentFlags().StringVar(&timezoneName, "timezone", "", "Timezone for showing and reading dates, such as Europe/Berlin (default from config timezone, else the system's)")
+	root.PersistentFlags().BoolVar(&relative, "relative-dates", true, "Show how far off deadlines and start dates are in plain and table output, such as \"in 3 days\"")
+	root.PersistentFlags().StringVar(&dateLayout, "date-format", "", "Layout for showing dates, written as Go's reference date (e.g. 02.01.2006; default from config date_format, else 2006-01-02)")
+
+	root.PersistentFlags().BoolVar(&dryRunMode, "dry-run", false, "Print the Things URL or json payload instead of opening it")
+
+	root.PersistentFlags().BoolVarP(&verbose, "verbose", "v", false, "Log URLs (token redacted), callback ports, timings, and callback parameters to stderr")
+
+	root.PersistentPreRunE = func(cmd *cobra.Command, args []string) error {
+		formatter.SetCommand(strings.TrimPrefix(cmd.CommandPath(), root.Name()+" "))
+		if outputPath != "" {
+			if err := redirectOutput(outputPath); err != nil {
+				return err
+			}
+		}
+		things.SetDryRun(dryRunMode)
+		if err := util.SetTimezone(timezoneName); err != nil {
+			return err
+		}
+		if err := util.SetDateFormat(dateLayout); err != nil {
+			return err
+		}
+		if err := things.SetSort(sortField, sortReverse); err != nil {
+			return err
+		}
+		if err := formatter.SetGroupBy(groupField); err != nil {
+			return err
+		}
+		if pageLimit < 0 || pageOffset < 0 {
+			return fmt.Errorf("--limit and --offset cannot be negative")
+		}
+		if (pageLimit > 0 || pageOffset > 0) && cmd.Annotations[pagedAnnotation] == "" {
+			return fmt.Errorf("%s does not list items; --limit and --offset only apply to listing commands", cmd.CommandPath())
+		}
+		if verbose {
+			things.SetDebugLog(os.Stderr)
+		}
+		return applyOutputFormat()
+	}
+}
+
+// pagedAnnotation marks the listing commands that honor --limit and --offset;
+// the flags are rejected everywhere else rather than silently ignored
+const pagedAnnotation = "paged"
+
+// pageable marks commands as listings that honor --limit and --offset
+func pageable(cmds ...*cobra.Command) {
+	for _, cmd := range cmds {
+		if cmd.Annotations == nil {
+			cmd.Annotations = make(map[string]string)
+		}
+		cmd.Annotations[pagedAnnotation] = "true"
+	}
+}
+
+// listPage returns the page of a listing chosen with --limit and --offset
+func listPage() things.Page {
+	return things.Page{Limit: pageLimit, Offset: pageOffset}
+}
+
+// printDryRun prints the action that --dry-run held back
+func printDryRun(run *things.DryRunError) {
+	data := map[string]interface{}{
+		"dry_run": true,
+		"action":  run.Action,
+		"url":     run.URL,
+	}
+	if payload := run.Payload(); payload != nil {
+		data["payload"] = payload
+	}
+	if run.Script != "" {
+		delete(data, "url")
+		data["applescript"] = run.Script
+	}
+	formatter.PrintSuccess(data)
+}
+
+// heldByDryRun prints the action --dry-run held back when err is a
+// DryRunError, and reports whether it was.
+func heldByDryRun(err error) bool {
+	var run *things.DryRunError
+	if !errors.As(err, &run) {
+		return false
+	}
+	printDryRun(run)
+	return true
+}
+
+// applyOutputFormat selects the output format from --json, --plain, or
+// --format, falling back to output_format in the config. The default, auto,
+// is plain text on a terminal and JSON otherwise.
+func applyOutputFormat() error {
+	formatter.SetQuiet(quietOutput)
+	formatter.SetRelativeDates(relative)
+
+	name := outputFormat
+	if templateText != "" {
+		if err := formatter.SetTemplate(templateText); err != nil {
+			return err
+		}
+		if name == "" && !forceJSON && !forcePlain {
+			name = formatter.OutputTemplate
+		}
+	}
+	switch {
+	case forceJSON && forcePlain:
+		return fmt.Errorf("--json and --plain cannot be used together")
+	case (forceJSON || forcePlain) && name != "":
+		return fmt.Errorf("--format cannot be combined with --json or --plain")
+	case forceJSON:
+		name = formatter.OutputJSON
+	case forcePlain:
+		name = formatter.OutputPlain
+	}
+	if name == "" {
+		if config, err := util.LoadConfig(); err == nil {
+			name = config.OutputFormat
+		}
+	}
+	if err := formatter.SetOutputFormat(name); err != nil {
+		return err
+	}
+	if formatter.OutputFormat() == formatter.OutputTemplate && templateText == "" {
+		return fmt.Errorf("the template format needs --template")
+	}
+	if linkItems {
+		if formatter.OutputFormat() != formatter.OutputMarkdown {
+			return fmt.Errorf("--links only applies to the markdown format")
+		}
+		formatter.SetMarkdownLinks(true)
+	}
+	if columnList != "" {
+		if format := formatter.OutputFormat(); format != formatter.OutputTable && format != formatter.OutputCSV {
+			return fmt.Errorf("--columns only applies to the table and csv formats")
+		}
+		return formatter.SetColumns(strings.Split(columnList, ","))
+	}
+	return nil
+}
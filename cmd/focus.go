@@ -0,0 +1,111 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/yourusername/things3-cli/pkg/formatter"
+	"github.com/yourusername/things3-cli/pkg/things"
+)
+
+// focusEntry pairs an active project with its next action
+type focusEntry struct {
+	ID         string       `json:"id"`
+	Title      string       `json:"title"`
+	Area       string       `json:"area,omitempty"`
+	Deadline   string       `json:"deadline,omitempty"`
+	NextAction *things.Item `json:"next_action"`
+}
+
+// focusCmd lists one next action per active project
+var focusCmd = &cobra.Command{
+	Use:   "focus",
+	Short: "Show one next action for every active project",
+	Long: `List each active project with exactly one next action: its first open to-do
+in app order, which is the first one outside any heading or else the first one
+under the earliest heading that has any. Projects in Someday or scheduled for a
+later date are not active. Projects without an open to-do are listed with a
+null next_action and counted under "missing".
+
+Examples:
+  things focus
+  things focus --missing`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		missingOnly, _ := cmd.Flags().GetBool("missing")
+
+		db, err := things.OpenDB()
+		if err != nil {
+			formatter.PrintError("Failed to open Things database", "DATABASE_ERROR", err.Error())
+			return nil
+		}
+		projects, err := db.OpenProjects()
+		if err != nil {
+			formatter.PrintError("Failed to query Things database", "DATABASE_ERROR", err.Error())
+			return nil
+		}
+
+		entries := []focusEntry{}
+		missing := 0
+		for _, project := range projects {
+			if project.When == "someday" || project.When == "upcoming" {
+				continue
+			}
+			contents, err := db.ProjectContents(project.ID)
+			if err != nil {
+				formatter.PrintError("Failed to query Things database", "DATABASE_ERROR", err.Error())
+				return nil
+			}
+
+			next := nextAction(contents)
+			if next == nil {
+				missing++
+			} else if missingOnly {
+				continue
+			}
+			entries = append(entries, focusEntry{
+				ID:         project.ID,
+				Title:      project.Title,
+				Area:       project.Area,
+				Deadline:   project.Deadline,
+				NextAction: next,
+			})
+		}
+
+		entries = things.PageOf(listPage(), entries)
+		formatter.PrintSuccess(map[string]interface{}{
+			"count":    len(entries),
+			"missing":  missing,
+			"projects": entries,
+		})
+		return nil
+	},
+}
+
+// nextAction returns the first open to-do of a project in app order, or nil.
+// Things lists to-dos outside headings first, then each heading's to-dos.
+func nextAction(contents []things.Item) *things.Item {
+	var headings []string
+	firstUnder := make(map[string]*things.Item)
+	for i := range contents {
+		item := &contents[i]
+		switch {
+		case item.Type == "heading":
+			headings = append(headings, item.ID)
+		case item.Type != "to-do" || item.Status != "open":
+			continue
+		case item.HeadingID == "":
+			return item
+		case firstUnder[item.HeadingID] == nil:
+			firstUnder[item.HeadingID] = item
+		}
+	}
+	for _, heading := range headings {
+		if item := firstUnder[heading]; item != nil {
+			return item
+		}
+	}
+	return nil
+}
+
+func init() {
+	pageable(focusCmd)
+	focusCmd.Flags().Bool("missing", false, "Only list projects without a next action")
+}
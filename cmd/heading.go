@@ -0,0 +1,91 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/yourusername/things3-cli/pkg/formatter"
+	"github.com/yourusername/things3-cli/pkg/things"
+)
+
+// headingCmd groups the heading subcommands
+var headingCmd = &cobra.Command{
+	Use:   "heading",
+	Short: "Inspect the headings of a project",
+	Long: `Inspect the headings of a project.
+
+The Things URL scheme and AppleScript dictionary have no way to create,
+archive, or reorder headings in an existing project; headings can only be set
+up when a project is created (see add-project, template apply, and import
+markdown). Use heading list to find heading IDs for --heading-id.`,
+}
+
+// headingSummary describes a heading and how much work is left under it
+type headingSummary struct {
+	ID       string `json:"id"`
+	Title    string `json:"title"`
+	Archived bool   `json:"archived"`
+	Open     int    `json:"open"`
+}
+
+// headingListCmd prints the headings of a project
+var headingListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the headings of a project in app order",
+	Long: `List the headings of a project in app order with the number of open to-dos
+under each.
+
+Example:
+  things heading list --project-id "THINGS-ID"`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ref, _ := cmd.Flags().GetString("project-id")
+		if ref == "" {
+			formatter.PrintError("Project (--project-id) is required", "INVALID_ARGUMENTS", "")
+			return nil
+		}
+
+		db, err := things.OpenDB()
+		if err != nil {
+			formatter.PrintError("Failed to open Things database", "DATABASE_ERROR", err.Error())
+			return nil
+		}
+		project, err := db.FindProject(ref)
+		if err != nil {
+			printLookupError("Project", ref, err)
+			return nil
+		}
+		contents, err := db.ProjectContents(project.ID)
+		if err != nil {
+			formatter.PrintError("Failed to query Things database", "DATABASE_ERROR", err.Error())
+			return nil
+		}
+
+		headings := []headingSummary{}
+		index := make(map[string]int)
+		for _, item := range contents {
+			if item.Type == "heading" {
+				index[item.ID] = len(headings)
+				headings = append(headings, headingSummary{ID: item.ID, Title: item.Title, Archived: item.Status != "open"})
+			}
+		}
+		for _, item := range contents {
+			if i, ok := index[item.HeadingID]; ok && item.Type == "to-do" && item.Status == "open" {
+				headings[i].Open++
+			}
+		}
+		headings = things.PageOf(listPage(), headings)
+
+		formatter.PrintSuccess(map[string]interface{}{
+			"project_id": project.ID,
+			"project":    project.Title,
+			"count":      len(headings),
+			"headings":   headings,
+		})
+		return nil
+	},
+}
+
+func init() {
+	pageable(headingListCmd)
+	headingListCmd.Flags().String("project-id", "", "Project ID, @alias, or title (required)")
+
+	headingCmd.AddCommand(headingListCmd)
+}
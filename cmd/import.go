@@ -0,0 +1,316 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/yourusername/things3-cli/pkg/formatter"
+	"github.com/yourusername/things3-cli/pkg/things"
+	"github.com/yourusername/things3-cli/pkg/util"
+)
+
+// importCmd groups commands that create to-dos from other sources
+var importCmd = &cobra.Command{
+	Use:   "import",
+	Short: "Create to-dos from other sources",
+}
+
+// importGitCmd creates to-dos from marker comments added in recent commits
+var importGitCmd = &cobra.Command{
+	Use:   "git",
+	Short: "Create to-dos from TODO/FIXME comments added in recent commits",
+	Long: `Scan the commits of a git repository for added lines matching --grep and
+create a to-do for each. The notes hold the file:line and commit hash, and every
+to-do is tagged with the repository name (the tag must exist in Things).
+Comments that have since been removed from the working tree are skipped.
+
+--since accepts 12h, 3d, 1w, 2m, 1y, or a date.
+
+Examples:
+  things import git --repo . --since 1w
+  things import git --repo ~/src/api --since 2024-05-01 --grep 'TODO|FIXME|XXX' --list "API"
+  things import git --since 3d --dry-run`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		repo, _ := cmd.Flags().GetString("repo")
+		repo, err := util.ExpandHomePath(repo)
+		if err != nil {
+			formatter.PrintError("Invalid repository path", "FILE_ERROR", err.Error())
+			return nil
+		}
+
+		sinceFlag, _ := cmd.Flags().GetString("since")
+		since, err := util.ParseSince(sinceFlag, util.Now())
+		if err != nil {
+			formatter.PrintError(err.Error(), "INVALID_ARGUMENTS", "")
+			return nil
+		}
+
+		grep, _ := cmd.Flags().GetString("grep")
+		pattern, err := regexp.Compile(grep)
+		if err != nil {
+			formatter.PrintError("Invalid --grep pattern", "INVALID_ARGUMENTS", err.Error())
+			return nil
+		}
+
+		tag, _ := cmd.Flags().GetString("tag")
+		if tag == "" {
+			if tag, err = things.GitRepoName(repo); err != nil {
+				formatter.PrintError("Failed to read git repository", "FILE_ERROR", err.Error())
+				return nil
+			}
+		}
+
+		todos, err := things.HarvestGitTodos(repo, since, pattern)
+		if err != nil {
+			formatter.PrintError("Failed to read git repository", "FILE_ERROR", err.Error())
+			return nil
+		}
+
+		list, _ := cmd.Flags().GetString("list")
+		ops := make([]things.JSONOperation, len(todos))
+		for i, todo := range todos {
+			attrs := map[string]interface{}{
+				"title": todo.Text,
+				"notes": fmt.Sprintf("%s\nCommit %s: %s", todo.Location(), todo.Commit, todo.Subject),
+				"tags":  []string{tag},
+			}
+			if list != "" {
+				attrs["list"] = list
+			}
+			ops[i] = things.JSONOperation{Type: "to-do", Attributes: attrs}
+		}
+
+		runImport(cmd, ops, todos)
+		return nil
+	},
+}
+
+// importReadingListCmd creates to-dos from Safari's Reading List
+var importReadingListCmd = &cobra.Command{
+	Use:   "reading-list",
+	Short: "Create \"Read:\" to-dos from Safari's Reading List or a bookmarks export",
+	Long: `Create a "Read: <title>" to-do with the URL in its notes for each page in
+Safari's Reading List. --file may instead point at a Netscape bookmarks HTML
+export from any browser; links in its "Reading List" folder are used when there
+is one, otherwise every link. Imported URLs are remembered and skipped on later
+runs.
+
+The to-dos go to --project, or reading_list_project from the config, or the
+Inbox. Reading Safari's bookmarks needs Full Disk Access for the terminal.
+
+Examples:
+  things import reading-list --project "Reading"
+  things import reading-list --file ~/Downloads/bookmarks.html --dry-run`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		path, _ := cmd.Flags().GetString("file")
+		var err error
+		if path == "" {
+			path, err = things.DefaultReadingListPath()
+		} else {
+			path, err = util.ExpandHomePath(path)
+		}
+		if err != nil {
+			formatter.PrintError("Invalid bookmarks path", "FILE_ERROR", err.Error())
+			return nil
+		}
+
+		project, _ := cmd.Flags().GetString("project")
+		if project == "" {
+			config, err := util.LoadConfig()
+			if err != nil {
+				formatter.PrintError("Failed to load config", "CONFIG_ERROR", err.Error())
+				return nil
+			}
+			project = config.ReadingListProject
+		}
+
+		entries, err := things.LoadReadingList(path)
+		if err != nil {
+			formatter.PrintError("Failed to read bookmarks", "FILE_ERROR", err.Error())
+			return nil
+		}
+		imported, err := things.ImportedReadingListURLs()
+		if err != nil {
+			formatter.PrintError("Failed to read import history", "STATE_ERROR", err.Error())
+			return nil
+		}
+
+		fresh := []things.ReadingListEntry{}
+		var ops []things.JSONOperation
+		var urls []string
+		for _, entry := range entries {
+			if imported[entry.URL] {
+				continue
+			}
+			imported[entry.URL] = true
+			attrs := map[string]interface{}{
+				"title": "Read: " + entry.Title,
+				"notes": entry.URL,
+			}
+			if project != "" {
+				attrs["list"] = project
+			}
+			fresh = append(fresh, entry)
+			ops = append(ops, things.JSONOperation{Type: "to-do", Attributes: attrs})
+			urls = append(urls, entry.URL)
+		}
+
+		if runImport(cmd, ops, fresh) {
+			// Like the undo history, a failure here only costs duplicate detection
+			_ = things.RecordReadingListURLs(urls)
+		}
+		return nil
+	},
+}
+
+// importMarkdownCmd creates a project from a markdown outline
+var importMarkdownCmd = &cobra.Command{
+	Use:   "markdown",
+	Short: "Create a project from a markdown outline",
+	Long: `Create a whole project in one json action from a markdown outline. Headings
+become project headings, top-level "- [ ]" bullets become to-dos ("- [x]"
+ones are created completed), and bullets nested under a to-do become its
+checklist. Other text becomes notes of the to-do above it, or of the project
+when it comes first. Text between a heading and its first to-do is kept as
+notes of the heading; since Things headings have no notes, it is added to the
+project notes under the heading's title.
+
+The project is titled by --title, else by a single "#" heading above "##"
+sections, else by the file name. Use --file - to read from stdin.
+
+Examples:
+  things import markdown --file plan.md
+  things import markdown --file plan.md --title "Q3 Launch" --area Work --dry-run`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		file, _ := cmd.Flags().GetString("file")
+		if file == "" {
+			formatter.PrintError("Markdown file (--file) is required", "INVALID_ARGUMENTS", "")
+			return nil
+		}
+
+		var data []byte
+		var err error
+		fallback := "Imported Plan"
+		if file == "-" {
+			data, err = io.ReadAll(os.Stdin)
+		} else {
+			fallback = strings.TrimSuffix(filepath.Base(file), filepath.Ext(file))
+			data, err = os.ReadFile(file)
+		}
+		if err != nil {
+			formatter.PrintError("Failed to read markdown", "FILE_ERROR", err.Error())
+			return nil
+		}
+
+		op, summary, err := things.ParseMarkdownOutline(string(data), fallback)
+		if err != nil {
+			formatter.PrintError("Failed to convert markdown: "+err.Error(), "INVALID_ARGUMENTS", "")
+			return nil
+		}
+
+		if title, _ := cmd.Flags().GetString("title"); title != "" {
+			op.Attributes["title"] = title
+			summary.Title = title
+		}
+		area, _ := cmd.Flags().GetString("area")
+		extra, ok := templateExtras(area)
+		if !ok {
+			return nil
+		}
+		if when, _ := cmd.Flags().GetString("when"); when != "" {
+			extra["when"] = when
+		}
+		for key, value := range extra {
+			op.Attributes[key] = value
+		}
+
+		if dryRunMode {
+			formatter.PrintSuccess(map[string]interface{}{
+				"summary": summary,
+				"payload": []things.JSONOperation{op},
+				"dry_run": true,
+			})
+			return nil
+		}
+
+		payload, err := things.EncodeJSONPayload([]things.JSONOperation{op})
+		if err != nil {
+			formatter.PrintError("Failed to build JSON payload", "INVALID_ARGUMENTS", err.Error())
+			return nil
+		}
+		params := map[string]string{"data": payload}
+		addBoolParam(cmd, params, "reveal", "reveal")
+		addStringParam(cmd, params, "auth-token", "auth-token")
+
+		result, ok := executeAction("json", params, things.ExecuteOptions{UseAuthIfAvailable: true})
+		if !ok {
+			return nil
+		}
+		formatter.PrintSuccess(map[string]interface{}{
+			"summary": summary,
+			"result":  result,
+		})
+		return nil
+	},
+}
+
+// runImport creates the to-dos built by an import command in one json payload
+// and prints how many were created. With --dry-run the source entries are
+// printed instead. It reports whether the to-dos were created.
+func runImport(cmd *cobra.Command, ops []things.JSONOperation, entries interface{}) bool {
+	report := map[string]interface{}{
+		"count": len(ops),
+		"items": entries,
+	}
+
+	if dryRunMode || len(ops) == 0 {
+		report["dry_run"] = dryRunMode
+		formatter.PrintSuccess(report)
+		return false
+	}
+
+	data, err := things.EncodeJSONPayload(ops)
+	if err != nil {
+		formatter.PrintError("Failed to build JSON payload", "INVALID_ARGUMENTS", err.Error())
+		return false
+	}
+	params := map[string]string{"data": data}
+	addStringParam(cmd, params, "auth-token", "auth-token")
+
+	result, ok := executeAction("json", params, things.ExecuteOptions{UseAuthIfAvailable: true})
+	if !ok {
+		return false
+	}
+	report["result"] = result
+	formatter.PrintSuccess(report)
+	return true
+}
+
+func init() {
+	importGitCmd.Flags().String("repo", ".", "Path inside the git repository")
+	importGitCmd.Flags().String("since", "1w", "How far back to look (12h, 3d, 1w, 2m, 1y, or a date)")
+	importGitCmd.Flags().String("grep", "TODO|FIXME", "Regular expression marking lines to import")
+	importGitCmd.Flags().String("tag", "", "Tag for the created to-dos (defaults to the repository name)")
+	importGitCmd.Flags().String("list", "", "Project or area to create the to-dos in (defaults to the Inbox)")
+	importGitCmd.Flags().String("auth-token", "", "Things auth token (overrides config/ENV)")
+
+	importReadingListCmd.Flags().String("file", "", "Bookmarks.plist or bookmarks HTML export (defaults to Safari's bookmarks)")
+	importReadingListCmd.Flags().String("project", "", "Project for the to-dos (defaults to reading_list_project, then the Inbox)")
+	importReadingListCmd.Flags().String("auth-token", "", "Things auth token (overrides config/ENV)")
+
+	importMarkdownCmd.Flags().String("file", "", "Markdown file, or - for stdin (required)")
+	importMarkdownCmd.Flags().String("title", "", "Project title (overrides the outline's title)")
+	importMarkdownCmd.Flags().String("area", "", "Area name or ID for the new project")
+	importMarkdownCmd.Flags().String("when", "", "When to schedule the project (today, tomorrow, someday, date, or phrase)")
+	importMarkdownCmd.Flags().Bool("reveal", false, "Reveal the created project in Things")
+	importMarkdownCmd.Flags().String("auth-token", "", "Things auth token (overrides config/ENV)")
+
+	importCmd.AddCommand(importGitCmd)
+	importCmd.AddCommand(importReadingListCmd)
+	importCmd.AddCommand(importMarkdownCmd)
+}
@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/yourusername/things3-cli/pkg/formatter"
+	"github.com/yourusername/things3-cli/pkg/things"
+)
+
+// inboxCmd lists the Inbox or captures a new to-do into it
+var inboxCmd = &cobra.Command{
+	Use:   "inbox",
+	Short: "List the Inbox or capture a to-do into it",
+	Long: `List the open to-dos in the Inbox, read from the local Things database, or
+capture a new one with --add. Output is a compact list on a terminal and JSON
+when piped, or as chosen with --json or --plain.
+
+Examples:
+  things inbox
+  things inbox --add "Call the plumber"
+  things inbox --count
+  things inbox --json`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		countOnly, _ := cmd.Flags().GetBool("count")
+		if cmd.Flags().Changed("add") {
+			if countOnly {
+				formatter.PrintError("--count cannot be used with --add", "INVALID_ARGUMENTS", "")
+				return nil
+			}
+			title, _ := cmd.Flags().GetString("add")
+			if title == "" {
+				formatter.PrintError("Title (--add) cannot be empty", "INVALID_ARGUMENTS", "")
+				return nil
+			}
+
+			result, ok := executeAction("add", map[string]string{"title": title}, things.ExecuteOptions{})
+			if !ok {
+				return nil
+			}
+			formatter.PrintActionResult(result, things.SummarizeAction("add", map[string]string{"title": title}, result))
+			return nil
+		}
+
+		db, err := things.OpenDB()
+		if err != nil {
+			formatter.PrintError("Failed to open Things database", "DATABASE_ERROR", err.Error())
+			return nil
+		}
+
+		if countOnly {
+			count, err := db.InboxCount()
+			if err != nil {
+				formatter.PrintError("Failed to query Things database", "DATABASE_ERROR", err.Error())
+				return nil
+			}
+			formatter.PrintCount(count)
+			return nil
+		}
+
+		items, err := db.Paged(listPage()).Inbox()
+		if err != nil {
+			formatter.PrintError("Failed to query Things database", "DATABASE_ERROR", err.Error())
+			return nil
+		}
+
+		formatter.PrintItemList("Inbox", items)
+		return nil
+	},
+}
+
+func init() {
+	pageable(inboxCmd)
+	inboxCmd.Flags().String("add", "", "Capture a to-do with this title into the Inbox")
+	inboxCmd.Flags().Bool("count", false, "Print only the number of to-dos in the Inbox")
+}
@@ -0,0 +1,85 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/yourusername/things3-cli/pkg/formatter"
+	"github.com/yourusername/things3-cli/pkg/things"
+	"github.com/yourusername/things3-cli/pkg/util"
+)
+
+// jsonValidateCmd checks a JSON payload without sending it
+var jsonValidateCmd = &cobra.Command{
+	Use:   "validate",
+	Short: "Check a JSON payload without sending it",
+	Long: `Check a JSON payload against the Things JSON format without sending it:
+entry types and operations, attribute names and types, IDs for updates, and
+when, deadline, and creation/completion dates. Every problem is reported with
+the path to the offending value.
+
+Examples:
+  things json validate --file payload.json
+  things json validate --data '[{"type":"to-do","attributes":{"title":"Milk","when":"today"}}]'`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		data, ok := readJSONPayload(cmd)
+		if !ok {
+			return nil
+		}
+
+		problems := things.ValidatePayload(data)
+		if len(problems) > 0 {
+			details := make([]string, len(problems))
+			for i, problem := range problems {
+				details[i] = problem.String()
+			}
+			message := fmt.Sprintf("JSON payload has %d problems", len(problems))
+			if len(problems) == 1 {
+				message = "JSON payload has a problem"
+			}
+			formatter.PrintError(message, "INVALID_ARGUMENTS", strings.Join(details, "\n"))
+			return nil
+		}
+
+		formatter.PrintSuccess(map[string]interface{}{
+			"valid": true,
+		})
+		return nil
+	},
+}
+
+// readJSONPayload returns the payload given with --data or read from --file,
+// printing an error and returning false when there is none.
+func readJSONPayload(cmd *cobra.Command) (string, bool) {
+	data, _ := cmd.Flags().GetString("data")
+	filePath, _ := cmd.Flags().GetString("file")
+
+	if filePath != "" {
+		expanded, err := util.ExpandHomePath(filePath)
+		if err != nil {
+			formatter.PrintError("Invalid file path", "INVALID_ARGUMENTS", err.Error())
+			return "", false
+		}
+		payload, err := os.ReadFile(expanded)
+		if err != nil {
+			formatter.PrintError("Failed to read JSON file", "FILE_ERROR", err.Error())
+			return "", false
+		}
+		data = string(payload)
+	}
+
+	if strings.TrimSpace(data) == "" {
+		formatter.PrintError("Provide --data or --file", "INVALID_ARGUMENTS", "")
+		return "", false
+	}
+	return data, true
+}
+
+func init() {
+	jsonValidateCmd.Flags().String("data", "", "JSON payload string")
+	jsonValidateCmd.Flags().String("file", "", "Path to JSON payload file")
+
+	jsonCmd.AddCommand(jsonValidateCmd)
+}
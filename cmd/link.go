@@ -0,0 +1,90 @@
+package cmd
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/yourusername/things3-cli/pkg/formatter"
+	"github.com/yourusername/things3-cli/pkg/things"
+)
+
+// linkCmd appends a markdown link to the notes of an item
+var linkCmd = &cobra.Command{
+	Use:   "link",
+	Short: "Append a link to the notes of a to-do or project",
+	Long: `Append a URL to the end of an item's notes as a markdown link, using --title
+as the link text when given. Nothing is sent if the notes already contain the
+URL. Requires an auth token.
+
+Examples:
+  things link --id "THINGS-ID" --url https://github.com/org/repo/pull/42 --title "PR #42"
+  things link --id "THINGS-ID" --url https://example.com/spec`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		id, _ := cmd.Flags().GetString("id")
+		if id == "" {
+			formatter.PrintError("Item ID (--id) is required", "INVALID_ARGUMENTS", "")
+			return nil
+		}
+		rawURL, _ := cmd.Flags().GetString("url")
+		rawURL = strings.TrimSpace(rawURL)
+		if rawURL == "" {
+			formatter.PrintError("URL (--url) is required", "INVALID_ARGUMENTS", "")
+			return nil
+		}
+		if parsed, err := url.Parse(rawURL); err != nil || parsed.Scheme == "" || (parsed.Host == "" && parsed.Opaque == "") {
+			formatter.PrintError("Invalid URL: "+rawURL, "INVALID_ARGUMENTS", "Use an absolute URL such as https://example.com")
+			return nil
+		}
+		title, _ := cmd.Flags().GetString("title")
+
+		items, ok := lookupItems([]string{id})
+		if !ok {
+			return nil
+		}
+		item := items[0]
+		if item.Type != "to-do" && item.Type != "project" {
+			formatter.PrintError("Only to-dos and projects have notes: "+id, "INVALID_ARGUMENTS", "")
+			return nil
+		}
+
+		if strings.Contains(item.Notes, rawURL) {
+			formatter.PrintSuccess(map[string]interface{}{
+				"id":      item.ID,
+				"url":     rawURL,
+				"changed": false,
+			})
+			return nil
+		}
+
+		link := markdownLink(title, rawURL)
+		if item.Notes != "" {
+			link = "\n" + link
+		}
+		params := map[string]string{"id": item.ID, "append-notes": link}
+		addStringParam(cmd, params, "auth-token", "auth-token")
+
+		action := "update"
+		if item.Type == "project" {
+			action = "update-project"
+		}
+		return runAction(action, params, things.ExecuteOptions{RequiresAuth: true, UseAuthIfAvailable: true})
+	},
+}
+
+// markdownLink formats a URL as a markdown link, or returns it bare without a title.
+func markdownLink(title, rawURL string) string {
+	title = strings.TrimSpace(title)
+	if title == "" {
+		return rawURL
+	}
+	title = strings.NewReplacer(`[`, `\[`, `]`, `\]`).Replace(title)
+	return "[" + title + "](" + rawURL + ")"
+}
+
+func init() {
+	linkCmd.Flags().String("id", "", "To-do or project ID (required)")
+	linkCmd.Flags().String("url", "", "URL to append (required)")
+	linkCmd.Flags().String("title", "", "Link text")
+	linkCmd.Flags().String("auth-token", "", "Things auth token (overrides config/ENV)")
+}
@@ -0,0 +1,73 @@
+package cmd
+
+import (
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/yourusername/things3-cli/pkg/formatter"
+	"github.com/yourusername/things3-cli/pkg/things"
+)
+
+// logCmd completes an item and records a closing note in one operation
+var logCmd = &cobra.Command{
+	Use:   "log",
+	Short: "Complete a to-do or project with a closing note",
+	Long: `Append a note, set the completion date to now, and complete the item in a
+single json update, then print the item as it now stands in the database.
+Requires an auth token.
+
+Example:
+  things log --id "THINGS-ID" --note "shipped in v2.3"`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		id, _ := cmd.Flags().GetString("id")
+		if id == "" {
+			formatter.PrintError("Item ID (--id) is required", "INVALID_ARGUMENTS", "")
+			return nil
+		}
+		note, _ := cmd.Flags().GetString("note")
+
+		items, ok := lookupItems([]string{id})
+		if !ok {
+			return nil
+		}
+		item := items[0]
+		if item.Status != "open" {
+			formatter.PrintError("Item is already "+item.Status+": "+id, "INVALID_ARGUMENTS", "")
+			return nil
+		}
+
+		attrs := map[string]interface{}{
+			"completed":       true,
+			"completion-date": time.Now().UTC().Format(time.RFC3339),
+		}
+		if note = strings.TrimSpace(note); note != "" {
+			if item.Notes != "" {
+				note = "\n" + note
+			}
+			attrs["append-notes"] = note
+		}
+
+		result, ok := executeJSONOperations(cmd, []things.JSONOperation{
+			things.NewUpdateOperation(item.Type, item.ID, attrs),
+		})
+		if !ok {
+			return nil
+		}
+
+		response := map[string]interface{}{"result": result}
+		if db, err := things.OpenDB(); err == nil {
+			if updated, err := db.FindItem(item.ID); err == nil {
+				response["item"] = updated
+			}
+		}
+		formatter.PrintSuccess(response)
+		return nil
+	},
+}
+
+func init() {
+	logCmd.Flags().String("id", "", "To-do or project ID (required)")
+	logCmd.Flags().String("note", "", "Note to append before completing")
+	logCmd.Flags().String("auth-token", "", "Things auth token (overrides config/ENV)")
+}
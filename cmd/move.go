@@ -0,0 +1,149 @@
+package cmd
+
+import (
+	"errors"
+
+	"github.com/spf13/cobra"
+	"github.com/yourusername/things3-cli/pkg/formatter"
+	"github.com/yourusername/things3-cli/pkg/things"
+)
+
+// moveCmd relocates a to-do or project to another project, heading, or area
+var moveCmd = &cobra.Command{
+	Use:   "move",
+	Short: "Move a to-do or project to another list",
+	Long: `Move a to-do to another project, heading, or area, or a project to another
+area. The destination is checked against the local Things database before the
+update is sent. Requires an auth token.
+
+Examples:
+  things move --id "THINGS-ID" --to "Website"
+  things move --id "THINGS-ID" --to "Website" --heading "Phase 2"
+  things move --id "THINGS-ID" --to-id "PROJECT-ID"
+  things move --id "PROJECT-ID" --area "Work"`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		id, _ := cmd.Flags().GetString("id")
+		if id == "" {
+			formatter.PrintError("Item ID (--id) is required", "INVALID_ARGUMENTS", "")
+			return nil
+		}
+
+		to, _ := cmd.Flags().GetString("to")
+		toID, _ := cmd.Flags().GetString("to-id")
+		heading, _ := cmd.Flags().GetString("heading")
+		area, _ := cmd.Flags().GetString("area")
+
+		if to == "" && toID == "" && heading == "" && area == "" {
+			formatter.PrintError("Provide --to, --to-id, --heading, or --area", "INVALID_ARGUMENTS", "")
+			return nil
+		}
+		if to != "" && toID != "" {
+			formatter.PrintError("Use either --to or --to-id, not both", "INVALID_ARGUMENTS", "")
+			return nil
+		}
+		if area != "" && (to != "" || toID != "") {
+			formatter.PrintError("--area cannot be combined with --to or --to-id", "INVALID_ARGUMENTS", "")
+			return nil
+		}
+
+		db, err := things.OpenDB()
+		if err != nil {
+			formatter.PrintError("Failed to open Things database", "DATABASE_ERROR", err.Error())
+			return nil
+		}
+
+		item, err := db.FindItem(id)
+		if err != nil {
+			printLookupError("Item", id, err)
+			return nil
+		}
+
+		params := map[string]string{"id": id}
+		addStringParam(cmd, params, "auth-token", "auth-token")
+
+		if item.Type == "project" {
+			if heading != "" {
+				formatter.PrintError("Projects cannot be moved under a heading", "INVALID_ARGUMENTS", "")
+				return nil
+			}
+			ref := area
+			if ref == "" {
+				ref = to + toID
+			}
+			dest, err := db.FindArea(ref)
+			if err != nil {
+				printLookupError("Area", ref, err)
+				return nil
+			}
+			params["area-id"] = dest.ID
+			return runAction("update-project", params, things.ExecuteOptions{RequiresAuth: true, UseAuthIfAvailable: true})
+		}
+
+		if item.Type != "to-do" {
+			formatter.PrintError("Only to-dos and projects can be moved", "INVALID_ARGUMENTS", "")
+			return nil
+		}
+
+		projectID := item.ProjectID
+		switch {
+		case area != "":
+			dest, err := db.FindArea(area)
+			if err != nil {
+				printLookupError("Area", area, err)
+				return nil
+			}
+			params["list-id"] = dest.ID
+			projectID = ""
+		case to != "" || toID != "":
+			ref := to + toID
+			if project, err := db.FindProject(ref); err == nil {
+				params["list-id"] = project.ID
+				projectID = project.ID
+			} else if errors.Is(err, things.ErrNotFound) {
+				dest, err := db.FindArea(ref)
+				if err != nil {
+					printLookupError("Project or area", ref, err)
+					return nil
+				}
+				params["list-id"] = dest.ID
+				projectID = ""
+			} else {
+				formatter.PrintError("Failed to query Things database", "DATABASE_ERROR", err.Error())
+				return nil
+			}
+		}
+
+		if heading != "" {
+			if projectID == "" {
+				formatter.PrintError("--heading requires a project destination", "INVALID_ARGUMENTS", "")
+				return nil
+			}
+			dest, err := db.FindHeading(projectID, heading)
+			if err != nil {
+				printLookupError("Heading", heading, err)
+				return nil
+			}
+			params["heading-id"] = dest.ID
+		}
+
+		return runAction("update", params, things.ExecuteOptions{RequiresAuth: true, UseAuthIfAvailable: true})
+	},
+}
+
+// printLookupError reports a failed database lookup, distinguishing missing rows from query failures
+func printLookupError(kind, ref string, err error) {
+	if errors.Is(err, things.ErrNotFound) {
+		formatter.PrintError(kind+" not found: "+ref, "NOT_FOUND", "")
+		return
+	}
+	formatter.PrintError("Failed to query Things database", "DATABASE_ERROR", err.Error())
+}
+
+func init() {
+	moveCmd.Flags().String("id", "", "To-do or project ID (required)")
+	moveCmd.Flags().String("to", "", "Destination project or area name")
+	moveCmd.Flags().String("to-id", "", "Destination project or area ID")
+	moveCmd.Flags().String("heading", "", "Destination heading name or ID within the project")
+	moveCmd.Flags().String("area", "", "Destination area name or ID")
+	moveCmd.Flags().String("auth-token", "", "Things auth token (overrides config/ENV)")
+}
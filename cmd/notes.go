@@ -0,0 +1,120 @@
+package cmd
+
+import (
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/yourusername/things3-cli/pkg/formatter"
+	"github.com/yourusername/things3-cli/pkg/things"
+)
+
+// notesCmd groups the notes subcommands
+var notesCmd = &cobra.Command{
+	Use:   "notes",
+	Short: "Edit the notes of a to-do or project",
+}
+
+// notesEditCmd opens the notes of an item in the user's editor
+var notesEditCmd = &cobra.Command{
+	Use:   "edit",
+	Short: "Edit the notes of a to-do or project in $EDITOR",
+	Long: `Read the current notes of an item from the local database, open them in
+$VISUAL or $EDITOR (vi if neither is set), and replace the notes with the saved
+text when the editor exits. Nothing is sent if the notes are unchanged or the
+editor exits with an error. Requires an auth token.
+
+Example:
+  things notes edit --id "THINGS-ID"`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		id, _ := cmd.Flags().GetString("id")
+		if id == "" {
+			formatter.PrintError("Item ID (--id) is required", "INVALID_ARGUMENTS", "")
+			return nil
+		}
+
+		items, ok := lookupItems([]string{id})
+		if !ok {
+			return nil
+		}
+		item := items[0]
+		if item.Type != "to-do" && item.Type != "project" {
+			formatter.PrintError("Only to-dos and projects have notes: "+id, "INVALID_ARGUMENTS", "")
+			return nil
+		}
+
+		edited, err := editText(item.Notes)
+		if err != nil {
+			formatter.PrintError("Failed to edit notes", "FILE_ERROR", err.Error())
+			return nil
+		}
+		// Editors usually end the file with a newline that was not in the notes
+		edited = strings.TrimRight(edited, "\n")
+		if edited == item.Notes {
+			formatter.PrintSuccess(map[string]interface{}{
+				"id":      item.ID,
+				"changed": false,
+			})
+			return nil
+		}
+
+		params := map[string]string{"id": item.ID, "notes": edited}
+		addStringParam(cmd, params, "auth-token", "auth-token")
+
+		action := "update"
+		if item.Type == "project" {
+			action = "update-project"
+		}
+		return runAction(action, params, things.ExecuteOptions{RequiresAuth: true, UseAuthIfAvailable: true})
+	},
+}
+
+// editText opens text in the user's editor and returns the saved result.
+func editText(text string) (string, error) {
+	file, err := os.CreateTemp("", "things-notes-*.md")
+	if err != nil {
+		return "", err
+	}
+	path := file.Name()
+	defer os.Remove(path)
+
+	if _, err := file.WriteString(text); err != nil {
+		file.Close()
+		return "", err
+	}
+	if err := file.Close(); err != nil {
+		return "", err
+	}
+
+	editor := os.Getenv("VISUAL")
+	if editor == "" {
+		editor = os.Getenv("EDITOR")
+	}
+	if editor == "" {
+		editor = "vi"
+	}
+
+	// The editor setting may carry arguments, as in "code --wait", so it is
+	// run through the shell with the file passed as a positional parameter.
+	editorCmd := exec.Command("sh", "-c", editor+` "$1"`, "sh", path)
+	editorCmd.Stdin = os.Stdin
+	editorCmd.Stdout = os.Stdout
+	editorCmd.Stderr = os.Stderr
+	if err := editorCmd.Run(); err != nil {
+		return "", err
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+func init() {
+	notesEditCmd.Flags().String("id", "", "To-do or project ID (required)")
+	notesEditCmd.Flags().String("auth-token", "", "Things auth token (overrides config/ENV)")
+
+	notesCmd.AddCommand(notesEditCmd)
+}
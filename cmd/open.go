@@ -0,0 +1,70 @@
+package cmd
+
+import (
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/yourusername/things3-cli/pkg/formatter"
+	"github.com/yourusername/things3-cli/pkg/things"
+)
+
+// builtInLists are the list IDs accepted by the show action
+var builtInLists = []string{
+	"inbox", "today", "anytime", "upcoming", "someday", "logbook",
+	"tomorrow", "deadlines", "repeating", "all-projects", "logged-projects",
+}
+
+// openCmd reveals an item or list in Things without waiting for a callback
+var openCmd = &cobra.Command{
+	Use:   "open [LIST | NAME]",
+	Short: "Reveal an item, project, area, or list in Things",
+	Long: `Bring Things to the front showing an item, a built-in list, or a project,
+area, or tag by name. Unlike show, nothing is sent back, so the command returns
+as soon as the URL has been handed to Things.
+
+Built-in lists: ` + strings.Join(builtInLists, ", ") + `
+
+Examples:
+  things open today
+  things open --id "THINGS-ID"
+  things open "Website Relaunch"`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		id, _ := cmd.Flags().GetString("id")
+		if id != "" && len(args) > 0 {
+			formatter.PrintError("Use either --id or a list/name, not both", "INVALID_ARGUMENTS", "")
+			return nil
+		}
+
+		params := make(map[string]string)
+		switch {
+		case id != "":
+			params["id"] = id
+		case len(args) > 0 && strings.TrimSpace(args[0]) != "":
+			target := strings.TrimSpace(args[0])
+			if list := strings.ToLower(target); isBuiltInList(list) {
+				params["id"] = list
+			} else {
+				params["query"] = target
+			}
+		default:
+			formatter.PrintError("Provide --id or a list/name to open", "INVALID_ARGUMENTS", "")
+			return nil
+		}
+
+		return runAction("show", params, things.ExecuteOptions{NoWait: true})
+	},
+}
+
+func isBuiltInList(name string) bool {
+	for _, list := range builtInLists {
+		if list == name {
+			return true
+		}
+	}
+	return false
+}
+
+func init() {
+	openCmd.Flags().String("id", "", "ID of the item, project, or area to reveal")
+}
@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/yourusername/things3-cli/pkg/formatter"
+	"github.com/yourusername/things3-cli/pkg/util"
+)
+
+// outputRedirect holds stdout while --output sends it to a temporary file
+// beside the target, which replaces the target only once the command succeeds
+type outputRedirect struct {
+	path   string
+	tmp    *os.File
+	stdout *os.File
+}
+
+// redirect is the active --output redirect, if any
+var redirect *outputRedirect
+
+// redirectOutput sends everything written to stdout to a temporary file
+// until FinishOutput. Format names are rejected, since --output used to
+// choose the format.
+func redirectOutput(path string) error {
+	for _, format := range formatter.OutputFormats() {
+		if path == format {
+			return fmt.Errorf("--output takes a file path; use --format %s to choose the format (or ./%s for a file of that name)", format, format)
+		}
+	}
+
+	path, err := util.ExpandHomePath(path)
+	if err != nil {
+		return fmt.Errorf("invalid output path: %w", err)
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("cannot write output: %w", err)
+	}
+
+	redirect = &outputRedirect{path: path, tmp: tmp, stdout: os.Stdout}
+	os.Stdout = tmp
+	return nil
+}
+
+// FinishOutput completes --output once the command has run. On success the
+// temporary file is renamed over the target, so readers never see a partial
+// file. On failure the target is left alone and the output, which holds the
+// error, is copied to stdout instead.
+func FinishOutput(success bool) error {
+	if redirect == nil {
+		return nil
+	}
+	r := redirect
+	redirect = nil
+	os.Stdout = r.stdout
+	defer os.Remove(r.tmp.Name())
+
+	if !success {
+		if _, err := r.tmp.Seek(0, io.SeekStart); err == nil {
+			io.Copy(os.Stdout, r.tmp)
+		}
+		r.tmp.Close()
+		return nil
+	}
+
+	if err := r.tmp.Chmod(0644); err != nil {
+		r.tmp.Close()
+		return fmt.Errorf("cannot write %s: %w", r.path, err)
+	}
+	if err := r.tmp.Sync(); err != nil {
+		r.tmp.Close()
+		return fmt.Errorf("cannot write %s: %w", r.path, err)
+	}
+	if err := r.tmp.Close(); err != nil {
+		return fmt.Errorf("cannot write %s: %w", r.path, err)
+	}
+	if err := os.Rename(r.tmp.Name(), r.path); err != nil {
+		return fmt.Errorf("cannot write %s: %w", r.path, err)
+	}
+	return nil
+}
@@ -0,0 +1,116 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/yourusername/things3-cli/pkg/formatter"
+	"github.com/yourusername/things3-cli/pkg/things"
+)
+
+// pinCmd adds an item to the working set, or lists the working set
+var pinCmd = &cobra.Command{
+	Use:   "pin",
+	Short: "Pin a to-do to the working set of tasks in flight",
+	Long: `Keep a small working set of the to-dos you are actively on, without tagging
+them in Things. Pinned items are listed at the top of things today and exposed
+to MCP clients as the things://pinned resource. Without --id, the working set
+is listed. Completed items drop out of the list automatically.
+
+Examples:
+  things pin --id "THINGS-ID"
+  things pin`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		db, err := things.OpenDB()
+		if err != nil {
+			formatter.PrintError("Failed to open Things database", "DATABASE_ERROR", err.Error())
+			return nil
+		}
+
+		if id, _ := cmd.Flags().GetString("id"); id != "" {
+			item, err := db.FindItem(id)
+			if err != nil {
+				printLookupError("Item", id, err)
+				return nil
+			}
+			if item.Type == "heading" {
+				formatter.PrintError("Only to-dos and projects can be pinned: "+id, "INVALID_ARGUMENTS", "")
+				return nil
+			}
+			if _, err := things.Pin(item.ID); err != nil {
+				formatter.PrintError("Failed to save working set", "STATE_ERROR", err.Error())
+				return nil
+			}
+		}
+
+		return printPinned(db)
+	},
+}
+
+// unpinCmd removes an item from the working set
+var unpinCmd = &cobra.Command{
+	Use:   "unpin",
+	Short: "Remove a to-do from the working set",
+	Long: `Remove an item from the working set, or empty it with --all.
+
+Examples:
+  things unpin --id "THINGS-ID"
+  things unpin --all`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		id, _ := cmd.Flags().GetString("id")
+		all, _ := cmd.Flags().GetBool("all")
+		if (id == "") == !all {
+			formatter.PrintError("Provide either --id or --all", "INVALID_ARGUMENTS", "")
+			return nil
+		}
+
+		if all {
+			if err := things.ClearPins(); err != nil {
+				formatter.PrintError("Failed to save working set", "STATE_ERROR", err.Error())
+				return nil
+			}
+		} else {
+			resolved, err := things.ResolveAlias(id)
+			if err != nil {
+				printLookupError("Item", id, err)
+				return nil
+			}
+			_, found, err := things.Unpin(resolved)
+			if err != nil {
+				formatter.PrintError("Failed to save working set", "STATE_ERROR", err.Error())
+				return nil
+			}
+			if !found {
+				formatter.PrintError("Item is not pinned: "+id, "NOT_FOUND", "")
+				return nil
+			}
+		}
+
+		db, err := things.OpenDB()
+		if err != nil {
+			formatter.PrintError("Failed to open Things database", "DATABASE_ERROR", err.Error())
+			return nil
+		}
+		return printPinned(db)
+	},
+}
+
+// printPinned prints the open items in the working set
+func printPinned(db *things.DB) error {
+	items, err := db.PinnedItems()
+	if err != nil {
+		formatter.PrintError("Failed to read working set", "DATABASE_ERROR", err.Error())
+		return nil
+	}
+
+	formatter.PrintSuccess(map[string]interface{}{
+		"count":  len(items),
+		"pinned": items,
+	})
+	return nil
+}
+
+func init() {
+	pinCmd.Flags().String("id", "", "To-do or project ID to pin")
+
+	unpinCmd.Flags().String("id", "", "To-do or project ID to unpin")
+	unpinCmd.Flags().Bool("all", false, "Empty the working set")
+}
@@ -0,0 +1,111 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/yourusername/things3-cli/pkg/formatter"
+	"github.com/yourusername/things3-cli/pkg/things"
+)
+
+// projectCmd groups commands that work on a whole project
+var projectCmd = &cobra.Command{
+	Use:   "project",
+	Short: "Manage the contents of a project",
+}
+
+// projectArchiveDoneCmd clears completed to-dos out of a project
+var projectArchiveDoneCmd = &cobra.Command{
+	Use:   "archive-done",
+	Short: "Move the completed to-dos of a project to the Logbook",
+	Long: `Clear completed and canceled to-dos out of a project, for example before
+sharing a status export. Things can only log completed items in every list at
+once, so this runs Log Completed for the whole app after listing the project's
+finished to-dos; it asks first unless --yes is given. With --trash, only the
+project's finished to-dos are moved to the Trash instead. Uses AppleScript.
+
+Examples:
+  things project archive-done --id "THINGS-ID"
+  things project archive-done --id @website --trash --yes`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		id, _ := cmd.Flags().GetString("id")
+		if id == "" {
+			formatter.PrintError("Project ID (--id) is required", "INVALID_ARGUMENTS", "")
+			return nil
+		}
+		trash, _ := cmd.Flags().GetBool("trash")
+
+		db, err := things.OpenDB()
+		if err != nil {
+			formatter.PrintError("Failed to open Things database", "DATABASE_ERROR", err.Error())
+			return nil
+		}
+		project, err := db.FindItem(id)
+		if err != nil {
+			printLookupError("Project", id, err)
+			return nil
+		}
+		if project.Type != "project" {
+			formatter.PrintError(id+" is a "+project.Type+", not a project", "INVALID_ARGUMENTS", "")
+			return nil
+		}
+
+		done, err := db.CompletedInProject(project.ID)
+		if err != nil {
+			formatter.PrintError("Failed to query Things database", "DATABASE_ERROR", err.Error())
+			return nil
+		}
+
+		report := map[string]interface{}{
+			"project": map[string]string{"id": project.ID, "title": project.Title},
+			"count":   len(done),
+			"items":   done,
+		}
+		if len(done) == 0 {
+			formatter.PrintSuccess(report)
+			return nil
+		}
+
+		if trash {
+			question := fmt.Sprintf("Move %d finished to-dos of %q to the Trash?", len(done), project.Title)
+			if !confirmDestructive(question, "Moving finished to-dos to the Trash") {
+				return nil
+			}
+			ids := make([]string, len(done))
+			for i, item := range done {
+				ids[i] = item.ID
+			}
+			if err := things.TrashItems(ids); err != nil {
+				if heldByDryRun(err) {
+					return nil
+				}
+				formatter.PrintError("Failed to move to-dos to the Trash", "THINGS_ERROR", err.Error())
+				return nil
+			}
+			report["action"] = "trashed"
+		} else {
+			question := "Things logs completed items in all lists at once. Log completed items now?"
+			if !confirmDestructive(question, "Logging completed items in all lists") {
+				return nil
+			}
+			if err := things.LogCompleted(); err != nil {
+				if heldByDryRun(err) {
+					return nil
+				}
+				formatter.PrintError("Failed to log completed items", "THINGS_ERROR", err.Error())
+				return nil
+			}
+			report["action"] = "logged"
+		}
+
+		formatter.PrintSuccess(report)
+		return nil
+	},
+}
+
+func init() {
+	projectArchiveDoneCmd.Flags().String("id", "", "Project ID (required)")
+	projectArchiveDoneCmd.Flags().Bool("trash", false, "Move the project's finished to-dos to the Trash instead of logging")
+
+	projectCmd.AddCommand(projectArchiveDoneCmd)
+}
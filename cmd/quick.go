@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/yourusername/things3-cli/pkg/formatter"
+	"github.com/yourusername/things3-cli/pkg/things"
+	"github.com/yourusername/things3-cli/pkg/things/quickadd"
+	"github.com/yourusername/things3-cli/pkg/util"
+)
+
+// quickCmd adds a to-do from a single line of quick-add syntax
+var quickCmd = &cobra.Command{
+	Use:   "quick TEXT",
+	Short: "Add a to-do using quick-add syntax",
+	Long: `Add a to-do from a single string. Words become the title, and these markers
+set the other fields:
+
+  #tag        add a tag (repeatable)
+  @when       schedule (today, evening/tonight, tomorrow, someday, a date, or @next-monday)
+  !deadline   deadline (a date or phrase such as !friday)
+  //notes     everything after // becomes the notes
+
+Quote multi-word values (@"next monday") or join them with hyphens.
+
+Examples:
+  things quick "Buy milk #errands @tomorrow !friday //pick up oat milk"
+  things quick "Renew passport #admin !end-of-month" --list "Personal"`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		text := strings.Join(args, " ")
+		if strings.TrimSpace(text) == "" {
+			formatter.PrintError("Quick-add text is required", "INVALID_ARGUMENTS", "")
+			return nil
+		}
+
+		parsed, err := quickadd.Parse(text)
+		if err != nil {
+			formatter.PrintError("Failed to parse quick-add text", "INVALID_ARGUMENTS", err.Error())
+			return nil
+		}
+
+		params := map[string]string{"title": parsed.Title}
+		if parsed.Notes != "" {
+			params["notes"] = parsed.Notes
+		}
+		if parsed.When != "" {
+			params["when"] = parsed.When
+		}
+		if parsed.Deadline != "" {
+			params["deadline"] = parsed.Deadline
+		}
+		if len(parsed.Tags) > 0 {
+			params["tags"] = util.JoinTags(parsed.Tags)
+		}
+		addStringParam(cmd, params, "list", "list")
+		addBoolParam(cmd, params, "reveal", "reveal")
+
+		return runAction("add", params, things.ExecuteOptions{})
+	},
+}
+
+func init() {
+	quickCmd.Flags().String("list", "", "List name or project title")
+	quickCmd.Flags().Bool("reveal", false, "Reveal the created to-do in Things")
+}
@@ -0,0 +1,97 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/yourusername/things3-cli/pkg/formatter"
+	"github.com/yourusername/things3-cli/pkg/things"
+	"github.com/yourusername/things3-cli/pkg/util"
+)
+
+// releaseNotesCmd drafts release notes from commits and completed to-dos
+var releaseNotesCmd = &cobra.Command{
+	Use:   "release-notes",
+	Short: "Draft release notes from commits and completed to-dos since a tag",
+	Long: `Draft release notes for a git repository. Commits since --since-tag are
+listed, and to-dos in --project that were created by import git from this
+repository and completed after the tag are listed as resolved, together with
+the commits since the tag that changed the file of their comment.
+
+The notes are written to stdout; --output writes them to a file, replacing the
+file only once the notes are complete. --as chooses the format of the notes.
+It takes the place of a --format flag, because --format is the global option
+that sets the format of the command's own messages.
+
+Examples:
+  things release-notes --project "API" --since-tag v1.2
+  things release-notes --project @api --since-tag v1.2 --repo ~/src/api --as json
+  things release-notes --project @api --since-tag v1.2 -o CHANGES.md`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ref, _ := cmd.Flags().GetString("project")
+		if ref == "" {
+			formatter.PrintError("Project (--project) is required", "INVALID_ARGUMENTS", "")
+			return nil
+		}
+		tag, _ := cmd.Flags().GetString("since-tag")
+		if tag == "" {
+			formatter.PrintError("Tag (--since-tag) is required", "INVALID_ARGUMENTS", "")
+			return nil
+		}
+		format, _ := cmd.Flags().GetString("as")
+		if format != "markdown" && format != "json" {
+			formatter.PrintError("Notes format (--as) must be markdown or json", "INVALID_ARGUMENTS", "")
+			return nil
+		}
+
+		repo, _ := cmd.Flags().GetString("repo")
+		repo, err := util.ExpandHomePath(repo)
+		if err != nil {
+			formatter.PrintError("Invalid repository path", "FILE_ERROR", err.Error())
+			return nil
+		}
+
+		db, err := things.OpenDB()
+		if err != nil {
+			formatter.PrintError("Failed to open Things database", "DATABASE_ERROR", err.Error())
+			return nil
+		}
+		project, err := db.FindProject(ref)
+		if err != nil {
+			printLookupError("Project", ref, err)
+			return nil
+		}
+
+		notes, err := things.BuildReleaseNotes(db, project, repo, tag)
+		if err != nil {
+			formatter.PrintError("Failed to draft release notes", "FILE_ERROR", err.Error())
+			return nil
+		}
+
+		var out bytes.Buffer
+		if format == "markdown" {
+			err = formatter.WriteReleaseNotesMarkdown(&out, notes)
+		} else {
+			var data []byte
+			if data, err = json.MarshalIndent(notes, "", "  "); err == nil {
+				out.Write(append(data, '\n'))
+			}
+		}
+		if err != nil {
+			formatter.PrintError("Failed to render release notes", "FORMAT_ERROR", err.Error())
+			return nil
+		}
+
+		os.Stdout.Write(out.Bytes())
+		return nil
+	},
+}
+
+func init() {
+	releaseNotesCmd.Flags().String("project", "", "Project title, ID, or @alias holding the imported to-dos (required)")
+	releaseNotesCmd.Flags().String("since-tag", "", "Tag of the previous release (required)")
+	releaseNotesCmd.Flags().String("repo", ".", "Path to the git repository")
+	releaseNotesCmd.Flags().String("as", "markdown", "Notes format: markdown or json (used instead of --format, which formats messages)")
+}
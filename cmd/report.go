@@ -0,0 +1,106 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/yourusername/things3-cli/pkg/formatter"
+	"github.com/yourusername/things3-cli/pkg/things"
+	"github.com/yourusername/things3-cli/pkg/util"
+)
+
+// reportCmd groups the report subcommands
+var reportCmd = &cobra.Command{
+	Use:   "report",
+	Short: "Generate reports from the Things database",
+}
+
+// reportShareCmd renders a project status page for collaborators
+var reportShareCmd = &cobra.Command{
+	Use:   "share",
+	Short: "Generate a project status page to share with people who don't use Things",
+	Long: `Generate a status page for a project: open, completed, and canceled counts,
+progress per heading, the next open deadlines, and to-dos completed recently.
+HTML output is a single self-contained page that can be attached to or pasted
+into an email. Use --redact-notes to leave out all notes.
+
+The report is written to stdout; --output writes it to a file, replacing the
+file only once the report is complete. --as chooses the report format. It
+takes the place of a --format flag, because --format is the global option that
+sets the format of the command's own messages.
+
+Examples:
+  things report share --project "Website" --as html --redact-notes > status.html
+  things report share --project @web --as markdown --since 2w
+  things report share --project @web -o ~/Desktop/website-status.html`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ref, _ := cmd.Flags().GetString("project")
+		if ref == "" {
+			formatter.PrintError("Project (--project) is required", "INVALID_ARGUMENTS", "")
+			return nil
+		}
+
+		format, _ := cmd.Flags().GetString("as")
+		if format != "html" && format != "markdown" && format != "json" {
+			formatter.PrintError("Report format (--as) must be html, markdown, or json", "INVALID_ARGUMENTS", "")
+			return nil
+		}
+
+		sinceFlag, _ := cmd.Flags().GetString("since")
+		since, err := util.ParseSince(sinceFlag, util.Now())
+		if err != nil {
+			formatter.PrintError(err.Error(), "INVALID_ARGUMENTS", "")
+			return nil
+		}
+
+		db, err := things.OpenDB()
+		if err != nil {
+			formatter.PrintError("Failed to open Things database", "DATABASE_ERROR", err.Error())
+			return nil
+		}
+		project, err := db.FindProject(ref)
+		if err != nil {
+			printLookupError("Project", ref, err)
+			return nil
+		}
+
+		redact, _ := cmd.Flags().GetBool("redact-notes")
+		report, err := things.BuildShareReport(db, project, since, redact)
+		if err != nil {
+			formatter.PrintError("Failed to query Things database", "DATABASE_ERROR", err.Error())
+			return nil
+		}
+
+		// Rendered in full before writing, so a failure leaves no partial report
+		var out bytes.Buffer
+		switch format {
+		case "html":
+			err = formatter.WriteShareReportHTML(&out, report)
+		case "markdown":
+			err = formatter.WriteShareReportMarkdown(&out, report)
+		default:
+			var data []byte
+			if data, err = json.MarshalIndent(report, "", "  "); err == nil {
+				out.Write(append(data, '\n'))
+			}
+		}
+		if err != nil {
+			formatter.PrintError("Failed to render report", "FORMAT_ERROR", err.Error())
+			return nil
+		}
+
+		os.Stdout.Write(out.Bytes())
+		return nil
+	},
+}
+
+func init() {
+	reportShareCmd.Flags().String("project", "", "Project title, ID, or @alias (required)")
+	reportShareCmd.Flags().String("as", "html", "Report format: html, markdown, or json (used instead of --format, which formats messages)")
+	reportShareCmd.Flags().Bool("redact-notes", false, "Leave out project and to-do notes")
+	reportShareCmd.Flags().String("since", "2w", "How far back to list completions (12h, 3d, 1w, 2m, or a date)")
+
+	reportCmd.AddCommand(reportShareCmd)
+}
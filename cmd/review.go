@@ -0,0 +1,169 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/yourusername/things3-cli/pkg/formatter"
+	"github.com/yourusername/things3-cli/pkg/things"
+)
+
+// reviewKeys maps the answers accepted during a review to their actions
+var reviewKeys = map[string]things.ReviewAction{
+	"":  things.ReviewKeep,
+	"k": things.ReviewKeep,
+	"t": things.ReviewToday,
+	"s": things.ReviewSomeday,
+	"d": things.ReviewComplete,
+	"c": things.ReviewCancel,
+}
+
+// reviewStepSummary reports how far a review step got
+type reviewStepSummary struct {
+	Name     string `json:"name"`
+	Title    string `json:"title"`
+	Count    int    `json:"count"`
+	Reviewed int    `json:"reviewed"`
+}
+
+// reviewCmd walks through a weekly review of the Things database
+var reviewCmd = &cobra.Command{
+	Use:   "review",
+	Short: "Walk through a weekly review",
+	Long: `Go through the items that need a decision, step by step: the Inbox, overdue
+items, deadlines in the next two weeks, projects without an open to-do, and
+Someday. For each item, answer:
+
+  k or Enter  keep it as it is
+  t           move it to Today
+  s           move it to Someday
+  d           mark it done
+  c           cancel it
+  n           skip the rest of this step
+  q           end the review
+
+Changes are sent together in one json update at the end, followed by a summary
+of the review. Nothing is sent with --dry-run. Without a terminal, or with
+--list, the items are printed without prompting. Requires an auth token to
+apply changes.
+
+Examples:
+  things review
+  things review --steps inbox,overdue
+  things review --list`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		steps, _ := cmd.Flags().GetStringSlice("steps")
+		list, _ := cmd.Flags().GetBool("list")
+
+		for _, name := range steps {
+			if !slices.Contains(things.ReviewStepNames, name) {
+				formatter.PrintError("Unknown review step: "+name, "INVALID_ARGUMENTS", "Use "+strings.Join(things.ReviewStepNames, ", "))
+				return nil
+			}
+		}
+
+		db, err := things.OpenDB()
+		if err != nil {
+			formatter.PrintError("Failed to open Things database", "DATABASE_ERROR", err.Error())
+			return nil
+		}
+		review, err := things.BuildReview(db, steps)
+		if err != nil {
+			formatter.PrintError("Failed to query Things database", "DATABASE_ERROR", err.Error())
+			return nil
+		}
+
+		if list || !isInteractive() {
+			formatter.PrintSuccess(map[string]interface{}{"steps": review})
+			return nil
+		}
+
+		summary, decisions := runReview(review)
+
+		var ops []things.JSONOperation
+		counts := make(map[things.ReviewAction]int)
+		for _, decision := range decisions {
+			if op, ok := decision.Operation(); ok {
+				ops = append(ops, op)
+				counts[decision.Action]++
+			}
+		}
+
+		response := map[string]interface{}{
+			"steps":     summary,
+			"decisions": decisions,
+			"changes":   counts,
+			"dry_run":   dryRunMode,
+		}
+		if len(ops) > 0 && !dryRunMode {
+			result, ok := executeJSONOperations(cmd, ops)
+			if !ok {
+				return nil
+			}
+			response["result"] = result
+		}
+		formatter.PrintSuccess(response)
+		return nil
+	},
+}
+
+// runReview prompts for an action on each item of each step on stderr, and
+// returns how far each step got and the decisions that change something.
+func runReview(review []things.ReviewStep) ([]reviewStepSummary, []things.ReviewDecision) {
+	summary := make([]reviewStepSummary, len(review))
+	var decisions []things.ReviewDecision
+
+	for i, step := range review {
+		summary[i] = reviewStepSummary{Name: step.Name, Title: step.Title, Count: len(step.Items)}
+		fmt.Fprintf(os.Stderr, "\n%s (%d)\n", step.Title, len(step.Items))
+		if len(step.Items) == 0 {
+			fmt.Fprintln(os.Stderr, "  nothing here")
+			continue
+		}
+
+	items:
+		for j, item := range step.Items {
+			fmt.Fprintf(os.Stderr, "  %d/%d %s\n", j+1, len(step.Items), formatter.FormatItemLine(item))
+			for {
+				fmt.Fprint(os.Stderr, "  keep (k), today (t), someday (s), done (d), cancel (c), next step (n), quit (q)? ")
+				answer, err := stdinReader.ReadString('\n')
+				answer = strings.ToLower(strings.TrimSpace(answer))
+				if err != nil && answer == "" {
+					answer = "q"
+				}
+
+				switch answer {
+				case "n":
+					break items
+				case "q":
+					return summary, decisions
+				}
+				action, ok := reviewKeys[answer]
+				if !ok {
+					continue
+				}
+				summary[i].Reviewed++
+				if action != things.ReviewKeep {
+					decisions = append(decisions, things.ReviewDecision{
+						Step:   step.Name,
+						ID:     item.ID,
+						Type:   item.Type,
+						Title:  item.Title,
+						Action: action,
+					})
+				}
+				break
+			}
+		}
+	}
+	return summary, decisions
+}
+
+func init() {
+	reviewCmd.Flags().StringSlice("steps", things.ReviewStepNames, "Review steps to go through, in order: "+strings.Join(things.ReviewStepNames, ", "))
+	reviewCmd.Flags().Bool("list", false, "Print the items of each step without prompting")
+	reviewCmd.Flags().String("auth-token", "", "Things auth token (overrides config/ENV)")
+}
@@ -0,0 +1,129 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/yourusername/things3-cli/pkg/formatter"
+	"github.com/yourusername/things3-cli/pkg/things"
+	"github.com/yourusername/things3-cli/pkg/util"
+)
+
+// rolledItem describes one to-do moved forward by rollover
+type rolledItem struct {
+	ID          string `json:"id"`
+	Title       string `json:"title"`
+	PlannedFor  string `json:"planned_for"`
+	Rollovers   int    `json:"rollovers"`
+	RolloverTag string `json:"rollover_tag"`
+}
+
+// rolloverCmd moves unfinished to-dos from earlier days onto today
+var rolloverCmd = &cobra.Command{
+	Use:   "rollover",
+	Short: "Move yesterday's unfinished to-dos onto today",
+	Long: `Find open to-dos that were scheduled for an earlier day, reschedule them for
+today, and tag each with how many times it has rolled over (rollover-1,
+rollover-2, ...). Things only applies tags that already exist, so create the
+rollover tags you want to track in the app first. Designed to run nightly from
+cron or launchd. Requires an auth token.
+
+Examples:
+  things rollover --yes
+  things rollover --ask
+  things rollover --tag-prefix carried`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ask, _ := cmd.Flags().GetBool("ask")
+		prefix, _ := cmd.Flags().GetString("tag-prefix")
+
+		if ask && !isInteractive() {
+			formatter.PrintError("--ask requires an interactive terminal", "INVALID_ARGUMENTS", "")
+			return nil
+		}
+
+		db, err := things.OpenDB()
+		if err != nil {
+			formatter.PrintError("Failed to open Things database", "DATABASE_ERROR", err.Error())
+			return nil
+		}
+
+		now := util.Now()
+		today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+		stale, err := db.ScheduledBefore(today)
+		if err != nil {
+			formatter.PrintError("Failed to query Things database", "DATABASE_ERROR", err.Error())
+			return nil
+		}
+
+		rolled := []rolledItem{}
+		skipped := []string{}
+		var ops []things.JSONOperation
+		for _, item := range stale {
+			if ask && !promptYesNo(fmt.Sprintf("Roll over %q (planned for %s)?", item.Title, item.StartDate)) {
+				skipped = append(skipped, item.ID)
+				continue
+			}
+
+			count, tags := nextRolloverTags(item.Tags, prefix)
+			rolled = append(rolled, rolledItem{
+				ID:          item.ID,
+				Title:       item.Title,
+				PlannedFor:  item.StartDate,
+				Rollovers:   count,
+				RolloverTag: tags[len(tags)-1],
+			})
+			ops = append(ops, things.NewUpdateOperation(item.Type, item.ID, map[string]interface{}{
+				"when": "today",
+				"tags": tags,
+			}))
+		}
+
+		summary := map[string]interface{}{
+			"date":        today.Format(util.DateLayout),
+			"rolled_over": len(rolled),
+			"skipped":     len(skipped),
+			"items":       rolled,
+		}
+
+		if len(ops) > 0 {
+			result, ok := executeJSONOperations(cmd, ops)
+			if !ok {
+				return nil
+			}
+			summary["result"] = result
+		}
+
+		formatter.PrintSuccess(summary)
+		return nil
+	},
+}
+
+// nextRolloverTags replaces any existing "<prefix>-N" tag with "<prefix>-(N+1)",
+// returning the new count and the tag set with the rollover tag last
+func nextRolloverTags(tags []string, prefix string) (int, []string) {
+	count := 0
+	var kept []string
+	for _, tag := range tags {
+		if n, ok := strings.CutPrefix(tag, prefix+"-"); ok {
+			if parsed, err := strconv.Atoi(n); err == nil {
+				if parsed > count {
+					count = parsed
+				}
+				continue
+			}
+		}
+		kept = append(kept, tag)
+	}
+
+	count++
+	return count, append(kept, fmt.Sprintf("%s-%d", prefix, count))
+}
+
+func init() {
+	rolloverCmd.Flags().Bool("ask", false, "Ask before rolling over each to-do")
+	rolloverCmd.Flags().String("tag-prefix", "rollover", "Prefix of the rollover count tag")
+	rolloverCmd.Flags().String("auth-token", "", "Things auth token (overrides config/ENV)")
+}
@@ -0,0 +1,107 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/yourusername/things3-cli/pkg/formatter"
+	"github.com/yourusername/things3-cli/pkg/things"
+)
+
+// scheduleCmd reschedules one or more items in a single invocation
+var scheduleCmd = &cobra.Command{
+	Use:   "schedule [ID...]",
+	Short: "Reschedule one or more to-dos or projects",
+	Long: `Set the when date on one or more items without touching any other field.
+IDs can be given with repeated --id flags or as arguments. All items are
+updated through a single JSON payload. Requires an auth token.
+
+Examples:
+  things schedule --id "THINGS-ID" --when tomorrow
+  things schedule --when 2024-06-01 ID-1 ID-2 ID-3`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ids := collectIDs(cmd, args)
+		if len(ids) == 0 {
+			formatter.PrintError("At least one item ID (--id) is required", "INVALID_ARGUMENTS", "")
+			return nil
+		}
+
+		when, _ := cmd.Flags().GetString("when")
+		if when == "" {
+			formatter.PrintError("Schedule (--when) is required", "INVALID_ARGUMENTS", "")
+			return nil
+		}
+
+		items, ok := lookupItems(ids)
+		if !ok {
+			return nil
+		}
+
+		ops := make([]things.JSONOperation, 0, len(items))
+		for _, item := range items {
+			ops = append(ops, things.NewUpdateOperation(item.Type, item.ID, map[string]interface{}{"when": when}))
+		}
+
+		return runJSONOperations(cmd, ops)
+	},
+}
+
+// collectIDs merges repeated --id flags with positional arguments
+func collectIDs(cmd *cobra.Command, args []string) []string {
+	ids, _ := cmd.Flags().GetStringArray("id")
+	return append(ids, args...)
+}
+
+// lookupItems loads each ID from the database so its type is known before updating.
+// Errors are printed and reported through the boolean result.
+func lookupItems(ids []string) ([]things.Item, bool) {
+	db, err := things.OpenDB()
+	if err != nil {
+		formatter.PrintError("Failed to open Things database", "DATABASE_ERROR", err.Error())
+		return nil, false
+	}
+
+	items := make([]things.Item, 0, len(ids))
+	for _, id := range ids {
+		item, err := db.FindItem(id)
+		if err != nil {
+			printLookupError("Item", id, err)
+			return nil, false
+		}
+		if item.Type != "to-do" && item.Type != "project" {
+			formatter.PrintError("Only to-dos and projects can be updated: "+id, "INVALID_ARGUMENTS", "")
+			return nil, false
+		}
+		items = append(items, *item)
+	}
+	return items, true
+}
+
+// runJSONOperations sends update operations through the json action and prints the result
+func runJSONOperations(cmd *cobra.Command, ops []things.JSONOperation) error {
+	result, ok := executeJSONOperations(cmd, ops)
+	if ok {
+		data, _ := things.EncodeJSONPayload(ops)
+		formatter.PrintActionResult(result, things.SummarizeAction("json", map[string]string{"data": data}, result))
+	}
+	return nil
+}
+
+// executeJSONOperations sends update operations through the json action.
+// Errors are printed and reported through the boolean result.
+func executeJSONOperations(cmd *cobra.Command, ops []things.JSONOperation) (things.ActionResult, bool) {
+	data, err := things.EncodeJSONPayload(ops)
+	if err != nil {
+		formatter.PrintError("Failed to build JSON payload", "INVALID_ARGUMENTS", err.Error())
+		return things.ActionResult{}, false
+	}
+
+	params := map[string]string{"data": data}
+	addStringParam(cmd, params, "auth-token", "auth-token")
+
+	return executeAction("json", params, things.ExecuteOptions{RequiresAuth: true, UseAuthIfAvailable: true})
+}
+
+func init() {
+	scheduleCmd.Flags().StringArray("id", []string{}, "Item ID (repeat flag)")
+	scheduleCmd.Flags().String("when", "", "When to schedule (today, evening/tonight, tomorrow, anytime, someday, or date)")
+	scheduleCmd.Flags().String("auth-token", "", "Things auth token (overrides config/ENV)")
+}
@@ -0,0 +1,222 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/yourusername/things3-cli/pkg/formatter"
+	"github.com/yourusername/things3-cli/pkg/things"
+)
+
+// selftestProject is the project the end-to-end test works in by default
+const selftestProject = "things3-cli self-test"
+
+// selftestStep is the outcome of one self-test step
+type selftestStep struct {
+	Name       string `json:"name"`
+	Passed     bool   `json:"passed"`
+	Skipped    bool   `json:"skipped,omitempty"`
+	DurationMS int64  `json:"duration_ms"`
+	Detail     string `json:"detail,omitempty"`
+	Error      string `json:"error,omitempty"`
+}
+
+// selftestCmd groups the self-test subcommands
+var selftestCmd = &cobra.Command{
+	Use:   "selftest",
+	Short: "Check that the CLI can still drive Things",
+}
+
+// selftestE2ECmd runs add, update, complete, and search against the real app
+var selftestE2ECmd = &cobra.Command{
+	Use:   "e2e",
+	Short: "Run an add, update, complete, and search round trip",
+	Long: `Create a to-do in a dedicated project, rename it, complete it, and find it in
+the database, reporting pass or fail for each step. The to-do is moved to the
+Trash afterwards unless --keep is given. Run it after macOS or Things updates
+to catch a broken setup before scheduled jobs fail silently. Requires an auth
+token and --real, since it changes the Things database.
+
+The project (--project, default "things3-cli self-test") is created if it
+does not exist.
+
+There is no sandbox backend: the CLI reaches Things only through its URL
+scheme and database, so --use-sandbox is rejected.
+
+Examples:
+  things selftest e2e --real
+  things selftest e2e --real --project "Automation checks" --keep`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if sandbox, _ := cmd.Flags().GetBool("use-sandbox"); sandbox {
+			formatter.PrintError("No sandbox backend is available", "INVALID_ARGUMENTS",
+				"The CLI talks to Things only through its URL scheme and database; run the test against the app with --real")
+			return nil
+		}
+		if useReal, _ := cmd.Flags().GetBool("real"); !useReal {
+			formatter.PrintError("Pass --real to run the test against the Things app", "INVALID_ARGUMENTS",
+				"The test creates, completes, and trashes a to-do in a dedicated project")
+			return nil
+		}
+
+		project, _ := cmd.Flags().GetString("project")
+		timeout, _ := cmd.Flags().GetDuration("timeout")
+		keep, _ := cmd.Flags().GetBool("keep")
+
+		client, err := things.NewClient()
+		if err != nil {
+			formatter.PrintError("Failed to initialize Things client", "CLIENT_ERROR", err.Error())
+			return nil
+		}
+		if token, _ := cmd.Flags().GetString("auth-token"); token != "" {
+			client.AuthToken = token
+		}
+
+		stamp := time.Now().Format("20060102-150405")
+		title := "Self-test " + stamp
+		renamed := title + " (updated)"
+		var id string
+		var db *things.DB
+
+		steps := []struct {
+			name string
+			run  func() (string, error)
+		}{
+			{"database", func() (string, error) {
+				if db, err = things.OpenDB(); err != nil {
+					return "", err
+				}
+				return db.Path, nil
+			}},
+			{"project", func() (string, error) {
+				if _, err := db.FindProject(project); err == nil {
+					return "exists", nil
+				} else if !errors.Is(err, things.ErrNotFound) {
+					return "", err
+				}
+				if _, err := client.Execute("add-project", map[string]string{"title": project}, things.ExecuteOptions{}); err != nil {
+					return "", err
+				}
+				return "created", nil
+			}},
+			{"add", func() (string, error) {
+				callback, err := client.Execute("add", map[string]string{"title": title, "list": project}, things.ExecuteOptions{})
+				if err != nil {
+					return "", err
+				}
+				result := things.NormalizeResponse("add", callback)
+				if id = result.ThingsID; id == "" && len(result.ThingsIDs) > 0 {
+					id = result.ThingsIDs[0]
+				}
+				if id == "" {
+					return "", fmt.Errorf("Things returned no ID for the new to-do")
+				}
+				return id, nil
+			}},
+			{"update", func() (string, error) {
+				_, err := client.Execute("update", map[string]string{"id": id, "title": renamed}, things.ExecuteOptions{RequiresAuth: true, UseAuthIfAvailable: true})
+				return renamed, err
+			}},
+			{"complete", func() (string, error) {
+				_, err := client.Execute("update", map[string]string{"id": id, "completed": "true"}, things.ExecuteOptions{RequiresAuth: true, UseAuthIfAvailable: true})
+				return "", err
+			}},
+			{"search", func() (string, error) {
+				return selftestSearch(db, id, stamp, renamed, timeout)
+			}},
+			{"cleanup", func() (string, error) {
+				if keep {
+					return "kept " + id, nil
+				}
+				return "moved to Trash", things.TrashItems([]string{id})
+			}},
+		}
+
+		report := make([]selftestStep, 0, len(steps))
+		var failed *selftestStep
+		for _, step := range steps {
+			result := selftestStep{Name: step.name}
+			if failed != nil && !(step.name == "cleanup" && id != "") {
+				result.Skipped = true
+				report = append(report, result)
+				continue
+			}
+
+			start := time.Now()
+			detail, err := step.run()
+			if heldByDryRun(err) {
+				// The round trip needs the real IDs, so it stops at the first action
+				return nil
+			}
+			result.DurationMS = time.Since(start).Milliseconds()
+			result.Detail = detail
+			if err != nil {
+				result.Error = err.Error()
+			} else {
+				result.Passed = true
+			}
+			report = append(report, result)
+			if err != nil && failed == nil {
+				failed = &report[len(report)-1]
+			}
+		}
+
+		if failed != nil {
+			lines := make([]string, len(report))
+			for i, step := range report {
+				switch {
+				case step.Skipped:
+					lines[i] = step.Name + ": skipped"
+				case step.Passed:
+					lines[i] = step.Name + ": passed"
+				default:
+					lines[i] = step.Name + ": failed: " + step.Error
+				}
+			}
+			formatter.PrintError("Self-test failed at step "+failed.Name, "SELFTEST_FAILED", strings.Join(lines, "; "))
+			return nil
+		}
+
+		formatter.PrintSuccess(map[string]interface{}{
+			"passed":  true,
+			"project": project,
+			"steps":   report,
+		})
+		return nil
+	},
+}
+
+// selftestSearch waits for the completed to-do to show up in a database
+// search, since Things writes changes to disk shortly after applying them.
+func selftestSearch(db *things.DB, id, stamp, title string, timeout time.Duration) (string, error) {
+	query := things.Query{Words: []string{stamp}, Status: "completed"}
+	deadline := time.Now().Add(timeout)
+	for {
+		items, err := db.Search(query)
+		if err != nil {
+			return "", err
+		}
+		for _, item := range items {
+			if item.ID == id && item.Title == title {
+				return "found " + id, nil
+			}
+		}
+		if time.Now().After(deadline) {
+			return "", fmt.Errorf("completed to-do %s not found in the database after %s", id, timeout)
+		}
+		time.Sleep(500 * time.Millisecond)
+	}
+}
+
+func init() {
+	selftestE2ECmd.Flags().Bool("real", false, "Run against the Things app (required)")
+	selftestE2ECmd.Flags().Bool("use-sandbox", false, "Run against a sandbox backend (not available)")
+	selftestE2ECmd.Flags().String("project", selftestProject, "Project to create the test to-do in")
+	selftestE2ECmd.Flags().Duration("timeout", 10*time.Second, "How long to wait for changes to reach the database")
+	selftestE2ECmd.Flags().Bool("keep", false, "Leave the completed test to-do instead of trashing it")
+	selftestE2ECmd.Flags().String("auth-token", "", "Things auth token (overrides config/ENV)")
+
+	selftestCmd.AddCommand(selftestE2ECmd)
+}
@@ -0,0 +1,58 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// serveEnv returns the environment variable that sets a serve flag, such as
+// THINGS_MCP_HOST for --host.
+func serveEnv(flag string) string {
+	return "THINGS_MCP_" + strings.ToUpper(strings.ReplaceAll(flag, "-", "_"))
+}
+
+// serveString returns a serve flag given on the command line, else its
+// THINGS_MCP_ environment variable, else the flag's default.
+func serveString(cmd *cobra.Command, flag string) string {
+	if !cmd.Flags().Changed(flag) {
+		if value, ok := os.LookupEnv(serveEnv(flag)); ok {
+			return value
+		}
+	}
+	value, _ := cmd.Flags().GetString(flag)
+	return value
+}
+
+// serveList returns a comma-separated serve flag or its environment variable.
+func serveList(cmd *cobra.Command, flag string) []string {
+	if !cmd.Flags().Changed(flag) {
+		if value, ok := os.LookupEnv(serveEnv(flag)); ok && value != "" {
+			return strings.Split(value, ",")
+		}
+	}
+	value, _ := cmd.Flags().GetStringSlice(flag)
+	return value
+}
+
+// servePort returns the port from --port or THINGS_MCP_PORT.
+func servePort(cmd *cobra.Command) (int, error) {
+	port, _ := cmd.Flags().GetInt("port")
+	source := "--port"
+	if !cmd.Flags().Changed("port") {
+		if value, ok := os.LookupEnv(serveEnv("port")); ok {
+			parsed, err := strconv.Atoi(strings.TrimSpace(value))
+			if err != nil {
+				return 0, fmt.Errorf("invalid %s %q: not a number", serveEnv("port"), value)
+			}
+			port, source = parsed, serveEnv("port")
+		}
+	}
+	if port < 1 || port > 65535 {
+		return 0, fmt.Errorf("invalid %s %d: must be between 1 and 65535", source, port)
+	}
+	return port, nil
+}
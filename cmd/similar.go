@@ -0,0 +1,75 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/yourusername/things3-cli/pkg/formatter"
+	"github.com/yourusername/things3-cli/pkg/things"
+)
+
+// similarCmd lists existing items that resemble a given item
+var similarCmd = &cobra.Command{
+	Use:   "similar",
+	Short: "Find items similar to a to-do or project",
+	Long: `List the to-dos and projects most similar to an item, to spot duplicates or
+find how something similar was handled before. By default items are compared
+by shared words in their titles and notes, including completed items in the
+Logbook. With --semantic, open items are compared by meaning using the local
+embedding index (see search --semantic).
+
+Examples:
+  things similar --id "THINGS-ID"
+  things similar --id "THINGS-ID" --limit 10 --semantic`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		id, _ := cmd.Flags().GetString("id")
+		if id == "" {
+			formatter.PrintError("Item ID (--id) is required", "INVALID_ARGUMENTS", "")
+			return nil
+		}
+		limit, _ := cmd.Flags().GetInt("limit")
+		semantic, _ := cmd.Flags().GetBool("semantic")
+
+		db, err := things.OpenDB()
+		if err != nil {
+			formatter.PrintError("Failed to open Things database", "DATABASE_ERROR", err.Error())
+			return nil
+		}
+
+		item, err := db.FindItem(id)
+		if err != nil {
+			printLookupError("Item", id, err)
+			return nil
+		}
+
+		var results []things.ScoredItem
+		if semantic {
+			results, err = things.SimilarItemsSemantic(db, things.NewEmbedder(), *item)
+			if err != nil {
+				formatter.PrintError("Semantic search failed", "EMBEDDING_ERROR", err.Error())
+				return nil
+			}
+		} else {
+			results, err = things.SimilarItems(db, *item)
+			if err != nil {
+				formatter.PrintError("Failed to query Things database", "DATABASE_ERROR", err.Error())
+				return nil
+			}
+		}
+		// similar's own --limit shadows the global one; --offset still applies
+		results = things.PageOf(things.Page{Limit: limit, Offset: pageOffset}, results)
+
+		formatter.PrintSuccess(map[string]interface{}{
+			"id":      item.ID,
+			"title":   item.Title,
+			"count":   len(results),
+			"similar": results,
+		})
+		return nil
+	},
+}
+
+func init() {
+	pageable(similarCmd)
+	similarCmd.Flags().String("id", "", "To-do or project ID (required)")
+	similarCmd.Flags().Int("limit", 5, "Maximum number of similar items")
+	similarCmd.Flags().Bool("semantic", false, "Compare by meaning using local embeddings")
+}
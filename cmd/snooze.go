@@ -0,0 +1,93 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/yourusername/things3-cli/pkg/formatter"
+	"github.com/yourusername/things3-cli/pkg/things"
+	"github.com/yourusername/things3-cli/pkg/util"
+)
+
+// snoozeCmd pushes items out of Today to a later date or Someday
+var snoozeCmd = &cobra.Command{
+	Use:   "snooze [ID...]",
+	Short: "Push to-dos or projects out of Today until a later date or Someday",
+	Long: `Move open items to Someday, or with --until to a later date. Items are given
+with repeated --id flags, as arguments, or matched with a search --filter (see
+things search). Completed and canceled items are skipped. All items are
+updated through a single JSON payload. Requires an auth token.
+
+Examples:
+  things snooze --id "THINGS-ID"
+  things snooze --until 2024-07-01 ID-1 ID-2
+  things snooze --until "next monday" --filter 'project:"Website"'`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ids := collectIDs(cmd, args)
+		filter, _ := cmd.Flags().GetString("filter")
+		if len(ids) == 0 && filter == "" {
+			formatter.PrintError("Provide item IDs (--id) or --filter", "INVALID_ARGUMENTS", "")
+			return nil
+		}
+
+		until, _ := cmd.Flags().GetString("until")
+		someday, _ := cmd.Flags().GetBool("someday")
+		if until != "" && someday {
+			formatter.PrintError("Use either --until or --someday, not both", "INVALID_ARGUMENTS", "")
+			return nil
+		}
+
+		when := "someday"
+		if until != "" {
+			now := util.Now()
+			date, ok := util.ParseDate(until, now)
+			if !ok {
+				formatter.PrintError("Invalid date: "+until, "INVALID_ARGUMENTS", "Use YYYY-MM-DD or a phrase like \"next monday\"")
+				return nil
+			}
+			if !date.After(now) {
+				formatter.PrintError("Snooze date must be after today: "+until, "INVALID_ARGUMENTS", "")
+				return nil
+			}
+			when = date.Format(util.DateLayout)
+		}
+
+		var items []things.Item
+		if len(ids) > 0 {
+			found, ok := lookupItems(ids)
+			if !ok {
+				return nil
+			}
+			items = append(items, found...)
+		}
+		if filter != "" {
+			matched, ok := bulkMatches(filter)
+			if !ok {
+				return nil
+			}
+			items = append(items, matched...)
+		}
+
+		seen := make(map[string]bool)
+		ops := []things.JSONOperation{}
+		for _, item := range items {
+			if item.Status != "open" || seen[item.ID] {
+				continue
+			}
+			seen[item.ID] = true
+			ops = append(ops, things.NewUpdateOperation(item.Type, item.ID, map[string]interface{}{"when": when}))
+		}
+		if len(ops) == 0 {
+			formatter.PrintError("No open items to snooze", "NOT_FOUND", "")
+			return nil
+		}
+
+		return runJSONOperations(cmd, ops)
+	},
+}
+
+func init() {
+	snoozeCmd.Flags().StringArray("id", []string{}, "Item ID (repeat flag)")
+	snoozeCmd.Flags().String("filter", "", "Search query selecting the items to snooze")
+	snoozeCmd.Flags().String("until", "", "Date to snooze until (YYYY-MM-DD or a phrase like \"next monday\")")
+	snoozeCmd.Flags().Bool("someday", false, "Move the items to Someday (the default)")
+	snoozeCmd.Flags().String("auth-token", "", "Things auth token (overrides config/ENV)")
+}
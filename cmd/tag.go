@@ -0,0 +1,147 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/yourusername/things3-cli/pkg/formatter"
+	"github.com/yourusername/things3-cli/pkg/things"
+	"github.com/yourusername/things3-cli/pkg/util"
+)
+
+// tagCmd adds and removes individual tags on an existing item
+var tagCmd = &cobra.Command{
+	Use:   "tag",
+	Short: "Add or remove tags on a to-do or project",
+	Long: `Add or remove individual tags without replacing the rest. The current tags
+are read from the local Things database and the corrected set is written back.
+Requires an auth token.
+
+Examples:
+  things tag --id "THINGS-ID" --add urgent
+  things tag --id "THINGS-ID" --add urgent --remove "someday-maybe"
+  things tag --id "THINGS-ID" --remove "errands,home"`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		id, _ := cmd.Flags().GetString("id")
+		if id == "" {
+			formatter.PrintError("Item ID (--id) is required", "INVALID_ARGUMENTS", "")
+			return nil
+		}
+
+		add := tagFlagValues(cmd, "add")
+		remove := tagFlagValues(cmd, "remove")
+		if len(add) == 0 && len(remove) == 0 {
+			formatter.PrintError("Provide --add or --remove", "INVALID_ARGUMENTS", "")
+			return nil
+		}
+
+		items, ok := lookupItems([]string{id})
+		if !ok {
+			return nil
+		}
+		item := items[0]
+
+		// The full tag set is always sent so that removing the last tag clears it.
+		params := map[string]string{"id": id, "tags": util.JoinTags(editTags(item.Tags, add, remove))}
+		addStringParam(cmd, params, "auth-token", "auth-token")
+
+		action := "update"
+		if item.Type == "project" {
+			action = "update-project"
+		}
+		return runAction(action, params, things.ExecuteOptions{RequiresAuth: true, UseAuthIfAvailable: true})
+	},
+}
+
+// tagDeleteCmd deletes a tag from the library
+var tagDeleteCmd = &cobra.Command{
+	Use:   "delete",
+	Short: "Delete a tag from every item that carries it",
+	Long: `Delete a tag from the library through AppleScript. Things removes it from
+every to-do and project that carries it, so this asks first unless --yes is
+given.
+
+Example:
+  things tag delete --name "someday-maybe" --yes`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name, _ := cmd.Flags().GetString("name")
+		name = strings.TrimSpace(name)
+		if name == "" {
+			formatter.PrintError("Tag name (--name) is required", "INVALID_ARGUMENTS", "")
+			return nil
+		}
+
+		db, err := things.OpenDB()
+		if err != nil {
+			formatter.PrintError("Failed to open Things database", "DATABASE_ERROR", err.Error())
+			return nil
+		}
+		usage, err := db.TagUsage()
+		if err != nil {
+			formatter.PrintError("Failed to query Things database", "DATABASE_ERROR", err.Error())
+			return nil
+		}
+		var tag *things.TagUsage
+		for i := range usage {
+			if strings.EqualFold(usage[i].Title, name) {
+				tag = &usage[i]
+				break
+			}
+		}
+		if tag == nil {
+			formatter.PrintError("Tag not found: "+name, "NOT_FOUND", "")
+			return nil
+		}
+
+		question := fmt.Sprintf("Delete tag %q and remove it from %d items?", tag.Title, tag.Items)
+		if !confirmDestructive(question, "Deleting a tag") {
+			return nil
+		}
+		if err := things.DeleteTag(tag.Title); err != nil {
+			if heldByDryRun(err) {
+				return nil
+			}
+			formatter.PrintError("Failed to delete tag", "THINGS_ERROR", err.Error())
+			return nil
+		}
+
+		formatter.PrintSuccess(map[string]interface{}{
+			"id":    tag.ID,
+			"title": tag.Title,
+			"items": tag.Items,
+		})
+		return nil
+	},
+}
+
+// editTags returns current with the add tags appended and the remove tags dropped
+func editTags(current, add, remove []string) []string {
+	var tags []string
+	for _, tag := range append(append([]string{}, current...), add...) {
+		if !util.TagsContain(remove, tag) {
+			tags = append(tags, tag)
+		}
+	}
+	return util.DedupeTags(tags)
+}
+
+// tagFlagValues reads a repeatable tag flag, also splitting comma-separated values
+func tagFlagValues(cmd *cobra.Command, flagName string) []string {
+	values, _ := cmd.Flags().GetStringArray(flagName)
+	var tags []string
+	for _, value := range values {
+		tags = append(tags, util.ParseTags(value)...)
+	}
+	return tags
+}
+
+func init() {
+	tagCmd.Flags().String("id", "", "To-do or project ID (required)")
+	tagCmd.Flags().StringArray("add", []string{}, "Tag to add (repeat flag or comma-separate)")
+	tagCmd.Flags().StringArray("remove", []string{}, "Tag to remove (repeat flag or comma-separate)")
+	tagCmd.Flags().String("auth-token", "", "Things auth token (overrides config/ENV)")
+
+	tagDeleteCmd.Flags().String("name", "", "Tag to delete (required)")
+	tagCmd.AddCommand(tagDeleteCmd)
+}
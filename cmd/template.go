@@ -0,0 +1,446 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/yourusername/things3-cli/pkg/formatter"
+	"github.com/yourusername/things3-cli/pkg/things"
+	"github.com/yourusername/things3-cli/pkg/util"
+)
+
+// templateCmd groups the project template subcommands
+var templateCmd = &cobra.Command{
+	Use:   "template",
+	Short: "Save projects as templates and create projects from them",
+	Long: `Templates are project layouts (headings, to-dos, checklists, notes, and tags)
+stored as JSON files in ~/.config/things3-cli/templates. Any text may contain
+{{name}} placeholders that are filled in with --var when the template is applied.`,
+}
+
+// templateSaveCmd captures an existing project as a template
+var templateSaveCmd = &cobra.Command{
+	Use:   "save",
+	Short: "Save an existing project as a template",
+	Long: `Read a project from the local Things database and save its headings, to-dos,
+and checklists as a template. Canceled to-dos are left out. Edit the saved file
+to add {{name}} placeholders.
+
+Example:
+  things template save --from-project-id "PROJECT-ID" --name onboarding`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		projectID, _ := cmd.Flags().GetString("from-project-id")
+		name, _ := cmd.Flags().GetString("name")
+		if projectID == "" || name == "" {
+			formatter.PrintError("Both --from-project-id and --name are required", "INVALID_ARGUMENTS", "")
+			return nil
+		}
+
+		db, err := things.OpenDB()
+		if err != nil {
+			formatter.PrintError("Failed to open Things database", "DATABASE_ERROR", err.Error())
+			return nil
+		}
+
+		template, err := things.TemplateFromProject(db, projectID, name)
+		if errors.Is(err, things.ErrNotFound) {
+			formatter.PrintError("Project not found: "+projectID, "NOT_FOUND", "")
+			return nil
+		}
+		if err != nil {
+			formatter.PrintError("Failed to read project", "DATABASE_ERROR", err.Error())
+			return nil
+		}
+
+		if err := things.SaveTemplate(template); err != nil {
+			formatter.PrintError("Failed to save template", "FILE_ERROR", err.Error())
+			return nil
+		}
+
+		formatter.PrintSuccess(map[string]interface{}{
+			"name":      template.Name,
+			"title":     template.Title,
+			"items":     len(template.Items),
+			"variables": template.Variables(),
+		})
+		return nil
+	},
+}
+
+// templateApplyCmd creates a project from a template
+var templateApplyCmd = &cobra.Command{
+	Use:   "apply NAME",
+	Short: "Create a project from a template",
+	Long: `Create a new project from a saved template through the json batch action,
+replacing {{name}} placeholders with the values given by --var.
+
+Examples:
+  things template apply onboarding --title "Onboard Alice" --var name=Alice
+  things template apply release --var version=2.1 --area Work --when "next monday"`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		template, err := things.LoadTemplate(args[0])
+		if errors.Is(err, things.ErrNotFound) {
+			formatter.PrintError("Template not found: "+args[0], "NOT_FOUND", "")
+			return nil
+		}
+		if err != nil {
+			formatter.PrintError("Failed to load template", "FILE_ERROR", err.Error())
+			return nil
+		}
+
+		vars, err := templateVars(cmd)
+		if err != nil {
+			formatter.PrintError(err.Error(), "INVALID_ARGUMENTS", "")
+			return nil
+		}
+
+		area, _ := cmd.Flags().GetString("area")
+		extra, ok := templateExtras(area)
+		if !ok {
+			return nil
+		}
+		if when, _ := cmd.Flags().GetString("when"); when != "" {
+			extra["when"] = when
+		}
+		if deadline, _ := cmd.Flags().GetString("deadline"); deadline != "" {
+			extra["deadline"] = deadline
+		}
+
+		title, _ := cmd.Flags().GetString("title")
+		params, ok := templatePayload(template, title, vars, extra)
+		if !ok {
+			return nil
+		}
+		addBoolParam(cmd, params, "reveal", "reveal")
+		return runAction("json", params, things.ExecuteOptions{UseAuthIfAvailable: true})
+	},
+}
+
+// templateScheduleCmd makes a template recur
+var templateScheduleCmd = &cobra.Command{
+	Use:   "schedule NAME",
+	Short: "Create a project from a template on a recurring schedule",
+	Long: `Record a schedule for a template in the config. Projects are created by
+template run-due, so run it daily (for example from cron or launchd). Besides
+the --var values, {{date}} is filled in with the date the run was due.
+
+Recurrences: daily, weekdays, monthly (on the 1st), or a weekday name.
+
+Examples:
+  things template schedule weekly-review --every friday --title "Review {{date}}"
+  things template schedule invoicing --every monthly --area Work`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name := args[0]
+		if _, err := things.LoadTemplate(name); err != nil {
+			if errors.Is(err, things.ErrNotFound) {
+				formatter.PrintError("Template not found: "+name, "NOT_FOUND", "")
+			} else {
+				formatter.PrintError("Failed to load template", "FILE_ERROR", err.Error())
+			}
+			return nil
+		}
+
+		every, _ := cmd.Flags().GetString("every")
+		if _, err := util.LastOccurrence(every, util.Now()); err != nil {
+			formatter.PrintError(err.Error(), "INVALID_ARGUMENTS", "")
+			return nil
+		}
+
+		vars, err := templateVars(cmd)
+		if err != nil {
+			formatter.PrintError(err.Error(), "INVALID_ARGUMENTS", "")
+			return nil
+		}
+
+		config, err := util.LoadConfig()
+		if err != nil {
+			formatter.PrintError("Failed to load config", "CONFIG_ERROR", err.Error())
+			return nil
+		}
+		if config.TemplateSchedules == nil {
+			config.TemplateSchedules = make(map[string]util.TemplateSchedule)
+		}
+
+		schedule := util.TemplateSchedule{
+			Every: strings.ToLower(every),
+			Vars:  vars,
+			Since: util.Now().Format(util.DateLayout),
+		}
+		schedule.Title, _ = cmd.Flags().GetString("title")
+		schedule.Area, _ = cmd.Flags().GetString("area")
+		config.TemplateSchedules[name] = schedule
+
+		if err := util.SaveConfig(config); err != nil {
+			formatter.PrintError("Failed to save config", "CONFIG_ERROR", err.Error())
+			return nil
+		}
+
+		formatter.PrintSuccess(map[string]interface{}{
+			"name":     name,
+			"schedule": schedule,
+		})
+		return nil
+	},
+}
+
+// templateUnscheduleCmd removes a template schedule
+var templateUnscheduleCmd = &cobra.Command{
+	Use:   "unschedule NAME",
+	Short: "Stop creating projects from a template on a schedule",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		config, err := util.LoadConfig()
+		if err != nil {
+			formatter.PrintError("Failed to load config", "CONFIG_ERROR", err.Error())
+			return nil
+		}
+		if _, ok := config.TemplateSchedules[args[0]]; !ok {
+			formatter.PrintError("Template is not scheduled: "+args[0], "NOT_FOUND", "")
+			return nil
+		}
+
+		delete(config.TemplateSchedules, args[0])
+		if err := util.SaveConfig(config); err != nil {
+			formatter.PrintError("Failed to save config", "CONFIG_ERROR", err.Error())
+			return nil
+		}
+
+		formatter.PrintSuccess(map[string]interface{}{"name": args[0], "unscheduled": true})
+		return nil
+	},
+}
+
+// templateRunDueCmd creates projects for every schedule that has come due
+var templateRunDueCmd = &cobra.Command{
+	Use:   "run-due",
+	Short: "Create projects for templates whose schedule has come due",
+	Long: `Create a project for each scheduled template whose most recent occurrence
+has not been run yet. Missed occurrences are not made up: a schedule that was
+due several times since the last run creates one project. Use --dry-run to see
+what is due, with the payload each project would be sent as, without creating
+anything.
+
+Example:
+  things template run-due`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		config, err := util.LoadConfig()
+		if err != nil {
+			formatter.PrintError("Failed to load config", "CONFIG_ERROR", err.Error())
+			return nil
+		}
+
+		names := make([]string, 0, len(config.TemplateSchedules))
+		for name := range config.TemplateSchedules {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		today := util.Now()
+		results := []map[string]interface{}{}
+		for _, name := range names {
+			schedule := config.TemplateSchedules[name]
+			occurrence, err := util.LastOccurrence(schedule.Every, today)
+			if err != nil {
+				results = append(results, map[string]interface{}{"name": name, "error": err.Error()})
+				continue
+			}
+			date := occurrence.Format(util.DateLayout)
+			if date < schedule.Since || date <= schedule.LastRun {
+				continue
+			}
+
+			entry := map[string]interface{}{"name": name, "due": date}
+			result, err := runScheduledTemplate(name, schedule, date)
+			var run *things.DryRunError
+			if errors.As(err, &run) {
+				entry["payload"] = run.Payload()
+				results = append(results, entry)
+				continue
+			}
+			if err != nil {
+				entry["error"] = err.Error()
+				results = append(results, entry)
+				continue
+			}
+			entry["result"] = result
+
+			// Saved after each project so a later failure doesn't repeat earlier ones
+			schedule.LastRun = date
+			config.TemplateSchedules[name] = schedule
+			if err := util.SaveConfig(config); err != nil {
+				formatter.PrintError("Project created but schedule could not be saved", "CONFIG_ERROR", err.Error())
+				return nil
+			}
+			results = append(results, entry)
+		}
+
+		formatter.PrintSuccess(map[string]interface{}{
+			"date":    today.Format(util.DateLayout),
+			"dry_run": dryRunMode,
+			"count":   len(results),
+			"due":     results,
+		})
+		return nil
+	},
+}
+
+// runScheduledTemplate creates the project for one due schedule
+func runScheduledTemplate(name string, schedule util.TemplateSchedule, date string) (things.ActionResult, error) {
+	template, err := things.LoadTemplate(name)
+	if err != nil {
+		return things.ActionResult{}, err
+	}
+
+	vars := map[string]string{"date": date}
+	for key, value := range schedule.Vars {
+		vars[key] = value
+	}
+
+	extra := make(map[string]interface{})
+	if schedule.Area != "" {
+		db, err := things.OpenDB()
+		if err != nil {
+			return things.ActionResult{}, err
+		}
+		area, err := db.FindArea(schedule.Area)
+		if err != nil {
+			return things.ActionResult{}, fmt.Errorf("area %s: %w", schedule.Area, err)
+		}
+		extra["area-id"] = area.ID
+	}
+
+	ops, err := template.Build(schedule.Title, vars, extra)
+	if err != nil {
+		return things.ActionResult{}, err
+	}
+	data, err := things.EncodeJSONPayload(ops)
+	if err != nil {
+		return things.ActionResult{}, err
+	}
+
+	client, err := things.NewClient()
+	if err != nil {
+		return things.ActionResult{}, err
+	}
+	client.Confirm = confirmMassMutation
+	callback, err := client.Execute("json", map[string]string{"data": data}, things.ExecuteOptions{UseAuthIfAvailable: true})
+	if err != nil {
+		return things.ActionResult{}, err
+	}
+	return things.NormalizeResponse("json", callback), nil
+}
+
+// templateExtras returns the project attributes for an optional area.
+// Errors are printed and reported through the boolean result.
+func templateExtras(area string) (map[string]interface{}, bool) {
+	extra := make(map[string]interface{})
+	if area != "" {
+		areaID, ok := resolveAreaID(area)
+		if !ok {
+			return nil, false
+		}
+		extra["area-id"] = areaID
+	}
+	return extra, true
+}
+
+// templatePayload builds the json action parameters creating a project from a template.
+// Errors are printed and reported through the boolean result.
+func templatePayload(template *things.Template, title string, vars map[string]string, extra map[string]interface{}) (map[string]string, bool) {
+	ops, err := template.Build(title, vars, extra)
+	if err != nil {
+		formatter.PrintError(err.Error(), "INVALID_ARGUMENTS", "")
+		return nil, false
+	}
+
+	data, err := things.EncodeJSONPayload(ops)
+	if err != nil {
+		formatter.PrintError("Failed to build JSON payload", "INVALID_ARGUMENTS", err.Error())
+		return nil, false
+	}
+	return map[string]string{"data": data}, true
+}
+
+// templateListCmd lists the saved templates
+var templateListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List saved templates",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		templates, err := things.ListTemplates()
+		if err != nil {
+			formatter.PrintError("Failed to read templates", "FILE_ERROR", err.Error())
+			return nil
+		}
+
+		summaries := make([]map[string]interface{}, 0, len(templates))
+		for i := range templates {
+			summaries = append(summaries, map[string]interface{}{
+				"name":      templates[i].Name,
+				"title":     templates[i].Title,
+				"items":     len(templates[i].Items),
+				"variables": templates[i].Variables(),
+			})
+		}
+		summaries = things.PageOf(listPage(), summaries)
+		formatter.PrintSuccess(map[string]interface{}{
+			"count":     len(summaries),
+			"templates": summaries,
+		})
+		return nil
+	},
+}
+
+// templateVars parses the repeated --var name=value flags
+func templateVars(cmd *cobra.Command) (map[string]string, error) {
+	values, _ := cmd.Flags().GetStringArray("var")
+	vars := make(map[string]string, len(values))
+	for _, value := range values {
+		name, val, ok := strings.Cut(value, "=")
+		name = strings.TrimSpace(name)
+		if !ok || name == "" {
+			return nil, fmt.Errorf("invalid --var %q (expected name=value)", value)
+		}
+		vars[name] = val
+	}
+	return vars, nil
+}
+
+// resolveAreaID looks up an area by name or ID in the database.
+// Errors are printed and reported through the boolean result.
+func resolveAreaID(ref string) (string, bool) {
+	area, ok := lookupArea(ref)
+	if !ok {
+		return "", false
+	}
+	return area.ID, true
+}
+
+func init() {
+	pageable(templateListCmd)
+	templateSaveCmd.Flags().String("from-project-id", "", "ID of the project to save (required)")
+	templateSaveCmd.Flags().String("name", "", "Template name (required)")
+
+	templateApplyCmd.Flags().String("title", "", "Project title (defaults to the template's title)")
+	templateApplyCmd.Flags().StringArray("var", []string{}, "Template variable as name=value (repeat flag)")
+	templateApplyCmd.Flags().String("area", "", "Area name or ID for the new project")
+	templateApplyCmd.Flags().String("when", "", "When to schedule the project (today, tomorrow, someday, date, or phrase)")
+	templateApplyCmd.Flags().String("deadline", "", "Deadline for the project")
+	templateApplyCmd.Flags().Bool("reveal", false, "Reveal the created project in Things")
+
+	templateCmd.AddCommand(templateSaveCmd)
+	templateCmd.AddCommand(templateApplyCmd)
+	templateScheduleCmd.Flags().String("every", "", "Recurrence: daily, weekdays, monthly, or a weekday name (required)")
+	templateScheduleCmd.Flags().String("title", "", "Project title (defaults to the template's title)")
+	templateScheduleCmd.Flags().StringArray("var", []string{}, "Template variable as name=value (repeat flag)")
+	templateScheduleCmd.Flags().String("area", "", "Area name or ID for the new projects")
+
+	templateCmd.AddCommand(templateListCmd)
+	templateCmd.AddCommand(templateScheduleCmd)
+	templateCmd.AddCommand(templateUnscheduleCmd)
+	templateCmd.AddCommand(templateRunDueCmd)
+}
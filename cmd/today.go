@@ -0,0 +1,99 @@
+package cmd
+
+import (
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/yourusername/things3-cli/pkg/formatter"
+	"github.com/yourusername/things3-cli/pkg/things"
+	"github.com/yourusername/things3-cli/pkg/util"
+)
+
+// todayCmd lists the Today list from the local database, split into day and evening
+var todayCmd = &cobra.Command{
+	Use:   "today",
+	Short: "List today's to-dos, with This Evening as its own section",
+	Long: `List the open to-dos and projects in Today, read from the local Things database.
+Items in the This Evening section are returned separately under "evening", and
+the working set maintained with things pin is listed under "pinned". With
+--evening only This Evening is listed.
+
+Examples:
+  things today
+  things today --evening
+  things today --count`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		db, err := things.OpenDB()
+		if err != nil {
+			formatter.PrintError("Failed to open Things database", "DATABASE_ERROR", err.Error())
+			return nil
+		}
+
+		now := util.Now()
+		today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+		eveningOnly, _ := cmd.Flags().GetBool("evening")
+		if countOnly, _ := cmd.Flags().GetBool("count"); countOnly {
+			count, err := db.TodayCount(today)
+			if eveningOnly {
+				count, err = db.EveningCount(today)
+			}
+			if err != nil {
+				formatter.PrintError("Failed to query Things database", "DATABASE_ERROR", err.Error())
+				return nil
+			}
+			formatter.PrintCount(count)
+			return nil
+		}
+
+		if eveningOnly {
+			evening, err := db.Paged(listPage()).Evening(today)
+			if err != nil {
+				formatter.PrintError("Failed to query Things database", "DATABASE_ERROR", err.Error())
+				return nil
+			}
+			formatter.PrintSuccess(map[string]interface{}{
+				"date":    today.Format(util.DateLayout),
+				"count":   len(evening),
+				"evening": evening,
+			})
+			return nil
+		}
+
+		items, err := db.Paged(listPage()).Today(today)
+		if err != nil {
+			formatter.PrintError("Failed to query Things database", "DATABASE_ERROR", err.Error())
+			return nil
+		}
+
+		pinned, err := db.PinnedItems()
+		if err != nil {
+			formatter.PrintError("Failed to read working set", "DATABASE_ERROR", err.Error())
+			return nil
+		}
+
+		day := []things.Item{}
+		evening := []things.Item{}
+		for _, item := range items {
+			if item.When == "evening" {
+				evening = append(evening, item)
+			} else {
+				day = append(day, item)
+			}
+		}
+
+		formatter.PrintSuccess(map[string]interface{}{
+			"date":    today.Format(util.DateLayout),
+			"count":   len(items),
+			"pinned":  pinned,
+			"today":   day,
+			"evening": evening,
+		})
+		return nil
+	},
+}
+
+func init() {
+	pageable(todayCmd)
+	todayCmd.Flags().Bool("evening", false, "List only the items in This Evening")
+	todayCmd.Flags().Bool("count", false, "Print only the number of items in Today, evening included (only This Evening with --evening)")
+}
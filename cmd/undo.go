@@ -0,0 +1,57 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/yourusername/things3-cli/pkg/formatter"
+	"github.com/yourusername/things3-cli/pkg/things"
+)
+
+// undoCmd moves the items created by the last creating action to the Trash
+var undoCmd = &cobra.Command{
+	Use:   "undo",
+	Short: "Trash the items created by the last add or json action",
+	Long: `Move the to-dos and projects created by the most recent add, add-project, or
+create-only json action to the Trash. Updates to existing items are not
+recorded and cannot be undone. Items can be restored from the Trash in Things.
+This asks first unless --yes is given.
+
+Example:
+  things undo --yes`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		record, err := things.LastCreated()
+		if err != nil {
+			formatter.PrintError("Failed to read undo history", "STATE_ERROR", err.Error())
+			return nil
+		}
+		if record == nil || len(record.IDs) == 0 {
+			formatter.PrintError("Nothing to undo", "NOTHING_TO_UNDO", "")
+			return nil
+		}
+
+		question := fmt.Sprintf("Move %d items created by the last %s to the Trash?", len(record.IDs), record.Action)
+		if !confirmDestructive(question, "Undoing") {
+			return nil
+		}
+		if err := things.TrashItems(record.IDs); err != nil {
+			if heldByDryRun(err) {
+				return nil
+			}
+			formatter.PrintError("Failed to move items to the Trash", "THINGS_ERROR", err.Error())
+			return nil
+		}
+
+		if err := things.ClearLastCreated(); err != nil {
+			formatter.PrintError("Items trashed but undo history could not be cleared", "STATE_ERROR", err.Error())
+			return nil
+		}
+
+		formatter.PrintSuccess(map[string]interface{}{
+			"undone_action": record.Action,
+			"trashed_ids":   record.IDs,
+			"created_at":    record.CreatedAt,
+		})
+		return nil
+	},
+}
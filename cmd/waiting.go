@@ -0,0 +1,109 @@
+package cmd
+
+import (
+	"errors"
+
+	"github.com/spf13/cobra"
+	"github.com/yourusername/things3-cli/pkg/formatter"
+	"github.com/yourusername/things3-cli/pkg/things"
+	"github.com/yourusername/things3-cli/pkg/util"
+)
+
+// waitingCmd lists the items delegated to other people
+var waitingCmd = &cobra.Command{
+	Use:   "waiting",
+	Short: "List to-dos waiting on other people",
+	Long: `List the open to-dos and projects tagged waiting, as set by --delegate on add
+and update, read from the local Things database. --by narrows the list to one
+person, matched by their tag (their first name, as in "alice").
+
+Examples:
+  things waiting
+  things waiting --by alice`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		by, _ := cmd.Flags().GetString("by")
+
+		db, err := things.OpenDB()
+		if err != nil {
+			formatter.PrintError("Failed to open Things database", "DATABASE_ERROR", err.Error())
+			return nil
+		}
+		open, err := db.OpenItems()
+		if err != nil {
+			formatter.PrintError("Failed to query Things database", "DATABASE_ERROR", err.Error())
+			return nil
+		}
+
+		items := []things.Item{}
+		for _, item := range open {
+			if !util.TagsContain(item.Tags, things.WaitingTag) {
+				continue
+			}
+			if by != "" && !util.TagsContain(item.Tags, things.PersonTag(by)) {
+				continue
+			}
+			items = append(items, item)
+		}
+		items = things.PageOf(listPage(), items)
+
+		response := map[string]interface{}{
+			"count": len(items),
+			"items": items,
+		}
+		if by != "" {
+			response["by"] = things.PersonTag(by)
+		}
+		formatter.PrintSuccess(response)
+		return nil
+	},
+}
+
+// addDelegation handles --delegate on add and update: it looks the person up
+// in Contacts, tags the item waiting and with the person's tag, creating
+// either tag if needed, and records a mailto: link in the notes. Errors are printed and reported through the
+// boolean result.
+func addDelegation(cmd *cobra.Command, params map[string]string, update bool) bool {
+	name, _ := cmd.Flags().GetString("delegate")
+	if name == "" {
+		return true
+	}
+
+	contact, err := things.LookupContact(name)
+	if errors.Is(err, things.ErrNotFound) {
+		formatter.PrintError("No contact found matching: "+name, "NOT_FOUND", "")
+		return false
+	}
+	if err != nil {
+		formatter.PrintError("Failed to look up contact", "THINGS_ERROR", err.Error())
+		return false
+	}
+
+	tags := []string{things.WaitingTag, contact.Tag()}
+	// Things drops tags that don't exist yet, which would leave the item
+	// out of things waiting and --by
+	if _, err := things.EnsureTags(tags); err != nil {
+		formatter.PrintError("Failed to create delegation tags", "THINGS_ERROR", err.Error())
+		return false
+	}
+	tagsParam := "tags"
+	if update && params["tags"] == "" {
+		tagsParam = "add-tags"
+	}
+	params[tagsParam] = util.JoinTags(util.DedupeTags(append(util.ParseTags(params[tagsParam]), tags...)))
+
+	if !update {
+		if params["notes"] != "" {
+			params["notes"] += "\n\n" + contact.DelegationNote()
+		} else {
+			params["notes"] = contact.DelegationNote()
+		}
+		return true
+	}
+	things.AppendNotesLine(params, contact.DelegationNote())
+	return true
+}
+
+func init() {
+	pageable(waitingCmd)
+	waitingCmd.Flags().String("by", "", "Only items delegated to this person (tag or first name)")
+}
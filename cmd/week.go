@@ -0,0 +1,94 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/yourusername/things3-cli/pkg/formatter"
+	"github.com/yourusername/things3-cli/pkg/things"
+	"github.com/yourusername/things3-cli/pkg/util"
+)
+
+// weekDay is one day of the week view
+type weekDay struct {
+	Date      string        `json:"date"`
+	Weekday   string        `json:"weekday"`
+	Scheduled []things.Item `json:"scheduled"`
+	Due       []things.Item `json:"due"`
+}
+
+// weekCmd lists what is scheduled or due in a week, day by day
+var weekCmd = &cobra.Command{
+	Use:   "week",
+	Short: "List the to-dos scheduled or due in a week, day by day",
+	Long: `List the open to-dos and projects that start or are due in a week, read from
+the local Things database. The week begins on first_day_of_week from the config
+(Monday unless set to sunday) and is labeled with its ISO 8601 week number.
+
+--week takes any date within the week, a phrase such as "next week", or an ISO
+week such as 2024-W23.
+
+Examples:
+  things week
+  things week --week "next week"
+  things week --week 2024-W23`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ref, _ := cmd.Flags().GetString("week")
+		date, ok := util.ParseDate(ref, util.Now())
+		if !ok {
+			formatter.PrintError("Invalid week: "+ref, "INVALID_ARGUMENTS", "Use a date, a phrase like \"next week\", or an ISO week like 2024-W23")
+			return nil
+		}
+
+		firstDay := util.WeekStart()
+		start := util.StartOfWeek(date, firstDay)
+		end := start.AddDate(0, 0, 6)
+
+		db, err := things.OpenDB()
+		if err != nil {
+			formatter.PrintError("Failed to open Things database", "DATABASE_ERROR", err.Error())
+			return nil
+		}
+		items, err := db.Paged(listPage()).ScheduledOrDueBetween(start, end)
+		if err != nil {
+			formatter.PrintError("Failed to query Things database", "DATABASE_ERROR", err.Error())
+			return nil
+		}
+
+		days := make([]weekDay, 7)
+		index := make(map[string]int, 7)
+		for i := range days {
+			day := start.AddDate(0, 0, i)
+			days[i] = weekDay{
+				Date:      day.Format(util.DateLayout),
+				Weekday:   day.Weekday().String(),
+				Scheduled: []things.Item{},
+				Due:       []things.Item{},
+			}
+			index[days[i].Date] = i
+		}
+		for _, item := range items {
+			if i, ok := index[item.StartDate]; ok {
+				days[i].Scheduled = append(days[i].Scheduled, item)
+			}
+			if i, ok := index[item.Deadline]; ok {
+				days[i].Due = append(days[i].Due, item)
+			}
+		}
+
+		// An ISO week runs Monday to Sunday, so a Sunday-first week is
+		// labeled by the ISO week holding most of its days.
+		formatter.PrintSuccess(map[string]interface{}{
+			"week":       util.ISOWeekLabel(start.AddDate(0, 0, 3)),
+			"first_day":  firstDay.String(),
+			"start_date": start.Format(util.DateLayout),
+			"end_date":   end.Format(util.DateLayout),
+			"count":      len(items),
+			"days":       days,
+		})
+		return nil
+	},
+}
+
+func init() {
+	pageable(weekCmd)
+	weekCmd.Flags().String("week", "today", "Any date in the week, a phrase like \"next week\", or an ISO week like 2024-W23")
+}
@@ -6,6 +6,8 @@ import (
 
 	"github.com/spf13/cobra"
 	"github.com/yourusername/things3-cli/cmd"
+	"github.com/yourusername/things3-cli/pkg/formatter"
+	"github.com/yourusername/things3-cli/pkg/things"
 )
 
 // rootCmd is the main command that all subcommands attach to
@@ -34,11 +36,26 @@ func init() {
 		rootCmd.AddCommand(c)
 	}
 	rootCmd.AddCommand(helpCmd)
+	cmd.RegisterGlobalFlags(rootCmd)
 }
 
 func main() {
+	// kill -USR1 <pid> reports what a seemingly hung command is waiting on
+	things.NotifyStatusSignal(os.Stderr)
+
+	// Commands print their own errors; cobra only returns usage errors such
+	// as unknown flags or conflicting output options
+	code := formatter.ExitInvalidArgs
 	if err := rootCmd.Execute(); err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-		os.Exit(1)
+	} else {
+		code = formatter.ExitCode()
+	}
+
+	// --output replaces its file only when the command succeeded
+	if err := cmd.FinishOutput(code == formatter.ExitSuccess); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		code = formatter.ExitFailure
 	}
+	os.Exit(code)
 }
@@ -0,0 +1,57 @@
+package formatter
+
+import (
+	"os"
+	"sync"
+
+	"github.com/yourusername/things3-cli/pkg/util"
+	"golang.org/x/term"
+)
+
+// ANSI escape sequences used by the plain and table formats
+const (
+	ansiReset = "\x1b[0m"
+	ansiRed   = "\x1b[31m"
+	ansiBlue  = "\x1b[34m"
+	ansiDim   = "\x1b[2m"
+)
+
+var (
+	colorOnce sync.Once
+	colorOn   bool
+)
+
+// colorEnabled reports whether output may be colored: stdout is a terminal,
+// TERM isn't dumb, and NO_COLOR is unset (see https://no-color.org).
+func colorEnabled() bool {
+	colorOnce.Do(func() {
+		if _, set := os.LookupEnv("NO_COLOR"); set || os.Getenv("TERM") == "dumb" {
+			return
+		}
+		colorOn = term.IsTerminal(int(os.Stdout.Fd()))
+	})
+	return colorOn
+}
+
+// colorize wraps s in an ANSI color when color is enabled.
+func colorize(color, s string) string {
+	if color == "" || s == "" || !colorEnabled() {
+		return s
+	}
+	return color + s + ansiReset
+}
+
+// itemColor returns the color of an open item: red when its deadline has
+// passed and blue when it is scheduled for today or this evening.
+func itemColor(status, deadline, when string) string {
+	if status != "open" {
+		return ""
+	}
+	if deadline != "" && deadline < util.Now().Format(util.DateLayout) {
+		return ansiRed
+	}
+	if when == "today" || when == "evening" {
+		return ansiBlue
+	}
+	return ""
+}
@@ -0,0 +1,59 @@
+package formatter
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/yourusername/things3-cli/pkg/things"
+)
+
+// listColumn names the list an item was found in; it isn't an item field
+const listColumn = "list"
+
+// ItemColumns returns the column names accepted by --columns: the JSON
+// fields of things.Item followed by list.
+func ItemColumns() []string {
+	t := reflect.TypeOf(things.Item{})
+	columns := make([]string, 0, t.NumField()+1)
+	for i := 0; i < t.NumField(); i++ {
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+		if name != "" && name != "-" {
+			columns = append(columns, name)
+		}
+	}
+	return append(columns, listColumn)
+}
+
+// SetColumns chooses the columns of the table and csv formats, in order.
+// Names are checked against ItemColumns and may not repeat.
+func SetColumns(names []string) error {
+	known := make(map[string]bool)
+	for _, column := range ItemColumns() {
+		known[column] = true
+	}
+
+	seen := make(map[string]bool)
+	var columns []string
+	for _, name := range names {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+		if !known[name] {
+			return fmt.Errorf("unknown column %q (use %s)", name, strings.Join(ItemColumns(), ", "))
+		}
+		if seen[name] {
+			return fmt.Errorf("column %q is listed twice", name)
+		}
+		seen[name] = true
+		columns = append(columns, name)
+	}
+	if len(columns) == 0 {
+		return fmt.Errorf("no columns given")
+	}
+
+	tableColumns = columns
+	csvColumns = columns
+	return nil
+}
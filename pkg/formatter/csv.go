@@ -0,0 +1,134 @@
+package formatter
+
+import (
+	"encoding/csv"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// csvColumns are the item fields written by the csv format, in order. The
+// set doesn't depend on the command, so spreadsheets and scripts can rely on
+// it; list names where an item was found, such as today or evening.
+// --columns replaces them.
+var csvColumns = []string{
+	listColumn, "id", "type", "title", "status", "when", "start_date", "deadline",
+	"tags", "project", "area", "heading", "created_at", "modified_at", "completed_at", "notes",
+}
+
+// FormatCSV renders the to-dos, projects, and headings in a value as CSV
+// with a header row. Values without items, such as the result of an update,
+// are written as field,value rows instead, with nested fields joined by dots.
+func FormatCSV(v interface{}) string {
+	generic := genericValue(v)
+
+	var items []listedItem
+	collectItems(&items, "", generic)
+
+	var rows [][]string
+	if len(items) > 0 || hasItemList(generic) {
+		rows = append(rows, csvColumns)
+		for _, item := range items {
+			rows = append(rows, csvRow(item.list, item.fields))
+		}
+	} else {
+		rows = [][]string{{"field", "value"}}
+		collectCSVFields(&rows, "", generic)
+	}
+
+	var b strings.Builder
+	w := csv.NewWriter(&b)
+	w.WriteAll(rows)
+	return strings.TrimRight(b.String(), "\n")
+}
+
+// listedItem is an item found in a response together with the key of the
+// list holding it.
+type listedItem struct {
+	list   string
+	fields map[string]interface{}
+}
+
+// collectItems returns every item in v in key order, naming each after the
+// key of the list holding it.
+func collectItems(items *[]listedItem, list string, v interface{}) {
+	switch value := v.(type) {
+	case map[string]interface{}:
+		if isItem(value) {
+			*items = append(*items, listedItem{list: list, fields: value})
+			return
+		}
+		keys := make([]string, 0, len(value))
+		for key := range value {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			collectItems(items, key, value[key])
+		}
+	case []interface{}:
+		for _, entry := range value {
+			collectItems(items, list, entry)
+		}
+	}
+}
+
+// hasItemList reports whether v holds a list that is empty or made up of
+// items, so an empty result still gets the item header.
+func hasItemList(v interface{}) bool {
+	switch value := v.(type) {
+	case map[string]interface{}:
+		for _, field := range value {
+			if hasItemList(field) {
+				return true
+			}
+		}
+	case []interface{}:
+		_, ok := itemMaps(value)
+		return ok || len(value) == 0
+	}
+	return false
+}
+
+// csvRow returns the csvColumns of one item.
+func csvRow(list string, item map[string]interface{}) []string {
+	row := make([]string, len(csvColumns))
+	for i, column := range csvColumns {
+		if column == listColumn {
+			row[i] = list
+			continue
+		}
+		row[i] = fieldText(item, column)
+	}
+	return row
+}
+
+// collectCSVFields appends a field,value row for every scalar in v.
+func collectCSVFields(rows *[][]string, prefix string, v interface{}) {
+	join := func(key string) string {
+		if prefix == "" {
+			return key
+		}
+		return prefix + "." + key
+	}
+
+	switch value := v.(type) {
+	case map[string]interface{}:
+		keys := make([]string, 0, len(value))
+		for key := range value {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			collectCSVFields(rows, join(key), value[key])
+		}
+	case []interface{}:
+		for i, entry := range value {
+			collectCSVFields(rows, join(strconv.Itoa(i)), entry)
+		}
+	case nil:
+		*rows = append(*rows, []string{prefix, ""})
+	default:
+		*rows = append(*rows, []string{prefix, plainScalar(value)})
+	}
+}
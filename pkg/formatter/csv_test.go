@@ -0,0 +1,84 @@
+package formatter
+
+import (
+	"encoding/csv"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestFormatCSVQuoting(t *testing.T) {
+	tests := []struct {
+		name  string
+		field string
+		value string
+		want  string
+	}{
+		{name: "plain", field: "title", value: "Buy milk", want: "Buy milk"},
+		{name: "comma", field: "title", value: "Milk, eggs", want: `"Milk, eggs"`},
+		{name: "quotes", field: "title", value: `Read "Dune"`, want: `"Read ""Dune"""`},
+		{name: "embedded newline", field: "notes", value: "line one\nline two", want: "\"line one\nline two\""},
+		{name: "leading space", field: "title", value: " Indented", want: `" Indented"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			item := map[string]interface{}{"id": "T1", "type": "to-do", "title": "Task"}
+			item[tt.field] = tt.value
+			out := FormatCSV(map[string]interface{}{"today": []interface{}{item}})
+
+			if !strings.Contains(out, ","+tt.want) {
+				t.Errorf("FormatCSV wrote %q, want the %s field as %s", out, tt.field, tt.want)
+			}
+
+			records, err := csv.NewReader(strings.NewReader(out)).ReadAll()
+			if err != nil {
+				t.Fatalf("output does not parse as CSV: %v", err)
+			}
+			if len(records) != 2 {
+				t.Fatalf("got %d records, want the header and one row: %q", len(records), records)
+			}
+			column := indexOf(records[0], tt.field)
+			if got := records[1][column]; got != tt.value {
+				t.Errorf("%s read back as %q, want %q", tt.field, got, tt.value)
+			}
+		})
+	}
+}
+
+func TestFormatCSVWithoutItems(t *testing.T) {
+	out := FormatCSV(map[string]interface{}{
+		"id":     "T1",
+		"result": map[string]interface{}{"notes": "a\nb", "tags": []interface{}{"x", "y"}},
+	})
+	records, err := csv.NewReader(strings.NewReader(out)).ReadAll()
+	if err != nil {
+		t.Fatalf("output does not parse as CSV: %v", err)
+	}
+	want := [][]string{
+		{"field", "value"},
+		{"id", "T1"},
+		{"result.notes", "a\nb"},
+		{"result.tags.0", "x"},
+		{"result.tags.1", "y"},
+	}
+	if !reflect.DeepEqual(records, want) {
+		t.Errorf("FormatCSV = %q, want %q", records, want)
+	}
+}
+
+func TestFormatCSVEmptyListKeepsHeader(t *testing.T) {
+	out := FormatCSV(map[string]interface{}{"count": 0, "items": []interface{}{}})
+	if out != strings.Join(csvColumns, ",") {
+		t.Errorf("FormatCSV = %q, want only the header row", out)
+	}
+}
+
+func indexOf(list []string, s string) int {
+	for i, entry := range list {
+		if entry == s {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,48 @@
+package formatter
+
+// Process exit codes. Scripts can rely on these staying stable; any failure
+// without a more specific code exits with ExitFailure.
+const (
+	ExitSuccess     = 0
+	ExitFailure     = 1
+	ExitInvalidArgs = 2
+	ExitThingsError = 3
+	ExitTimeout     = 4
+	ExitAuthMissing = 5
+)
+
+// exitCodes maps error codes printed by PrintError to exit codes
+var exitCodes = map[string]int{
+	"INVALID_ARGUMENTS": ExitInvalidArgs,
+	"THINGS_ERROR":      ExitThingsError,
+	"TIMEOUT":           ExitTimeout,
+	"AUTH_REQUIRED":     ExitAuthMissing,
+}
+
+// exitCode is the exit code of the first error printed
+var exitCode = ExitSuccess
+
+// recordExitCode remembers the exit code for an error code unless an earlier
+// error already set one.
+func recordExitCode(code string) {
+	if exitCode != ExitSuccess {
+		return
+	}
+	if mapped, ok := exitCodes[code]; ok {
+		exitCode = mapped
+		return
+	}
+	exitCode = ExitFailure
+}
+
+// SetExitCode overrides the exit code, for errors whose code doesn't map to
+// one, such as the codes Things reports through its error callback.
+func SetExitCode(code int) {
+	exitCode = code
+}
+
+// ExitCode returns the code the process should exit with: ExitSuccess unless
+// an error was printed.
+func ExitCode() int {
+	return exitCode
+}
@@ -0,0 +1,129 @@
+package formatter
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Fields accepted by --group-by
+const (
+	GroupByProject = "project"
+	GroupByArea    = "area"
+	GroupByTag     = "tag"
+	GroupByDate    = "date"
+)
+
+// groupFields lists the accepted --group-by fields in help order
+var groupFields = []string{GroupByProject, GroupByArea, GroupByTag, GroupByDate}
+
+// groupBy is the field item lists are grouped by; empty leaves them flat
+var groupBy string
+
+// GroupFields returns the names accepted by SetGroupBy.
+func GroupFields() []string {
+	return append([]string(nil), groupFields...)
+}
+
+// SetGroupBy nests every list of items in the output under the value of
+// field, such as its project, for the rest of the process. An empty field
+// turns grouping off.
+func SetGroupBy(field string) error {
+	field = strings.ToLower(strings.TrimSpace(field))
+	if field == "" {
+		groupBy = ""
+		return nil
+	}
+	for _, known := range groupFields {
+		if field == known {
+			groupBy = field
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown group %q (use %s)", field, strings.Join(groupFields, ", "))
+}
+
+// groupItems returns v with each list of items replaced by an object mapping
+// group names to the items in that group, in their original order. The text
+// formats render the groups as section headers, and csv and template output
+// name them in the list column. v is returned unchanged when grouping is off.
+func groupItems(v interface{}) interface{} {
+	if groupBy == "" {
+		return v
+	}
+	return regroup(genericValue(v))
+}
+
+// regroup groups the item lists within a generic value.
+func regroup(v interface{}) interface{} {
+	switch value := v.(type) {
+	case map[string]interface{}:
+		if isItem(value) {
+			return value
+		}
+		grouped := make(map[string]interface{}, len(value))
+		for key, field := range value {
+			grouped[key] = regroup(field)
+		}
+		return grouped
+	case []interface{}:
+		items, ok := itemMaps(value)
+		if !ok {
+			for i, entry := range value {
+				value[i] = regroup(entry)
+			}
+			return value
+		}
+		groups := map[string]interface{}{}
+		for _, item := range items {
+			for _, name := range itemGroups(item) {
+				list, _ := groups[name].([]interface{})
+				groups[name] = append(list, item)
+			}
+		}
+		return groups
+	}
+	return v
+}
+
+// itemGroups returns the groups an item belongs to: one for each of its tags
+// when grouping by tag, and a single group otherwise. Items without a value
+// are grouped under "No project", "No area", and so on.
+func itemGroups(item map[string]interface{}) []string {
+	var names []string
+	switch groupBy {
+	case GroupByProject:
+		names = []string{fieldText(item, "project")}
+		if fieldText(item, "type") == "project" {
+			names = []string{fieldText(item, "title")}
+		}
+	case GroupByArea:
+		names = []string{fieldText(item, "area")}
+	case GroupByTag:
+		if tags, ok := item["tags"].([]interface{}); ok {
+			for _, tag := range tags {
+				names = append(names, fmt.Sprint(tag))
+			}
+		}
+	case GroupByDate:
+		names = []string{itemDate(item)}
+	}
+	if len(names) == 0 || names[0] == "" {
+		return []string{"No " + groupBy}
+	}
+	return names
+}
+
+// itemDate returns the day an item belongs to on an agenda: the day it was
+// completed or canceled, else its start date, else its deadline.
+func itemDate(item map[string]interface{}) string {
+	if completed := fieldText(item, "completed_at"); completed != "" && fieldText(item, "status") != "open" {
+		if len(completed) > len("2006-01-02") {
+			completed = completed[:len("2006-01-02")]
+		}
+		return completed
+	}
+	if start := fieldText(item, "start_date"); start != "" {
+		return start
+	}
+	return fieldText(item, "deadline")
+}
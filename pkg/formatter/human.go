@@ -0,0 +1,127 @@
+package formatter
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/yourusername/things3-cli/pkg/things"
+)
+
+// FormatItemLine renders a single item as one compact, human-readable line
+// Example: "[ ] Buy milk  due 2024-06-01 (in 3 days)  #errands  (ABC123)"
+// On a color terminal overdue items are red, today's blue, and tags dimmed.
+func FormatItemLine(item things.Item) string {
+	box := "[ ]"
+	switch item.Status {
+	case "completed":
+		box = "[x]"
+	case "canceled":
+		box = "[-]"
+	}
+
+	color := itemColor(item.Status, item.Deadline, item.When)
+	parts := []string{box + " " + colorize(color, item.Title)}
+	if item.Deadline != "" {
+		parts = append(parts, colorize(color, "due "+deadlineText(item.Status, item.Deadline)))
+	}
+	if len(item.Tags) > 0 {
+		tags := make([]string, len(item.Tags))
+		for i, tag := range item.Tags {
+			tags[i] = "#" + tag
+		}
+		parts = append(parts, colorize(ansiDim, strings.Join(tags, " ")))
+	}
+	parts = append(parts, "("+item.ID+")")
+
+	return strings.Join(parts, "  ")
+}
+
+// FormatItemList renders a titled list of items in the compact human format
+func FormatItemList(title string, items []things.Item) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s (%d)\n", title, len(items))
+	if len(items) == 0 {
+		b.WriteString("  nothing here\n")
+	}
+	for _, item := range items {
+		b.WriteString("  " + FormatItemLine(item) + "\n")
+	}
+	return strings.TrimRight(b.String(), "\n")
+}
+
+// PrintItemList prints a titled list of items in the compact human format,
+// or as a success response with count and items in the JSON format
+func PrintItemList(title string, items []things.Item) {
+	if quiet {
+		return
+	}
+	if outputFormat == OutputJSON {
+		PrintSuccess(map[string]interface{}{
+			"count": len(items),
+			"items": items,
+		})
+		return
+	}
+
+	list := groupItems(items)
+	switch outputFormat {
+	case OutputScreenReader:
+		fmt.Println(FormatScreenReader(map[string]interface{}{title: list}))
+		return
+	case OutputTable:
+		fmt.Println(FormatTable(map[string]interface{}{title: list}, terminalWidth()))
+		return
+	case OutputCSV:
+		fmt.Println(FormatCSV(map[string]interface{}{strings.ToLower(title): list}))
+		return
+	case OutputMarkdown:
+		fmt.Println(FormatMarkdown(map[string]interface{}{strings.ToLower(title): list}))
+		return
+	case OutputTaskPaper:
+		fmt.Println(FormatTaskPaper(map[string]interface{}{strings.ToLower(title): list}))
+		return
+	case OutputOrg:
+		fmt.Println(FormatOrg(map[string]interface{}{strings.ToLower(title): list}))
+		return
+	case OutputTemplate:
+		printTemplate(map[string]interface{}{strings.ToLower(title): list})
+		return
+	case OutputNDJSON:
+		PrintNDJSON(items)
+		return
+	}
+	if groupBy != "" {
+		fmt.Println(FormatPlain(map[string]interface{}{title: list}))
+		return
+	}
+	fmt.Println(FormatItemList(title, items))
+}
+
+// PrintActionResult prints the result of a Things action. The plain format
+// shows only the one-line summary, marked with a check mark; the others
+// print the result itself.
+func PrintActionResult(result things.ActionResult, summary string) {
+	if quiet {
+		return
+	}
+	if outputFormat == OutputPlain {
+		fmt.Println("✓ " + summary)
+		return
+	}
+	PrintSuccess(result)
+}
+
+// PrintCount prints the number of items a listing matched: a success
+// response with just the count in the JSON formats, and the bare number
+// otherwise, for scripts and prompt widgets.
+func PrintCount(n int) {
+	if quiet {
+		return
+	}
+	switch outputFormat {
+	case OutputJSON, OutputNDJSON:
+		PrintSuccess(map[string]interface{}{"count": n})
+		return
+	}
+	fmt.Println(n)
+}
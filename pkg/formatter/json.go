@@ -3,16 +3,13 @@ package formatter
 import (
 	"encoding/json"
 	"fmt"
+	"os"
 )
 
 // FormatSuccess formats a successful operation response as JSON
 // data: The data to include in the response (can be any type)
 func FormatSuccess(data interface{}) string {
-	response := map[string]interface{}{
-		"success": true,
-		"data":    data,
-	}
-	return formatAsJSON(response)
+	return formatAsJSON(NewSuccessResponse(data))
 }
 
 // FormatError formats an error response as JSON
@@ -20,39 +17,7 @@ func FormatSuccess(data interface{}) string {
 // code: Machine-readable error code (e.g., "NOTE_NOT_FOUND")
 // details: Optional additional details about the error
 func FormatError(errorMsg string, code string, details string) string {
-	response := map[string]interface{}{
-		"success":    false,
-		"error":      errorMsg,
-		"error_code": code,
-	}
-
-	if details != "" {
-		response["details"] = details
-	}
-
-	return formatAsJSON(response)
-}
-
-// FormatNoteList formats a list of notes as JSON
-// This is a convenience function for consistent list formatting
-func FormatNoteList(notes interface{}, count int) string {
-	response := map[string]interface{}{
-		"success": true,
-		"count":   count,
-		"data":    notes,
-	}
-	return formatAsJSON(response)
-}
-
-// FormatTagList formats a list of tags as JSON
-// This is a convenience function for consistent tag list formatting
-func FormatTagList(tags interface{}, count int) string {
-	response := map[string]interface{}{
-		"success": true,
-		"count":   count,
-		"data":    tags,
-	}
-	return formatAsJSON(response)
+	return formatAsJSON(NewErrorResponse(errorMsg, code, details))
 }
 
 // formatAsJSON converts any Go value to pretty-printed JSON
@@ -62,15 +27,11 @@ func formatAsJSON(v interface{}) string {
 	data, err := json.MarshalIndent(v, "", "  ")
 	if err != nil {
 		// If marshaling fails, return an error response
-		fallback := map[string]interface{}{
-			"success":    false,
-			"error":      "Failed to format response",
-			"error_code": "FORMAT_ERROR",
-		}
+		fallback := NewErrorResponse("Failed to format response", "FORMAT_ERROR", err.Error())
 		if data, err := json.MarshalIndent(fallback, "", "  "); err == nil {
 			return string(data)
 		}
-		return `{"success": false, "error": "Critical formatting error"}`
+		return `{"schema_version": 1, "success": false, "error": {"code": "FORMAT_ERROR", "message": "Critical formatting error"}}`
 	}
 
 	return string(data)
@@ -85,23 +46,63 @@ func PrintJSON(v interface{}) {
 
 // PrintSuccess prints a success response to stdout
 func PrintSuccess(data interface{}) {
-	PrintJSON(map[string]interface{}{
-		"success": true,
-		"data":    data,
-	})
+	if quiet {
+		return
+	}
+	if outputFormat == OutputNDJSON {
+		PrintNDJSON(data)
+		return
+	}
+
+	response := NewSuccessResponse(data)
+	data = groupItems(data)
+	switch outputFormat {
+	case OutputScreenReader:
+		fmt.Println(FormatScreenReader(data))
+		return
+	case OutputPlain:
+		fmt.Println(FormatPlain(data))
+		return
+	case OutputTable:
+		fmt.Println(FormatTable(data, terminalWidth()))
+		return
+	case OutputCSV:
+		fmt.Println(FormatCSV(data))
+		return
+	case OutputMarkdown:
+		fmt.Println(FormatMarkdown(data))
+		return
+	case OutputTaskPaper:
+		fmt.Println(FormatTaskPaper(data))
+		return
+	case OutputOrg:
+		fmt.Println(FormatOrg(data))
+		return
+	case OutputTemplate:
+		printTemplate(data)
+		return
+	}
+	response.Data = data
+	PrintJSON(response)
 }
 
-// PrintError prints an error response to stdout
+// PrintError prints an error response to stdout, or to stderr in the
+// text formats, and sets the exit code for the error code
 func PrintError(errorMsg string, code string, details string) {
-	response := map[string]interface{}{
-		"success":    false,
-		"error":      errorMsg,
-		"error_code": code,
+	recordExitCode(code)
+	switch outputFormat {
+	case OutputScreenReader:
+		fmt.Println(FormatScreenReaderError(errorMsg, details))
+		return
+	case OutputPlain, OutputTable, OutputCSV, OutputMarkdown, OutputTaskPaper, OutputOrg, OutputTemplate:
+		fmt.Fprintln(os.Stderr, FormatPlainError(errorMsg, details))
+		return
 	}
 
-	if details != "" {
-		response["details"] = details
+	response := NewErrorResponse(errorMsg, code, details)
+	if outputFormat == OutputNDJSON {
+		ndjsonEncoder.Encode(response)
+		return
 	}
-
 	PrintJSON(response)
 }
@@ -0,0 +1,124 @@
+package formatter
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/yourusername/things3-cli/pkg/util"
+)
+
+// markdownLinks turns item titles in markdown output into links that open
+// the item in Things
+var markdownLinks bool
+
+// SetMarkdownLinks turns the item links of the markdown format on or off.
+func SetMarkdownLinks(on bool) {
+	markdownLinks = on
+}
+
+// FormatMarkdown renders the to-dos and projects in a value as markdown
+// checkbox lists, such as "- [ ] Send invoice (due Jun 3) #finance", ready to
+// paste into a document or pull request. Items are grouped under a heading
+// per list when the response has several, such as today and evening, and per
+// project otherwise. Values without items are rendered as plain text.
+func FormatMarkdown(v interface{}) string {
+	generic := genericValue(v)
+
+	var items []listedItem
+	collectItems(&items, "", generic)
+	if len(items) == 0 {
+		if hasItemList(generic) {
+			return "_Nothing here._"
+		}
+		return FormatPlain(v)
+	}
+
+	lists := map[string]bool{}
+	for _, item := range items {
+		lists[item.list] = true
+	}
+	groupOf := func(item listedItem) string {
+		if len(lists) > 1 {
+			return capitalize(item.list)
+		}
+		for _, key := range []string{"project", "area"} {
+			if name, _ := item.fields[key].(string); name != "" {
+				return name
+			}
+		}
+		if item.list == "" || item.list == "items" {
+			return "No project"
+		}
+		return capitalize(item.list)
+	}
+
+	var order []string
+	groups := map[string][]string{}
+	for _, item := range items {
+		group := groupOf(item)
+		if _, ok := groups[group]; !ok {
+			order = append(order, group)
+		}
+		groups[group] = append(groups[group], markdownItemLine(item.fields))
+	}
+
+	var b strings.Builder
+	for i, group := range order {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		fmt.Fprintf(&b, "## %s\n\n", group)
+		for _, line := range groups[group] {
+			b.WriteString(line + "\n")
+		}
+	}
+	return strings.TrimRight(b.String(), "\n")
+}
+
+// markdownItemLine renders one item as a checkbox line with its deadline and
+// tags, its title linking to the item when links are on.
+func markdownItemLine(fields map[string]interface{}) string {
+	title := fieldText(fields, "title")
+	if link := fieldText(fields, "url"); markdownLinks && link != "" {
+		title = "[" + markdownLinkText.Replace(title) + "](" + link + ")"
+	}
+	box := "[ ]"
+	switch fieldText(fields, "status") {
+	case "completed":
+		box = "[x]"
+	case "canceled":
+		box = "[x]"
+		title = "~~" + title + "~~"
+	}
+
+	line := "- " + box + " " + title
+	if deadline := fieldText(fields, "deadline"); deadline != "" {
+		line += " (due " + shortDate(deadline) + ")"
+	}
+	if tags, ok := fields["tags"].([]interface{}); ok {
+		for _, tag := range tags {
+			line += " #" + strings.ReplaceAll(fmt.Sprint(tag), " ", "-")
+		}
+	}
+	return line
+}
+
+// markdownLinkText escapes the characters that would end a link's text
+var markdownLinkText = strings.NewReplacer(`\`, `\\`, "[", `\[`, "]", `\]`)
+
+// shortDate renders a YYYY-MM-DD date as "Jun 3", adding the year when it
+// isn't the current one, or in the display format when one was chosen.
+func shortDate(date string) string {
+	if util.DateFormat() != util.DateLayout {
+		return util.DisplayDate(date)
+	}
+	t, err := time.Parse(util.DateLayout, date)
+	if err != nil {
+		return date
+	}
+	if t.Year() != util.Now().Year() {
+		return t.Format("Jan 2, 2006")
+	}
+	return t.Format("Jan 2")
+}
@@ -0,0 +1,54 @@
+package formatter
+
+import (
+	"encoding/json"
+	"os"
+)
+
+// ndjsonEncoder writes one compact JSON value per line to stdout
+var ndjsonEncoder = newNDJSONEncoder()
+
+func newNDJSONEncoder() *json.Encoder {
+	enc := json.NewEncoder(stdout{})
+	enc.SetEscapeHTML(false)
+	return enc
+}
+
+// stdout writes to os.Stdout as it is when written to, so output that is
+// redirected after startup, as with --output, is followed
+type stdout struct{}
+
+func (stdout) Write(p []byte) (int, error) {
+	return os.Stdout.Write(p)
+}
+
+// PrintNDJSON writes the to-dos, projects, and headings in a value as one
+// JSON object per line, each written as soon as it is encoded. A value
+// without items is written as a single line.
+func PrintNDJSON(v interface{}) {
+	generic := genericValue(v)
+
+	var items []listedItem
+	collectItems(&items, "", generic)
+	if len(items) == 0 {
+		if !hasItemList(generic) {
+			ndjsonEncoder.Encode(generic)
+		}
+		return
+	}
+	for _, item := range items {
+		ndjsonEncoder.Encode(item.fields)
+	}
+}
+
+// PrintRecord writes one event of a long-running command, such as a file
+// imported by capture watch. In the ndjson format it is a JSON line on
+// stdout; the other formats leave events to the caller's log and it reports
+// false.
+func PrintRecord(v interface{}) bool {
+	if outputFormat != OutputNDJSON || quiet {
+		return false
+	}
+	ndjsonEncoder.Encode(v)
+	return true
+}
@@ -0,0 +1,84 @@
+package formatter
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// redirectStdout points os.Stdout at a file for the rest of the test, the
+// way --output does after the encoders have been created, and returns a
+// function that reads what was written to it.
+func redirectStdout(t *testing.T) func() string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "out")
+	file, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	saved := os.Stdout
+	os.Stdout = file
+	t.Cleanup(func() {
+		os.Stdout = saved
+		file.Close()
+	})
+	return func() string {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(data)
+	}
+}
+
+func TestPrintNDJSON(t *testing.T) {
+	tests := []struct {
+		name string
+		v    interface{}
+		want string
+	}{
+		{
+			name: "one line per item",
+			v: map[string]interface{}{"today": []interface{}{
+				map[string]interface{}{"id": "T1", "type": "to-do", "title": "One"},
+				map[string]interface{}{"id": "T2", "type": "to-do", "title": "Two & <three>"},
+			}},
+			want: `{"id":"T1","title":"One","type":"to-do"}` + "\n" + `{"id":"T2","title":"Two & <three>","type":"to-do"}` + "\n",
+		},
+		{
+			name: "value without items",
+			v:    map[string]interface{}{"id": "T1", "updated": true},
+			want: `{"id":"T1","updated":true}` + "\n",
+		},
+		{
+			name: "empty list",
+			v:    map[string]interface{}{"count": 0, "items": []interface{}{}},
+			want: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			written := redirectStdout(t)
+			PrintNDJSON(tt.v)
+			if got := written(); got != tt.want {
+				t.Errorf("PrintNDJSON wrote %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPrintRecordFollowsRedirectedStdout(t *testing.T) {
+	if err := SetOutputFormat(OutputNDJSON); err != nil {
+		t.Fatal(err)
+	}
+	defer SetOutputFormat(OutputJSON)
+
+	written := redirectStdout(t)
+	if !PrintRecord(map[string]string{"file": "a.txt"}) {
+		t.Fatal("PrintRecord did not write in the ndjson format")
+	}
+	if got, want := written(), `{"file":"a.txt"}`+"\n"; got != want {
+		t.Errorf("PrintRecord wrote %q, want %q", got, want)
+	}
+}
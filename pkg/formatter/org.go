@@ -0,0 +1,138 @@
+package formatter
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/yourusername/things3-cli/pkg/util"
+)
+
+// orgTodoKeywords declares the keywords used for to-dos, since CANCELED is
+// not one of org's defaults
+const orgTodoKeywords = "#+TODO: TODO | DONE CANCELED"
+
+// FormatOrg renders the items in a value as an org-mode outline for review in
+// Emacs. Projects become top-level headings, Things headings second-level
+// ones, and to-dos TODO, DONE, or CANCELED entries with SCHEDULED, DEADLINE,
+// and CLOSED timestamps and their tags. Notes follow as body text. Values
+// without items are rendered as plain text.
+func FormatOrg(v interface{}) string {
+	generic := genericValue(v)
+
+	var items []listedItem
+	collectItems(&items, "", generic)
+	if len(items) == 0 {
+		if hasItemList(generic) {
+			return orgTodoKeywords
+		}
+		return FormatPlain(v)
+	}
+
+	var b strings.Builder
+	b.WriteString(orgTodoKeywords + "\n")
+	for _, p := range buildOutline(items) {
+		b.WriteString("\n* " + orgText(p.title) + orgTags(p.fields) + "\n")
+		writeOrgPlanning(&b, "  ", p.fields)
+		writeOrgBody(&b, "  ", fieldText(p.fields, "notes"))
+		for _, fields := range p.loose {
+			writeOrgEntry(&b, 2, fields)
+		}
+		for _, heading := range p.headings {
+			b.WriteString("** " + orgText(heading) + "\n")
+			for _, fields := range p.byHead[heading] {
+				writeOrgEntry(&b, 3, fields)
+			}
+		}
+	}
+	return strings.TrimRight(b.String(), "\n")
+}
+
+// writeOrgEntry writes a to-do as a heading of the given level.
+func writeOrgEntry(b *strings.Builder, level int, fields map[string]interface{}) {
+	keyword := "TODO"
+	switch fieldText(fields, "status") {
+	case "completed":
+		keyword = "DONE"
+	case "canceled":
+		keyword = "CANCELED"
+	}
+	indent := strings.Repeat(" ", level+1)
+	fmt.Fprintf(b, "%s %s %s%s\n", strings.Repeat("*", level), keyword, orgText(fieldText(fields, "title")), orgTags(fields))
+	writeOrgPlanning(b, indent, fields)
+	writeOrgBody(b, indent, fieldText(fields, "notes"))
+}
+
+// writeOrgPlanning writes the planning line of an entry: when it was closed,
+// when it is scheduled, and its deadline.
+func writeOrgPlanning(b *strings.Builder, indent string, fields map[string]interface{}) {
+	var planning []string
+	if closed, err := time.Parse(time.RFC3339, fieldText(fields, "completed_at")); err == nil {
+		planning = append(planning, "CLOSED: ["+closed.In(util.Location()).Format("2006-01-02 Mon 15:04")+"]")
+	}
+	if start := fieldText(fields, "start_date"); start != "" && fieldText(fields, "status") == "open" {
+		planning = append(planning, "SCHEDULED: "+orgDate(start))
+	}
+	if deadline := fieldText(fields, "deadline"); deadline != "" {
+		planning = append(planning, "DEADLINE: "+orgDate(deadline))
+	}
+	if len(planning) > 0 {
+		b.WriteString(indent + strings.Join(planning, " ") + "\n")
+	}
+}
+
+// writeOrgBody writes notes below an entry. The lines are indented, so ones
+// starting with "*" aren't read as headings.
+func writeOrgBody(b *strings.Builder, indent, notes string) {
+	notes = strings.TrimSpace(notes)
+	if notes == "" {
+		return
+	}
+	for _, line := range strings.Split(notes, "\n") {
+		line = strings.TrimRight(line, " \t")
+		if line == "" {
+			b.WriteString("\n")
+			continue
+		}
+		b.WriteString(indent + line + "\n")
+	}
+}
+
+// orgDate renders a YYYY-MM-DD date as an active org timestamp.
+func orgDate(date string) string {
+	t, err := time.Parse(util.DateLayout, date)
+	if err != nil {
+		return "<" + date + ">"
+	}
+	return "<" + t.Format("2006-01-02 Mon") + ">"
+}
+
+// orgTags returns the Things tags of an item as org tags, such as
+// " :work:urgent:", or "" when it has none.
+func orgTags(fields map[string]interface{}) string {
+	list, _ := fields["tags"].([]interface{})
+	if len(list) == 0 {
+		return ""
+	}
+	tags := make([]string, len(list))
+	for i, tag := range list {
+		tags[i] = orgTagName(fmt.Sprint(tag))
+	}
+	return " :" + strings.Join(tags, ":") + ":"
+}
+
+// orgTagName replaces the characters org doesn't allow in tags with "_".
+func orgTagName(tag string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || strings.ContainsRune("_@#%", r) {
+			return r
+		}
+		return '_'
+	}, tag)
+}
+
+// orgText keeps a heading on one line.
+func orgText(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
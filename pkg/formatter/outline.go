@@ -0,0 +1,60 @@
+package formatter
+
+// outlineProject is one project of an outline format such as TaskPaper or
+// org: the project item itself when the result includes it, the to-dos
+// outside a heading, and each heading with its to-dos, in result order.
+type outlineProject struct {
+	title    string
+	fields   map[string]interface{}
+	loose    []map[string]interface{}
+	headings []string
+	byHead   map[string][]map[string]interface{}
+}
+
+// buildOutline groups items by project and heading. To-dos outside a project
+// are grouped under the name of their list, or Inbox for plain results.
+func buildOutline(items []listedItem) []*outlineProject {
+	var order []*outlineProject
+	projects := map[string]*outlineProject{}
+	project := func(title string) *outlineProject {
+		p, ok := projects[title]
+		if !ok {
+			p = &outlineProject{title: title, byHead: map[string][]map[string]interface{}{}}
+			projects[title] = p
+			order = append(order, p)
+		}
+		return p
+	}
+	addHeading := func(p *outlineProject, heading string) {
+		if _, ok := p.byHead[heading]; !ok {
+			p.byHead[heading] = nil
+			p.headings = append(p.headings, heading)
+		}
+	}
+
+	for _, item := range items {
+		fields := item.fields
+		switch fieldText(fields, "type") {
+		case "project":
+			project(fieldText(fields, "title")).fields = fields
+		case "heading":
+			addHeading(project(fieldText(fields, "project")), fieldText(fields, "title"))
+		default:
+			title := fieldText(fields, "project")
+			if title == "" {
+				title = capitalize(item.list)
+				if item.list == "" || item.list == "items" {
+					title = "Inbox"
+				}
+			}
+			p := project(title)
+			if heading := fieldText(fields, "heading"); heading != "" {
+				addHeading(p, heading)
+				p.byHead[heading] = append(p.byHead[heading], fields)
+			} else {
+				p.loose = append(p.loose, fields)
+			}
+		}
+	}
+	return order
+}
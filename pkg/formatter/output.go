@@ -0,0 +1,89 @@
+package formatter
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"golang.org/x/term"
+)
+
+// Output formats accepted by --format and the output_format config setting.
+// OutputAuto is resolved when it is selected: plain text on a terminal and
+// JSON when stdout is a pipe or file.
+const (
+	OutputAuto         = "auto"
+	OutputJSON         = "json"
+	OutputPlain        = "plain"
+	OutputScreenReader = "screenreader"
+	OutputTable        = "table"
+	OutputCSV          = "csv"
+	OutputMarkdown     = "markdown"
+	OutputTaskPaper    = "taskpaper"
+	OutputTemplate     = "template"
+	OutputNDJSON       = "ndjson"
+	OutputOrg          = "org"
+)
+
+// outputFormats lists the accepted output formats in help order
+var outputFormats = []string{OutputAuto, OutputJSON, OutputNDJSON, OutputPlain, OutputTable, OutputCSV, OutputMarkdown, OutputTaskPaper, OutputOrg, OutputTemplate, OutputScreenReader}
+
+// outputFormat is the format used by PrintSuccess, PrintError, and PrintItemList
+var outputFormat = OutputJSON
+
+// quiet suppresses successful output; errors are still printed
+var quiet bool
+
+// OutputFormats returns the names of the accepted output formats.
+func OutputFormats() []string {
+	return append([]string(nil), outputFormats...)
+}
+
+// SetOutputFormat selects the output format for the rest of the process.
+// An empty name selects auto.
+func SetOutputFormat(name string) error {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "" {
+		name = OutputAuto
+	}
+	for _, format := range outputFormats {
+		if name != format {
+			continue
+		}
+		if name == OutputAuto {
+			name = OutputJSON
+			if term.IsTerminal(int(os.Stdout.Fd())) {
+				name = OutputPlain
+			}
+		}
+		outputFormat = name
+		return nil
+	}
+	return fmt.Errorf("unknown output format %q (use %s)", name, strings.Join(outputFormats, ", "))
+}
+
+// OutputFormat returns the selected output format, never OutputAuto.
+func OutputFormat() string {
+	return outputFormat
+}
+
+// SetQuiet turns off successful output for the rest of the process, for
+// scripts that only check whether a command failed.
+func SetQuiet(on bool) {
+	quiet = on
+}
+
+// Quiet reports whether successful output is turned off.
+func Quiet() bool {
+	return quiet
+}
+
+// terminalWidth returns the width of the terminal on stdout, or 0 when stdout
+// is not a terminal and output should not be shortened.
+func terminalWidth() int {
+	width, _, err := term.GetSize(int(os.Stdout.Fd()))
+	if err != nil {
+		return 0
+	}
+	return width
+}
@@ -0,0 +1,145 @@
+package formatter
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/yourusername/things3-cli/pkg/things"
+)
+
+// FormatPlain renders a value as indented text for reading in a terminal.
+// Simple fields come first as "key: value" lines, followed by lists headed
+// by their length, and to-dos, projects, and headings use the compact item
+// line of FormatItemLine.
+func FormatPlain(v interface{}) string {
+	r := &plainRenderer{}
+	return r.render(v)
+}
+
+// FormatPlainError renders an error response as plain text.
+func FormatPlainError(errorMsg string, details string) string {
+	line := "Error: " + errorMsg
+	if details != "" {
+		line += "\n  " + details
+	}
+	return line
+}
+
+// plainRenderer walks a value in its JSON form, writing indented lines.
+// itemList, when set, renders lists made up only of items, such as the
+// aligned columns of the table format.
+type plainRenderer struct {
+	lines    []string
+	itemList func(indent, list string, items []map[string]interface{}) []string
+}
+
+// render returns the lines for v.
+func (r *plainRenderer) render(v interface{}) string {
+	r.value("", "", genericValue(v))
+	if len(r.lines) == 0 {
+		return "done"
+	}
+	return strings.Join(r.lines, "\n")
+}
+
+// value appends the lines for v at the given indent, introduced by label
+// when it is set.
+func (r *plainRenderer) value(indent, label string, v interface{}) {
+	switch value := v.(type) {
+	case map[string]interface{}:
+		if isItem(value) {
+			r.lines = append(r.lines, indent+joinPlain(label, plainItemLine(value)))
+			return
+		}
+		if label != "" {
+			r.lines = append(r.lines, indent+label+":")
+			indent += "  "
+		}
+		r.fields(indent, value)
+	case []interface{}:
+		if label == "" {
+			label = "results"
+		}
+		r.lines = append(r.lines, fmt.Sprintf("%s%s (%d)", indent, label, len(value)))
+		if items, ok := itemMaps(value); ok && r.itemList != nil {
+			r.lines = append(r.lines, r.itemList(indent+"  ", label, items)...)
+			return
+		}
+		for _, entry := range value {
+			r.value(indent+"  ", "", entry)
+		}
+	default:
+		r.lines = append(r.lines, indent+joinPlain(label, plainScalar(value)))
+	}
+}
+
+// fields appends the simple fields of a map before its lists and nested
+// objects, each group in key order.
+func (r *plainRenderer) fields(indent string, fields map[string]interface{}) {
+	var simple, nested []string
+	for key, value := range fields {
+		switch value.(type) {
+		case map[string]interface{}, []interface{}:
+			nested = append(nested, key)
+		default:
+			simple = append(simple, key)
+		}
+	}
+	sort.Strings(simple)
+	sort.Strings(nested)
+	for _, key := range append(simple, nested...) {
+		r.value(indent, key, fields[key])
+	}
+}
+
+// itemMaps returns the entries of a non-empty list when all of them are items.
+func itemMaps(list []interface{}) ([]map[string]interface{}, bool) {
+	if len(list) == 0 {
+		return nil, false
+	}
+	items := make([]map[string]interface{}, len(list))
+	for i, entry := range list {
+		fields, ok := entry.(map[string]interface{})
+		if !ok || !isItem(fields) {
+			return nil, false
+		}
+		items[i] = fields
+	}
+	return items, true
+}
+
+// plainItemLine renders an item map with FormatItemLine.
+func plainItemLine(fields map[string]interface{}) string {
+	var item things.Item
+	data, err := json.Marshal(fields)
+	if err == nil {
+		err = json.Unmarshal(data, &item)
+	}
+	if err != nil {
+		return fmt.Sprint(fields["title"])
+	}
+	return FormatItemLine(item)
+}
+
+// plainScalar renders a JSON scalar, with "-" for null and whole numbers
+// without decimals.
+func plainScalar(v interface{}) string {
+	switch value := v.(type) {
+	case nil:
+		return "-"
+	case float64:
+		if value == float64(int64(value)) {
+			return fmt.Sprint(int64(value))
+		}
+	}
+	return fmt.Sprint(v)
+}
+
+func joinPlain(label, text string) string {
+	if label == "" {
+		return text
+	}
+	return label + ": " + text
+}
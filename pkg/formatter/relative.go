@@ -0,0 +1,81 @@
+package formatter
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/yourusername/things3-cli/pkg/util"
+)
+
+// relativeDates adds how far off a date is, such as "in 3 days", next to the
+// dates of open items in the plain and table formats
+var relativeDates = true
+
+// SetRelativeDates turns the relative dates of the plain and table formats
+// on or off, for exact output.
+func SetRelativeDates(on bool) {
+	relativeDates = on
+}
+
+// deadlineText renders the deadline of an item in the display format,
+// followed by how far off it is when the item is open: "2024-06-03 (in 3
+// days)" or "2024-05-29 (2 days overdue)".
+func deadlineText(status, deadline string) string {
+	text := util.DisplayDate(deadline)
+	if status != "open" {
+		return text
+	}
+	switch days, ok := daysUntil(deadline); {
+	case !ok:
+		return text
+	case days < 0:
+		return fmt.Sprintf("%s (%s overdue)", text, pluralDays(-days))
+	default:
+		return fmt.Sprintf("%s (%s)", text, relativeDay(days))
+	}
+}
+
+// startText renders the start date of an upcoming item in the display
+// format, followed by how far off it is.
+func startText(start string) string {
+	text := util.DisplayDate(start)
+	if days, ok := daysUntil(start); ok && days >= 0 {
+		return fmt.Sprintf("%s (%s)", text, relativeDay(days))
+	}
+	return text
+}
+
+// daysUntil returns the number of days from today to a YYYY-MM-DD date,
+// negative when it has passed. It reports false when relative dates are off
+// or date isn't a date.
+func daysUntil(date string) (int, bool) {
+	if !relativeDates {
+		return 0, false
+	}
+	now := util.Now()
+	t, err := time.ParseInLocation(util.DateLayout, date, now.Location())
+	if err != nil {
+		return 0, false
+	}
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	// Round rather than truncate, as days around a DST change aren't 24 hours
+	return int(t.Sub(today).Round(24*time.Hour) / (24 * time.Hour)), true
+}
+
+// relativeDay describes a day that is days from today, days >= 0.
+func relativeDay(days int) string {
+	switch days {
+	case 0:
+		return "today"
+	case 1:
+		return "tomorrow"
+	}
+	return "in " + pluralDays(days)
+}
+
+func pluralDays(n int) string {
+	if n == 1 {
+		return "1 day"
+	}
+	return fmt.Sprintf("%d days", n)
+}
@@ -0,0 +1,59 @@
+package formatter
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/yourusername/things3-cli/pkg/things"
+)
+
+// WriteReleaseNotesMarkdown renders drafted release notes as Markdown: the
+// resolved to-dos with the commits that fixed them, then the other commits.
+func WriteReleaseNotesMarkdown(w io.Writer, notes *things.ReleaseNotes) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s release notes\n\n", notes.Repo)
+	fmt.Fprintf(&b, "_Changes since %s (%s), drafted on %s_\n", notes.SinceTag, notes.TagDate, notes.GeneratedAt.Format("January 2, 2006"))
+
+	short := make(map[string]string, len(notes.Commits))
+	linked := make(map[string]bool)
+	for _, commit := range notes.Commits {
+		short[commit.Hash] = commit.ShortHash()
+	}
+
+	if len(notes.Resolved) > 0 {
+		b.WriteString("\n## Resolved\n\n")
+		for _, todo := range notes.Resolved {
+			fmt.Fprintf(&b, "- %s (`%s:%d`", todo.Title, todo.File, todo.Line)
+			if len(todo.Commits) > 0 {
+				hashes := make([]string, len(todo.Commits))
+				for i, hash := range todo.Commits {
+					hashes[i] = short[hash]
+					linked[hash] = true
+				}
+				fmt.Fprintf(&b, ", %s", strings.Join(hashes, ", "))
+			}
+			b.WriteString(")\n")
+		}
+	}
+
+	heading := "Changes"
+	if len(linked) > 0 {
+		heading = "Other changes"
+	}
+	fmt.Fprintf(&b, "\n## %s\n\n", heading)
+	listed := 0
+	for _, commit := range notes.Commits {
+		if linked[commit.Hash] {
+			continue
+		}
+		fmt.Fprintf(&b, "- %s (%s)\n", commit.Subject, commit.ShortHash())
+		listed++
+	}
+	if listed == 0 {
+		b.WriteString("No other commits.\n")
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
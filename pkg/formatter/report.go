@@ -0,0 +1,108 @@
+package formatter
+
+import (
+	"fmt"
+	"html/template"
+	"io"
+	"strings"
+
+	"github.com/yourusername/things3-cli/pkg/things"
+)
+
+// shareReportTemplate is a self-contained status page that survives being
+// pasted into an email: inline styles only, no scripts or external assets.
+var shareReportTemplate = template.Must(template.New("share").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>{{.Project}} – Status</title>
+</head>
+<body style="font-family: -apple-system, Helvetica, Arial, sans-serif; color: #222; max-width: 640px; margin: 24px auto; padding: 0 16px; line-height: 1.45;">
+<h1 style="margin-bottom: 4px;">{{.Project}}</h1>
+<p style="color: #666; margin-top: 0;">{{if .Area}}{{.Area}} · {{end}}Status as of {{.GeneratedAt.Format "January 2, 2006"}}{{if .Deadline}} · Due {{.Deadline}}{{end}}</p>
+{{if .Notes}}<p style="white-space: pre-wrap;">{{.Notes}}</p>{{end}}
+<table style="border-collapse: collapse; margin: 16px 0;">
+<tr>
+<td style="padding: 8px 16px; background: #f2f6fc; text-align: center;"><strong style="font-size: 22px;">{{.Open}}</strong><br>open</td>
+<td style="padding: 8px 16px; background: #eef8f0; text-align: center;"><strong style="font-size: 22px;">{{.Completed}}</strong><br>completed</td>
+<td style="padding: 8px 16px; background: #f6f6f6; text-align: center;"><strong style="font-size: 22px;">{{.Canceled}}</strong><br>canceled</td>
+<td style="padding: 8px 16px; text-align: center;"><strong style="font-size: 22px;">{{.PercentDone}}%</strong><br>done</td>
+</tr>
+</table>
+{{if .Sections}}<h2>Progress</h2>
+<ul>{{range .Sections}}
+<li>{{.Title}}: {{.Done}} done, {{.Open}} open</li>{{end}}
+</ul>{{end}}
+<h2>Upcoming milestones</h2>
+{{if .Milestones}}<ul>{{range .Milestones}}
+<li><strong>{{.Date}}</strong> – {{.Title}}{{if .Notes}}<br><span style="color: #666; white-space: pre-wrap;">{{.Notes}}</span>{{end}}</li>{{end}}
+</ul>{{else}}<p style="color: #666;">No open deadlines.</p>{{end}}
+<h2>Completed since {{.Since}}</h2>
+{{if .Recent}}<ul>{{range .Recent}}
+<li>{{.Title}} <span style="color: #666;">({{.Date}})</span>{{if .Notes}}<br><span style="color: #666; white-space: pre-wrap;">{{.Notes}}</span>{{end}}</li>{{end}}
+</ul>{{else}}<p style="color: #666;">Nothing completed in this period.</p>{{end}}
+</body>
+</html>
+`))
+
+// WriteShareReportHTML renders a share report as a standalone HTML page.
+func WriteShareReportHTML(w io.Writer, report *things.ShareReport) error {
+	return shareReportTemplate.Execute(w, report)
+}
+
+// WriteShareReportMarkdown renders a share report as markdown.
+func WriteShareReportMarkdown(w io.Writer, report *things.ShareReport) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s\n\n", report.Project)
+
+	meta := []string{"Status as of " + report.GeneratedAt.Format("January 2, 2006")}
+	if report.Area != "" {
+		meta = append([]string{report.Area}, meta...)
+	}
+	if report.Deadline != "" {
+		meta = append(meta, "Due "+report.Deadline)
+	}
+	fmt.Fprintf(&b, "_%s_\n\n", strings.Join(meta, " · "))
+	if report.Notes != "" {
+		fmt.Fprintf(&b, "%s\n\n", report.Notes)
+	}
+	fmt.Fprintf(&b, "**%d** open · **%d** completed · **%d** canceled · **%d%%** done\n",
+		report.Open, report.Completed, report.Canceled, report.PercentDone)
+
+	if len(report.Sections) > 0 {
+		b.WriteString("\n## Progress\n\n")
+		for _, section := range report.Sections {
+			fmt.Fprintf(&b, "- %s: %d done, %d open\n", section.Title, section.Done, section.Open)
+		}
+	}
+
+	b.WriteString("\n## Upcoming milestones\n\n")
+	if len(report.Milestones) == 0 {
+		b.WriteString("No open deadlines.\n")
+	}
+	for _, entry := range report.Milestones {
+		fmt.Fprintf(&b, "- **%s** – %s\n", entry.Date, entry.Title)
+		writeIndentedNotes(&b, entry.Notes)
+	}
+
+	fmt.Fprintf(&b, "\n## Completed since %s\n\n", report.Since)
+	if len(report.Recent) == 0 {
+		b.WriteString("Nothing completed in this period.\n")
+	}
+	for _, entry := range report.Recent {
+		fmt.Fprintf(&b, "- %s (%s)\n", entry.Title, entry.Date)
+		writeIndentedNotes(&b, entry.Notes)
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func writeIndentedNotes(b *strings.Builder, notes string) {
+	if notes == "" {
+		return
+	}
+	for _, line := range strings.Split(notes, "\n") {
+		b.WriteString("  " + line + "\n")
+	}
+}
@@ -0,0 +1,110 @@
+package formatter
+
+import "reflect"
+
+// SchemaVersion is the version of the Response layout. It changes only when
+// a field is removed or changes meaning; new fields may appear at any time.
+//
+// Version 1 replaced the top-level error string, error_code, and details of
+// earlier releases with the Error object.
+const SchemaVersion = 1
+
+// Response is the envelope of every JSON result:
+//
+//	{"schema_version": 1, "success": true, "command": "today", "count": 2, "data": {...}}
+//	{"schema_version": 1, "success": false, "command": "show", "error": {"code": "NOT_FOUND", "message": "..."}}
+type Response struct {
+	SchemaVersion int  `json:"schema_version"`
+	Success       bool `json:"success"`
+	// Command is the command that produced the response, such as "today"
+	// or "template apply".
+	Command string `json:"command,omitempty"`
+	// Count is the number of results, when the data is a list or reports
+	// its own count.
+	Count *int         `json:"count,omitempty"`
+	Data  interface{}  `json:"data,omitempty"`
+	Error *ErrorDetail `json:"error,omitempty"`
+}
+
+// ErrorDetail describes a failure. Code is one of the stable codes such as
+// INVALID_ARGUMENTS or NOT_FOUND, or an error code reported by Things.
+type ErrorDetail struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+	Details string `json:"details,omitempty"`
+}
+
+// command names the running command in responses
+var command string
+
+// SetCommand sets the command name included in every response.
+func SetCommand(name string) {
+	command = name
+}
+
+// NewSuccessResponse wraps data in a successful response.
+func NewSuccessResponse(data interface{}) Response {
+	return Response{
+		SchemaVersion: SchemaVersion,
+		Success:       true,
+		Command:       command,
+		Count:         responseCount(data),
+		Data:          data,
+	}
+}
+
+// NewErrorResponse builds a failed response.
+func NewErrorResponse(errorMsg, code, details string) Response {
+	return Response{
+		SchemaVersion: SchemaVersion,
+		Success:       false,
+		Command:       command,
+		Error:         &ErrorDetail{Code: code, Message: errorMsg, Details: details},
+	}
+}
+
+// responseCount returns the length of list data, the count field of data
+// that reports one, or nil otherwise.
+func responseCount(data interface{}) *int {
+	value := reflect.ValueOf(data)
+	for value.Kind() == reflect.Pointer || value.Kind() == reflect.Interface {
+		if value.IsNil() {
+			return nil
+		}
+		value = value.Elem()
+	}
+
+	switch value.Kind() {
+	case reflect.Slice, reflect.Array:
+		n := value.Len()
+		return &n
+	case reflect.Map:
+		if value.Type().Key().Kind() != reflect.String {
+			return nil
+		}
+		if count := value.MapIndex(reflect.ValueOf("count")); count.IsValid() {
+			return intValue(count)
+		}
+	case reflect.Struct:
+		if count := value.FieldByName("Count"); count.IsValid() {
+			return intValue(count)
+		}
+	}
+	return nil
+}
+
+// intValue returns v as an int when it holds an integer.
+func intValue(v reflect.Value) *int {
+	for v.Kind() == reflect.Interface {
+		v = v.Elem()
+	}
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n := int(v.Int())
+		return &n
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n := int(v.Uint())
+		return &n
+	}
+	return nil
+}
@@ -0,0 +1,257 @@
+package formatter
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/yourusername/things3-cli/pkg/util"
+)
+
+// FormatScreenReader renders a value as short plain sentences, one per line,
+// for reading with VoiceOver. There are no tables, brackets, or symbols to
+// announce, and every list states its length and numbers its entries as
+// "Item 3 of 7".
+func FormatScreenReader(v interface{}) string {
+	var lines []string
+	speakValue(&lines, "", genericValue(v), false)
+	return strings.Join(lines, "\n")
+}
+
+// FormatScreenReaderError renders an error response as sentences.
+func FormatScreenReaderError(errorMsg string, details string) string {
+	line := "Error. " + sentence(errorMsg)
+	if details != "" {
+		line += " " + sentence(details)
+	}
+	return line
+}
+
+// genericValue converts v to the maps, slices, and scalars of its JSON form,
+// so structs and maps are spoken with the same field names as JSON output.
+func genericValue(v interface{}) interface{} {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	var generic interface{}
+	if err := json.Unmarshal(data, &generic); err != nil {
+		return fmt.Sprint(v)
+	}
+	return generic
+}
+
+// speakValue appends the lines for v, introduced by label when it is set.
+// inList is true for entries of a list, which are spoken without their notes.
+func speakValue(lines *[]string, label string, v interface{}, inList bool) {
+	switch value := v.(type) {
+	case map[string]interface{}:
+		if isItem(value) {
+			*lines = append(*lines, joinLabel(label, itemSentence(value, inList)))
+			return
+		}
+		if label != "" {
+			*lines = append(*lines, sentence(label))
+		}
+		speakFields(lines, value)
+	case []interface{}:
+		speakList(lines, label, value)
+	default:
+		if label == "" {
+			*lines = append(*lines, sentence(spokenScalar(value)))
+			return
+		}
+		*lines = append(*lines, sentence(label+": "+spokenScalar(value)))
+	}
+}
+
+// speakFields speaks the simple fields of a map before its lists and nested
+// objects, each group in key order.
+func speakFields(lines *[]string, fields map[string]interface{}) {
+	var simple, nested []string
+	for key, value := range fields {
+		switch value.(type) {
+		case map[string]interface{}, []interface{}:
+			nested = append(nested, key)
+		default:
+			simple = append(simple, key)
+		}
+	}
+	sort.Strings(simple)
+	sort.Strings(nested)
+	for _, key := range append(simple, nested...) {
+		speakValue(lines, spokenKey(key), fields[key], false)
+	}
+}
+
+// speakList announces the length of a list and then each entry in turn.
+func speakList(lines *[]string, label string, list []interface{}) {
+	if label == "" {
+		label = "Results"
+	}
+	switch len(list) {
+	case 0:
+		*lines = append(*lines, sentence(label+": none"))
+		return
+	case 1:
+		*lines = append(*lines, sentence(label+": 1 item"))
+	default:
+		*lines = append(*lines, sentence(fmt.Sprintf("%s: %d items", label, len(list))))
+	}
+	for i, entry := range list {
+		position := fmt.Sprintf("Item %d of %d", i+1, len(list))
+		switch value := entry.(type) {
+		case map[string]interface{}:
+			if isItem(value) {
+				*lines = append(*lines, joinLabel(position, itemSentence(value, true)))
+				continue
+			}
+			*lines = append(*lines, sentence(position))
+			speakFields(lines, value)
+		case []interface{}:
+			speakList(lines, position, value)
+		default:
+			*lines = append(*lines, sentence(position+": "+spokenScalar(value)))
+		}
+	}
+}
+
+// isItem reports whether a map is a to-do, project, or heading.
+func isItem(fields map[string]interface{}) bool {
+	_, hasID := fields["id"]
+	_, hasTitle := fields["title"]
+	_, hasType := fields["type"]
+	return hasID && hasTitle && hasType
+}
+
+// itemSentence describes a to-do, project, or heading in a few sentences,
+// most important first: title, state, dates, location, tags, and ID.
+func itemSentence(fields map[string]interface{}, inList bool) string {
+	text := func(key string) string {
+		s, _ := fields[key].(string)
+		return s
+	}
+
+	title := text("title")
+	if title == "" {
+		title = "Untitled"
+	}
+	parts := []string{sentence(title)}
+
+	kind := strings.TrimSpace(text("status") + " " + text("type"))
+	if list, ok := whenNames[text("when")]; ok {
+		kind += " in " + list
+	}
+	parts = append(parts, sentence(capitalize(kind)))
+
+	if deadline := text("deadline"); deadline != "" {
+		parts = append(parts, sentence("Due "+spokenScalar(deadline)))
+	}
+	if start := text("start_date"); start != "" && text("when") == "upcoming" {
+		parts = append(parts, sentence("Starts "+spokenScalar(start)))
+	}
+	if completed := text("completed_at"); completed != "" {
+		parts = append(parts, sentence("Finished "+spokenScalar(completed)))
+	}
+	if project := text("project"); project != "" {
+		parts = append(parts, sentence("Project "+project))
+	} else if area := text("area"); area != "" {
+		parts = append(parts, sentence("Area "+area))
+	}
+	if tags, ok := fields["tags"].([]interface{}); ok && len(tags) > 0 {
+		names := make([]string, len(tags))
+		for i, tag := range tags {
+			names[i] = fmt.Sprint(tag)
+		}
+		parts = append(parts, sentence("Tags "+spokenSeries(names)))
+	}
+	if notes := text("notes"); notes != "" {
+		if inList {
+			parts = append(parts, "Has notes.")
+		} else {
+			parts = append(parts, "Notes: "+sentence(strings.Join(strings.Fields(notes), " ")))
+		}
+	}
+	parts = append(parts, sentence("ID "+text("id")))
+	return strings.Join(parts, " ")
+}
+
+// whenNames are the spoken names of the lists an item can be scheduled in
+var whenNames = map[string]string{
+	"inbox":    "Inbox",
+	"today":    "Today",
+	"evening":  "This Evening",
+	"upcoming": "Upcoming",
+	"anytime":  "Anytime",
+	"someday":  "Someday",
+}
+
+// spokenScalar renders a JSON scalar in words: yes or no for booleans, whole
+// numbers without decimals, and dates spelled out.
+func spokenScalar(v interface{}) string {
+	switch value := v.(type) {
+	case nil:
+		return "none"
+	case bool:
+		if value {
+			return "yes"
+		}
+		return "no"
+	case float64:
+		return strconv.FormatFloat(value, 'f', -1, 64)
+	case string:
+		if value == "" {
+			return "none"
+		}
+		if date, err := time.Parse(util.DateLayout, value); err == nil {
+			return date.Format("Monday January 2 2006")
+		}
+		if stamp, err := time.Parse(time.RFC3339, value); err == nil {
+			return stamp.In(util.Location()).Format("Monday January 2 2006 at 3:04 PM")
+		}
+		return value
+	default:
+		return fmt.Sprint(value)
+	}
+}
+
+// spokenKey turns a JSON field name such as "things_ids" into "Things ids".
+func spokenKey(key string) string {
+	return capitalize(strings.NewReplacer("_", " ", "-", " ").Replace(key))
+}
+
+// spokenSeries joins names as "a, b and c".
+func spokenSeries(names []string) string {
+	if len(names) < 2 {
+		return strings.Join(names, "")
+	}
+	return strings.Join(names[:len(names)-1], ", ") + " and " + names[len(names)-1]
+}
+
+// joinLabel prefixes text with a label sentence.
+func joinLabel(label, text string) string {
+	if label == "" {
+		return text
+	}
+	return sentence(label) + " " + text
+}
+
+// sentence ends s with a period unless it already ends a sentence.
+func sentence(s string) string {
+	s = strings.TrimSpace(s)
+	if s == "" || strings.HasSuffix(s, ".") || strings.HasSuffix(s, "?") || strings.HasSuffix(s, "!") {
+		return s
+	}
+	return s + "."
+}
+
+// capitalize upper-cases the first letter of s.
+func capitalize(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToUpper(s[:1]) + s[1:]
+}
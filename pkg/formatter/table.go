@@ -0,0 +1,164 @@
+package formatter
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/yourusername/things3-cli/pkg/util"
+)
+
+// tableColumns are the item fields shown by the table format, in order;
+// --columns replaces them
+var tableColumns = []string{"id", "title", "when", "deadline", "tags"}
+
+// minTitleWidth is the narrowest the title column is squeezed to fit the terminal
+const minTitleWidth = 12
+
+// FormatTable renders a value like FormatPlain, except that lists of to-dos,
+// projects, and headings become aligned columns. When width is positive the
+// title column is shortened so rows fit in that many characters.
+func FormatTable(v interface{}, width int) string {
+	r := &plainRenderer{
+		itemList: func(indent, list string, items []map[string]interface{}) []string {
+			return tableRows(indent, list, items, width)
+		},
+	}
+	return r.render(v)
+}
+
+// tableRows renders items as a header row and one aligned row per item.
+func tableRows(indent, list string, items []map[string]interface{}, width int) []string {
+	rows := make([][]string, 0, len(items)+1)
+	header := make([]string, len(tableColumns))
+	for i, column := range tableColumns {
+		header[i] = strings.ToUpper(column)
+	}
+	rows = append(rows, header)
+	for _, item := range items {
+		row := make([]string, len(tableColumns))
+		for i, column := range tableColumns {
+			row[i] = tableCell(item, column)
+			if column == listColumn {
+				row[i] = list
+			}
+		}
+		rows = append(rows, row)
+	}
+
+	widths := make([]int, len(tableColumns))
+	for _, row := range rows {
+		for i, cell := range row {
+			widths[i] = max(widths[i], utf8.RuneCountInString(cell))
+		}
+	}
+	fitTitle(widths, width-len(indent))
+
+	lines := make([]string, len(rows))
+	for r, row := range rows {
+		rowColor := ""
+		if r > 0 {
+			item := items[r-1]
+			rowColor = itemColor(fieldText(item, "status"), fieldText(item, "deadline"), fieldText(item, "when"))
+		}
+
+		var b strings.Builder
+		b.WriteString(indent)
+		for i, cell := range row {
+			cell = truncate(cell, widths[i])
+			padding := strings.Repeat(" ", widths[i]-utf8.RuneCountInString(cell))
+			if r > 0 {
+				cell = colorize(cellColor(tableColumns[i], rowColor), cell)
+			}
+			if i == len(row)-1 {
+				b.WriteString(cell)
+				break
+			}
+			fmt.Fprintf(&b, "%s%s  ", cell, padding)
+		}
+		lines[r] = strings.TrimRight(b.String(), " ")
+	}
+	return lines
+}
+
+// cellColor returns the color of a table cell: tags are dimmed and the title
+// and deadline take the color of their item.
+func cellColor(column, rowColor string) string {
+	switch column {
+	case "tags":
+		return ansiDim
+	case "title", "deadline":
+		return rowColor
+	}
+	return ""
+}
+
+// fitTitle narrows the title column so a row fits in width characters,
+// leaving the other columns alone.
+func fitTitle(widths []int, width int) {
+	if width <= 0 {
+		return
+	}
+	total := 2 * (len(widths) - 1)
+	title := -1
+	for i, w := range widths {
+		total += w
+		if tableColumns[i] == "title" {
+			title = i
+		}
+	}
+	if title < 0 || total <= width {
+		return
+	}
+	widths[title] = max(minTitleWidth, widths[title]-(total-width))
+}
+
+// tableCell returns the text of one item field for the table format, with
+// dates in the display format and relative to today. The when column shows
+// the start date of upcoming items.
+func tableCell(item map[string]interface{}, column string) string {
+	if column == "when" && item["when"] == "upcoming" {
+		if start, ok := item["start_date"].(string); ok && start != "" {
+			return startText(start)
+		}
+	}
+	switch column {
+	case "deadline":
+		if deadline := fieldText(item, column); deadline != "" {
+			return deadlineText(fieldText(item, "status"), deadline)
+		}
+	case "start_date":
+		return util.DisplayDate(fieldText(item, column))
+	}
+	return fieldText(item, column)
+}
+
+// fieldText returns the text of one item field, with lists such as tags
+// comma-separated.
+func fieldText(item map[string]interface{}, column string) string {
+	switch value := item[column].(type) {
+	case nil:
+		return ""
+	case []interface{}:
+		parts := make([]string, len(value))
+		for i, entry := range value {
+			parts[i] = fmt.Sprint(entry)
+		}
+		return strings.Join(parts, ", ")
+	case string:
+		return value
+	default:
+		return plainScalar(value)
+	}
+}
+
+// truncate shortens s to width characters, ending in an ellipsis.
+func truncate(s string, width int) string {
+	if utf8.RuneCountInString(s) <= width {
+		return s
+	}
+	if width <= 1 {
+		return string([]rune(s)[:width])
+	}
+	return string([]rune(s)[:width-1]) + "…"
+}
@@ -0,0 +1,115 @@
+package formatter
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/yourusername/things3-cli/pkg/util"
+)
+
+// FormatTaskPaper renders the items in a value in TaskPaper syntax for
+// OmniFocus and plain-text workflows. Projects become "Title:" lines, headings
+// nested projects, and to-dos "- " tasks tagged with @due, @defer, @done, or
+// @cancelled dates and their Things tags. To-dos outside a project are listed
+// under the name of their list. Values without items are rendered as plain text.
+func FormatTaskPaper(v interface{}) string {
+	generic := genericValue(v)
+
+	var items []listedItem
+	collectItems(&items, "", generic)
+	if len(items) == 0 {
+		if hasItemList(generic) {
+			return ""
+		}
+		return FormatPlain(v)
+	}
+
+	var b strings.Builder
+	for i, p := range buildOutline(items) {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		tags := ""
+		if p.fields != nil {
+			tags = taskPaperTags(p.fields)
+		}
+		b.WriteString(taskPaperText(p.title) + ":" + tags + "\n")
+		writeTaskPaperNotes(&b, "\t", fieldText(p.fields, "notes"))
+		for _, fields := range p.loose {
+			writeTaskPaperTask(&b, "\t", fields)
+		}
+		for _, heading := range p.headings {
+			b.WriteString("\t" + taskPaperText(heading) + ":\n")
+			for _, fields := range p.byHead[heading] {
+				writeTaskPaperTask(&b, "\t\t", fields)
+			}
+		}
+	}
+	return strings.TrimRight(b.String(), "\n")
+}
+
+// writeTaskPaperTask writes a to-do and its notes at the given indent.
+func writeTaskPaperTask(b *strings.Builder, indent string, fields map[string]interface{}) {
+	b.WriteString(indent + "- " + taskPaperText(fieldText(fields, "title")) + taskPaperTags(fields) + "\n")
+	writeTaskPaperNotes(b, indent+"\t", fieldText(fields, "notes"))
+}
+
+// writeTaskPaperNotes writes note lines, which TaskPaper shows as text below
+// the task or project above them.
+func writeTaskPaperNotes(b *strings.Builder, indent, notes string) {
+	for _, line := range strings.Split(strings.TrimSpace(notes), "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			b.WriteString(indent + line + "\n")
+		}
+	}
+}
+
+// taskPaperTags returns the TaskPaper tags of an item, each with a leading space.
+func taskPaperTags(fields map[string]interface{}) string {
+	var tags []string
+	if deadline := fieldText(fields, "deadline"); deadline != "" {
+		tags = append(tags, "@due("+deadline+")")
+	}
+	if start := fieldText(fields, "start_date"); start != "" && fieldText(fields, "when") == "upcoming" {
+		tags = append(tags, "@defer("+start+")")
+	}
+	switch fieldText(fields, "status") {
+	case "completed":
+		tags = append(tags, "@done"+taskPaperDate(fieldText(fields, "completed_at")))
+	case "canceled":
+		tags = append(tags, "@cancelled"+taskPaperDate(fieldText(fields, "completed_at")))
+	}
+	if list, ok := fields["tags"].([]interface{}); ok {
+		for _, tag := range list {
+			tags = append(tags, "@"+taskPaperTagName(fmt.Sprint(tag)))
+		}
+	}
+	if len(tags) == 0 {
+		return ""
+	}
+	return " " + strings.Join(tags, " ")
+}
+
+// taskPaperDate returns "(YYYY-MM-DD)" for a timestamp in the configured
+// timezone, or "" when there is none.
+func taskPaperDate(timestamp string) string {
+	t, err := time.Parse(time.RFC3339, timestamp)
+	if err != nil {
+		return ""
+	}
+	return "(" + t.In(util.Location()).Format(util.DateLayout) + ")"
+}
+
+// taskPaperTagName makes a Things tag usable as a TaskPaper tag, which
+// cannot contain spaces or parentheses.
+func taskPaperTagName(tag string) string {
+	return strings.NewReplacer(" ", "_", "(", "", ")", "").Replace(tag)
+}
+
+// taskPaperText keeps a title on one line and stops a trailing colon from
+// turning a task into a project.
+func taskPaperText(s string) string {
+	s = strings.Join(strings.Fields(s), " ")
+	return strings.TrimRight(s, ":")
+}
@@ -0,0 +1,90 @@
+package formatter
+
+import (
+	"testing"
+
+	"github.com/yourusername/things3-cli/pkg/util"
+)
+
+func TestTaskPaperTagName(t *testing.T) {
+	tests := []struct {
+		tag  string
+		want string
+	}{
+		{tag: "errands", want: "errands"},
+		{tag: "house stuff", want: "house_stuff"},
+		{tag: "a (b)", want: "a_b"},
+		{tag: "Éducation", want: "Éducation"},
+	}
+	for _, tt := range tests {
+		if got := taskPaperTagName(tt.tag); got != tt.want {
+			t.Errorf("taskPaperTagName(%q) = %q, want %q", tt.tag, got, tt.want)
+		}
+	}
+}
+
+func TestTaskPaperText(t *testing.T) {
+	tests := []struct {
+		text string
+		want string
+	}{
+		{text: "Buy milk", want: "Buy milk"},
+		{text: "Agenda:", want: "Agenda"},
+		{text: "Two\nlines", want: "Two lines"},
+		{text: "  spaced   out  ", want: "spaced out"},
+		{text: "Note: keep", want: "Note: keep"},
+	}
+	for _, tt := range tests {
+		if got := taskPaperText(tt.text); got != tt.want {
+			t.Errorf("taskPaperText(%q) = %q, want %q", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestFormatTaskPaper(t *testing.T) {
+	if err := util.SetTimezone("UTC"); err != nil {
+		t.Fatal(err)
+	}
+	defer util.SetTimezone("")
+
+	tests := []struct {
+		name string
+		item map[string]interface{}
+		want string
+	}{
+		{
+			name: "tags with spaces and parentheses",
+			item: map[string]interface{}{"id": "T1", "type": "to-do", "title": "Fix sink", "tags": []interface{}{"house stuff", "a(b)"}},
+			want: "Today:\n\t- Fix sink @house_stuff @ab",
+		},
+		{
+			name: "dates",
+			item: map[string]interface{}{"id": "T1", "type": "to-do", "title": "File taxes", "deadline": "2024-05-03", "status": "completed", "completed_at": "2024-05-01T23:30:00Z"},
+			want: "Today:\n\t- File taxes @due(2024-05-03) @done(2024-05-01)",
+		},
+		{
+			name: "deferred",
+			item: map[string]interface{}{"id": "T1", "type": "to-do", "title": "Renew", "when": "upcoming", "start_date": "2024-06-01"},
+			want: "Today:\n\t- Renew @defer(2024-06-01)",
+		},
+		{
+			name: "canceled",
+			item: map[string]interface{}{"id": "T1", "type": "to-do", "title": "Old plan", "status": "canceled"},
+			want: "Today:\n\t- Old plan @cancelled",
+		},
+		{
+			name: "title ending in a colon with notes",
+			item: map[string]interface{}{"id": "T1", "type": "to-do", "title": "Agenda:", "notes": "first\n\nsecond"},
+			want: "Today:\n\t- Agenda\n\t\tfirst\n\t\tsecond",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := FormatTaskPaper(map[string]interface{}{"today": []interface{}{tt.item}})
+			if got != tt.want {
+				t.Errorf("FormatTaskPaper = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,103 @@
+package formatter
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"text/template"
+
+	"github.com/yourusername/things3-cli/pkg/things"
+)
+
+// TemplateItem is the context of --template for each to-do, project, or
+// heading in a result: every field of things.Item, such as .ID, .Title,
+// .Status, .When, .StartDate, .Deadline, .Tags, .Project, .Area, .Heading,
+// and .Notes, plus .List, the name of the list the item was found in.
+type TemplateItem struct {
+	things.Item
+	List string
+}
+
+// outputTemplate is the parsed --template used by the template format
+var outputTemplate *template.Template
+
+// templateFuncs are the functions available to output templates
+var templateFuncs = template.FuncMap{
+	"join":  strings.Join,
+	"upper": strings.ToUpper,
+	"lower": strings.ToLower,
+}
+
+// SetTemplate parses the Go template used by the template format. The
+// escapes \t and \n stand for a tab and a newline, so they can be typed in
+// a shell without quoting tricks.
+func SetTemplate(text string) error {
+	text = strings.NewReplacer(`\t`, "\t", `\n`, "\n", `\\`, `\`).Replace(text)
+	tmpl, err := template.New("output").Funcs(templateFuncs).Option("missingkey=zero").Parse(text)
+	if err != nil {
+		return fmt.Errorf("invalid template: %w", err)
+	}
+	outputTemplate = tmpl
+	return nil
+}
+
+// FormatTemplate executes the template once per item in a value, one line
+// each, with a TemplateItem as context. A value without items, such as the
+// result of an update, is passed to the template once in its JSON form, so
+// its fields are reached by their JSON names, as in {{.action}}.
+func FormatTemplate(v interface{}) (string, error) {
+	if outputTemplate == nil {
+		return "", fmt.Errorf("no template given (use --template)")
+	}
+	generic := genericValue(v)
+
+	var items []listedItem
+	collectItems(&items, "", generic)
+	if len(items) == 0 && hasItemList(generic) {
+		return "", nil
+	}
+	if len(items) == 0 {
+		return executeTemplate(generic)
+	}
+
+	lines := make([]string, 0, len(items))
+	for _, entry := range items {
+		context := TemplateItem{List: entry.list}
+		data, err := json.Marshal(entry.fields)
+		if err == nil {
+			err = json.Unmarshal(data, &context.Item)
+		}
+		if err != nil {
+			return "", err
+		}
+		line, err := executeTemplate(context)
+		if err != nil {
+			return "", err
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n"), nil
+}
+
+// executeTemplate runs the output template with one context, dropping a
+// trailing newline so each execution makes exactly one line.
+func executeTemplate(context interface{}) (string, error) {
+	var b strings.Builder
+	if err := outputTemplate.Execute(&b, context); err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(b.String(), "\n"), nil
+}
+
+// printTemplate prints a value with the output template, or an error when
+// the template fails.
+func printTemplate(v interface{}) {
+	out, err := FormatTemplate(v)
+	if err != nil {
+		PrintError("Failed to render template", "FORMAT_ERROR", err.Error())
+		return
+	}
+	if out != "" {
+		fmt.Println(out)
+	}
+}
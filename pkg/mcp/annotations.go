@@ -0,0 +1,26 @@
+package mcp
+
+import gomcp "github.com/modelcontextprotocol/go-sdk/mcp"
+
+// Tool annotations tell clients which tools only read and which can change
+// or close existing items, so they can choose what to confirm with the user.
+// Every tool works on the local Things library only, so none is open-world.
+var (
+	// readOnlyTool reads the library or opens a view without changing data.
+	readOnlyTool = &gomcp.ToolAnnotations{ReadOnlyHint: true, OpenWorldHint: boolPtr(false)}
+
+	// additiveTool creates new items or session state and never changes or
+	// removes existing items.
+	additiveTool = &gomcp.ToolAnnotations{DestructiveHint: boolPtr(false), OpenWorldHint: boolPtr(false)}
+
+	// destructiveTool can overwrite, complete, or cancel existing items.
+	destructiveTool = &gomcp.ToolAnnotations{DestructiveHint: boolPtr(true), OpenWorldHint: boolPtr(false)}
+
+	// closingTool completes or cancels existing items; repeating the call
+	// changes nothing further.
+	closingTool = &gomcp.ToolAnnotations{DestructiveHint: boolPtr(true), IdempotentHint: true, OpenWorldHint: boolPtr(false)}
+)
+
+func boolPtr(b bool) *bool {
+	return &b
+}
@@ -0,0 +1,21 @@
+package mcp
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strings"
+)
+
+// requireBearerToken rejects requests whose Authorization header does not
+// carry token as a bearer token.
+func requireBearerToken(token string, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		scheme, given, _ := strings.Cut(r.Header.Get("Authorization"), " ")
+		if !strings.EqualFold(scheme, "Bearer") || subtle.ConstantTimeCompare([]byte(strings.TrimSpace(given)), []byte(token)) != 1 {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="things"`)
+			http.Error(w, "missing or invalid bearer token", http.StatusUnauthorized)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,147 @@
+package mcp
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	gomcp "github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/yourusername/things3-cli/pkg/things"
+)
+
+// confirmationTTL is how long a confirmation token stays valid.
+const confirmationTTL = 5 * time.Minute
+
+type ConfirmInput struct {
+	Token string `json:"token" jsonschema:"Confirmation token returned by the destructive tool call"`
+}
+
+// pendingAction is a destructive action held back until it is confirmed.
+type pendingAction struct {
+	session *gomcp.ServerSession
+	action  string
+	params  map[string]string
+	opts    things.ExecuteOptions
+	summary string
+	expires time.Time
+}
+
+// confirmationStore holds destructive actions waiting for things_confirm.
+// Tokens are single use and only valid for the session that requested them.
+type confirmationStore struct {
+	mu      sync.Mutex
+	pending map[string]pendingAction
+}
+
+func newConfirmationStore() *confirmationStore {
+	return &confirmationStore{pending: make(map[string]pendingAction)}
+}
+
+// hold stores an action and returns the result asking the model to confirm it.
+func (s *confirmationStore) hold(req *gomcp.CallToolRequest, action string, params map[string]string, opts things.ExecuteOptions, summary string) (*gomcp.CallToolResult, any, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return toolError("generating confirmation token: %v", err), nil, nil
+	}
+	token := hex.EncodeToString(buf)
+	expires := time.Now().Add(confirmationTTL)
+
+	s.mu.Lock()
+	now := time.Now()
+	for key, p := range s.pending {
+		if now.After(p.expires) {
+			delete(s.pending, key)
+		}
+	}
+	s.pending[token] = pendingAction{
+		session: req.Session,
+		action:  action,
+		params:  params,
+		opts:    opts,
+		summary: summary,
+		expires: expires,
+	}
+	s.mu.Unlock()
+
+	return jsonToolResult(ActionOutput{
+		ActionResult:         things.ActionResult{Action: action},
+		ConfirmationRequired: true,
+		Summary:              summary,
+		Token:                token,
+		ExpiresAt:            expires.UTC().Format(time.RFC3339),
+		NextStep:             "Call things_confirm with this token to carry out the action, after checking it with the user.",
+	})
+}
+
+// take removes and returns the pending action for a token.
+func (s *confirmationStore) take(req *gomcp.CallToolRequest, token string) (pendingAction, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	p, ok := s.pending[token]
+	if !ok || p.session != req.Session {
+		return pendingAction{}, fmt.Errorf("unknown or already used confirmation token")
+	}
+	delete(s.pending, token)
+	if time.Now().After(p.expires) {
+		return pendingAction{}, fmt.Errorf("confirmation token expired; repeat the original call")
+	}
+	return p, nil
+}
+
+// destructiveJSONSummary describes a json payload that completes or cancels
+// more than one item, or returns "" when the payload needs no confirmation.
+// Items nested in a project's items count too, so a project update cannot
+// carry a batch of cancellations past the check.
+func destructiveJSONSummary(data string) string {
+	var payload []interface{}
+	if err := json.Unmarshal([]byte(data), &payload); err != nil {
+		return ""
+	}
+
+	completed, canceled := countFinished(payload)
+	if completed+canceled < 2 {
+		return ""
+	}
+	return fmt.Sprintf("Complete %d and cancel %d items in one batch", completed, canceled)
+}
+
+// countFinished counts the entries of a json payload, and of their nested
+// items, that are marked completed or canceled.
+func countFinished(entries []interface{}) (completed, canceled int) {
+	for _, raw := range entries {
+		entry, _ := raw.(map[string]interface{})
+		attrs, _ := entry["attributes"].(map[string]interface{})
+		if v, _ := attrs["canceled"].(bool); v {
+			canceled++
+		} else if v, _ := attrs["completed"].(bool); v {
+			completed++
+		}
+		if items, ok := attrs["items"].([]interface{}); ok {
+			c, x := countFinished(items)
+			completed += c
+			canceled += x
+		}
+	}
+	return completed, canceled
+}
+
+func makeConfirmHandler(client *things.Client, store *preferenceStore, confirmations *confirmationStore) func(context.Context, *gomcp.CallToolRequest, ConfirmInput) (*gomcp.CallToolResult, any, error) {
+	return func(ctx context.Context, req *gomcp.CallToolRequest, input ConfirmInput) (*gomcp.CallToolResult, any, error) {
+		if input.Token == "" {
+			return toolError("token is required"), nil, nil
+		}
+		pending, err := confirmations.take(req, input.Token)
+		if err != nil {
+			return toolError("%v", err), nil, nil
+		}
+		// Redeeming the token is the approval, so the safe-mode threshold
+		// must not refuse the batch again.
+		pending.opts.Confirm = func(int) bool { return true }
+		return executeTool(ctx, req, client, pending.action, pending.params, pending.opts, store.get(req).Verbosity)
+	}
+}
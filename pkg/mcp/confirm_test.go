@@ -0,0 +1,126 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	gomcp "github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/yourusername/things3-cli/pkg/things"
+)
+
+// fakeOpen puts an open command first on PATH that records the URL it is
+// given instead of handing it to Things, and returns the file it writes to.
+func fakeOpen(t *testing.T) string {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("fake open needs a POSIX shell")
+	}
+	dir := t.TempDir()
+	log := filepath.Join(dir, "opened")
+	script := fmt.Sprintf("#!/bin/sh\necho \"$1\" >> %q\n", log)
+	if err := os.WriteFile(filepath.Join(dir, "open"), []byte(script), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("THINGS_DB_PATH", filepath.Join(dir, "missing.sqlite"))
+	return log
+}
+
+func TestConfirmApprovesBatchOverSafeModeThreshold(t *testing.T) {
+	opened := fakeOpen(t)
+
+	var payload []things.JSONOperation
+	for i := 0; i < 12; i++ {
+		payload = append(payload, things.JSONOperation{Type: "to-do", Attributes: map[string]interface{}{"title": fmt.Sprintf("Task %d", i)}})
+	}
+	data, err := things.EncodeJSONPayload(payload)
+	if err != nil {
+		t.Fatal(err)
+	}
+	params := map[string]string{"data": data}
+	opts := things.ExecuteOptions{RequiresAuth: true, UseAuthIfAvailable: true}
+
+	// The client has no callback timeout, so an action that reaches Things
+	// fails waiting for the callback rather than at the safe-mode check.
+	client := &things.Client{AuthToken: "token", SafeModeThreshold: 10}
+	req := &gomcp.CallToolRequest{}
+	ctx := context.Background()
+
+	t.Run("unconfirmed call is refused", func(t *testing.T) {
+		result, _, _ := executeTool(ctx, req, client, "json", copyParams(params), opts, "")
+		if text := resultText(result); !strings.Contains(text, "safe-mode threshold") {
+			t.Fatalf("got %q, want a safe-mode refusal", text)
+		}
+		if _, err := os.Stat(opened); err == nil {
+			t.Fatal("the action was opened without confirmation")
+		}
+	})
+
+	t.Run("confirmed call reaches Things", func(t *testing.T) {
+		confirmations := newConfirmationStore()
+		_, out, _ := confirmations.hold(req, "json", copyParams(params), opts, "Create 12 items")
+		token := out.(ActionOutput).Token
+
+		handler := makeConfirmHandler(client, newPreferenceStore(), confirmations)
+		result, _, _ := handler(ctx, req, ConfirmInput{Token: token})
+		if text := resultText(result); strings.Contains(text, "safe-mode threshold") {
+			t.Fatalf("confirmed batch refused: %q", text)
+		}
+		urls, err := os.ReadFile(opened)
+		if err != nil {
+			t.Fatalf("the confirmed action was not opened: %v", err)
+		}
+		if !strings.HasPrefix(string(urls), "things:///json?") {
+			t.Fatalf("opened %q, want a json action", urls)
+		}
+	})
+}
+
+func copyParams(params map[string]string) map[string]string {
+	copied := make(map[string]string, len(params))
+	for key, value := range params {
+		copied[key] = value
+	}
+	return copied
+}
+
+func resultText(result *gomcp.CallToolResult) string {
+	if result == nil || len(result.Content) == 0 {
+		return ""
+	}
+	if text, ok := result.Content[0].(*gomcp.TextContent); ok {
+		return text.Text
+	}
+	data, _ := json.Marshal(result.Content[0])
+	return string(data)
+}
+
+func TestJSONHoldsNestedDestructivePayload(t *testing.T) {
+	opened := fakeOpen(t)
+
+	data := `[{"type": "project", "operation": "update", "id": "P1", "attributes": {"items": [
+		{"type": "to-do", "attributes": {"title": "One", "canceled": true}},
+		{"type": "heading", "attributes": {"title": "Later"}},
+		{"type": "to-do", "attributes": {"title": "Two", "completed": true}}
+	]}}]`
+
+	handler := makeJSONHandler(&things.Client{AuthToken: "token"}, newPreferenceStore(), newConfirmationStore())
+	_, out, _ := handler(context.Background(), &gomcp.CallToolRequest{}, JSONInput{Data: data})
+	held, ok := out.(ActionOutput)
+	if !ok || !held.ConfirmationRequired || held.Token == "" {
+		t.Fatalf("got %+v, want the payload held for confirmation", out)
+	}
+	if want := "Complete 1 and cancel 1 items in one batch"; held.Summary != want {
+		t.Errorf("summary = %q, want %q", held.Summary, want)
+	}
+	if _, err := os.Stat(opened); err == nil {
+		t.Fatal("the action was opened without confirmation")
+	}
+}
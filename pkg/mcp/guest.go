@@ -0,0 +1,178 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"time"
+
+	gomcp "github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/yourusername/things3-cli/pkg/things"
+	"github.com/yourusername/things3-cli/pkg/util"
+)
+
+// dueSoonDays is the window counted as "due soon" in summaries.
+const dueSoonDays = 7
+
+type SummaryInput struct{}
+
+type ProjectProgressInput struct {
+	Project string `json:"project,omitempty" jsonschema:"Project title, ID, or @alias. Omit to report on every open project."`
+}
+
+// workloadSummary is a count-only overview of open work.
+type workloadSummary struct {
+	Date               string `json:"date"`
+	OpenToDos          int    `json:"open_todos"`
+	OpenProjects       int    `json:"open_projects"`
+	Inbox              int    `json:"inbox"`
+	Today              int    `json:"today"`
+	Evening            int    `json:"evening"`
+	Overdue            int    `json:"overdue"`
+	DueSoon            int    `json:"due_next_7_days"`
+	CompletedLast7Days int    `json:"completed_last_7_days"`
+}
+
+// projectProgress reports a project by counts and dates only.
+type projectProgress struct {
+	Title        string `json:"title"`
+	Area         string `json:"area,omitempty"`
+	Open         int    `json:"open"`
+	Completed    int    `json:"completed"`
+	Canceled     int    `json:"canceled"`
+	PercentDone  int    `json:"percent_done"`
+	Deadline     string `json:"deadline,omitempty"`
+	NextDeadline string `json:"next_deadline,omitempty"`
+	Overdue      int    `json:"overdue"`
+	DueSoon      int    `json:"due_next_7_days"`
+}
+
+func makeSummaryHandler() func(context.Context, *gomcp.CallToolRequest, SummaryInput) (*gomcp.CallToolResult, any, error) {
+	return func(ctx context.Context, req *gomcp.CallToolRequest, input SummaryInput) (*gomcp.CallToolResult, any, error) {
+		db, err := things.OpenDB()
+		if err != nil {
+			return toolError("%v", err), nil, nil
+		}
+		items, err := db.AllItems()
+		if err != nil {
+			return toolError("%v", err), nil, nil
+		}
+
+		now := util.Now()
+		today, soon := summaryWindow(now)
+		weekAgo := now.AddDate(0, 0, -dueSoonDays)
+		summary := workloadSummary{Date: today}
+		for _, item := range items {
+			if item.Status == "completed" {
+				if completed, err := time.Parse(time.RFC3339, item.CompletedAt); err == nil && completed.After(weekAgo) {
+					summary.CompletedLast7Days++
+				}
+				continue
+			}
+			if item.Status != "open" {
+				continue
+			}
+
+			if item.Type == "project" {
+				summary.OpenProjects++
+			} else {
+				summary.OpenToDos++
+				switch item.When {
+				case "inbox":
+					summary.Inbox++
+				case "today":
+					summary.Today++
+				case "evening":
+					summary.Evening++
+				}
+			}
+			switch {
+			case item.Deadline == "":
+			case item.Deadline < today:
+				summary.Overdue++
+			case item.Deadline < soon:
+				summary.DueSoon++
+			}
+		}
+
+		return jsonToolResult(summary)
+	}
+}
+
+func makeProjectProgressHandler() func(context.Context, *gomcp.CallToolRequest, ProjectProgressInput) (*gomcp.CallToolResult, any, error) {
+	return func(ctx context.Context, req *gomcp.CallToolRequest, input ProjectProgressInput) (*gomcp.CallToolResult, any, error) {
+		db, err := things.OpenDB()
+		if err != nil {
+			return toolError("%v", err), nil, nil
+		}
+
+		var projects []things.Item
+		if ref := strings.TrimSpace(input.Project); ref != "" {
+			project, err := db.FindProject(ref)
+			if err != nil {
+				return toolError("project %s: %v", ref, err), nil, nil
+			}
+			projects = []things.Item{*project}
+		} else if projects, err = db.OpenProjects(); err != nil {
+			return toolError("%v", err), nil, nil
+		}
+
+		today, soon := summaryWindow(util.Now())
+		report := make([]projectProgress, 0, len(projects))
+		for _, project := range projects {
+			outline, err := db.ProjectOutline(project.ID)
+			if err != nil {
+				return toolError("%v", err), nil, nil
+			}
+
+			progress := projectProgress{Title: project.Title, Area: project.Area, Deadline: project.Deadline}
+			for _, item := range outline {
+				if item.Type != "to-do" {
+					continue
+				}
+				switch item.Status {
+				case "completed":
+					progress.Completed++
+				case "canceled":
+					progress.Canceled++
+				default:
+					progress.Open++
+					if item.Deadline == "" {
+						break
+					}
+					if progress.NextDeadline == "" || item.Deadline < progress.NextDeadline {
+						progress.NextDeadline = item.Deadline
+					}
+					if item.Deadline < today {
+						progress.Overdue++
+					} else if item.Deadline < soon {
+						progress.DueSoon++
+					}
+				}
+			}
+			if total := progress.Open + progress.Completed; total > 0 {
+				progress.PercentDone = progress.Completed * 100 / total
+			}
+			report = append(report, progress)
+		}
+
+		return jsonToolResult(ProjectProgressOutput{Count: len(report), Projects: report})
+	}
+}
+
+// summaryWindow returns today and the first day after the due-soon window as YYYY-MM-DD.
+func summaryWindow(now time.Time) (string, string) {
+	return now.Format(util.DateLayout), now.AddDate(0, 0, dueSoonDays).Format(util.DateLayout)
+}
+
+// jsonToolResult returns v as indented JSON text and as the structured
+// result checked against the tool's output schema
+func jsonToolResult(v any) (*gomcp.CallToolResult, any, error) {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return toolError("marshaling result: %v", err), nil, nil
+	}
+	return &gomcp.CallToolResult{
+		Content: []gomcp.Content{&gomcp.TextContent{Text: string(data)}},
+	}, v, nil
+}
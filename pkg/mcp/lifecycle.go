@@ -0,0 +1,119 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+
+	gomcp "github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// shutdownTimeout bounds how long a stopping server waits for tool calls in
+// flight, which can be waiting on a Things callback.
+const shutdownTimeout = 30 * time.Second
+
+// callTracker counts the tool calls in flight so shutdown can wait for them,
+// and refuses new ones once shutdown has begun.
+type callTracker struct {
+	mu       sync.Mutex
+	calls    sync.WaitGroup
+	inFlight int
+	closing  bool
+}
+
+// middleware wraps the server's method handler to track tools/call requests.
+func (t *callTracker) middleware(next gomcp.MethodHandler) gomcp.MethodHandler {
+	return func(ctx context.Context, method string, req gomcp.Request) (gomcp.Result, error) {
+		if method != "tools/call" {
+			return next(ctx, method, req)
+		}
+		t.mu.Lock()
+		if t.closing {
+			t.mu.Unlock()
+			return nil, fmt.Errorf("server is shutting down")
+		}
+		t.calls.Add(1)
+		t.inFlight++
+		t.mu.Unlock()
+
+		defer func() {
+			t.mu.Lock()
+			t.inFlight--
+			t.mu.Unlock()
+			t.calls.Done()
+		}()
+		return next(ctx, method, req)
+	}
+}
+
+// drain stops new tool calls and waits for those in flight to finish or for
+// ctx to end, returning how many were still running.
+func (t *callTracker) drain(ctx context.Context) int {
+	t.mu.Lock()
+	t.closing = true
+	t.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		t.calls.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return 0
+	case <-ctx.Done():
+		t.mu.Lock()
+		defer t.mu.Unlock()
+		return t.inFlight
+	}
+}
+
+func (t *callTracker) status() (closing bool, inFlight int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.closing, t.inFlight
+}
+
+// healthHandler serves /healthz: 200 while the server accepts tool calls and
+// 503 once it is shutting down. It needs no auth token so load balancers and
+// process supervisors can probe it.
+func healthHandler(tracker *callTracker) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		closing, inFlight := tracker.status()
+		status, code := "ok", http.StatusOK
+		if closing {
+			status, code = "shutting_down", http.StatusServiceUnavailable
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", "no-store")
+		w.WriteHeader(code)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"status":     status,
+			"tool_calls": inFlight,
+		})
+	})
+}
+
+// shutdown drains tool calls in flight, closes the client sessions so their
+// event streams end, and stops the HTTP server, all within shutdownTimeout.
+func shutdown(httpServer *http.Server, server *gomcp.Server, tracker *callTracker) error {
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+
+	if running := tracker.drain(ctx); running > 0 {
+		log.Printf("Warning: stopping with %d tool calls still running after %s", running, shutdownTimeout)
+	}
+	for session := range server.Sessions() {
+		session.Close()
+	}
+	if err := httpServer.Shutdown(ctx); err != nil {
+		httpServer.Close()
+		return fmt.Errorf("shutting down MCP server: %w", err)
+	}
+	log.Printf("MCP server stopped")
+	return nil
+}
@@ -0,0 +1,131 @@
+package mcp
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	gomcp "github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/yourusername/things3-cli/pkg/things"
+)
+
+type MoveInput struct {
+	ID      string `json:"id" jsonschema:"ID or @alias of the to-do or project to move"`
+	List    string `json:"list,omitempty" jsonschema:"Built-in list to move to: today, evening, anytime, or someday"`
+	Project string `json:"project,omitempty" jsonschema:"Destination project for a to-do, by ID, @alias, or title"`
+	Heading string `json:"heading,omitempty" jsonschema:"Destination heading, by ID or title, within the destination project or the to-do's current project"`
+	Area    string `json:"area,omitempty" jsonschema:"Destination area, by ID or title"`
+}
+
+// moveLists are the built-in lists an item can be moved to, which Things
+// treats as schedules.
+var moveLists = []string{"today", "evening", "anytime", "someday"}
+
+// makeMoveHandler moves a to-do to another project, heading, area, or
+// built-in list, or a project to another area or list. Like things move, each
+// destination is looked up in the database first so a typo fails here
+// instead of reaching Things.
+func makeMoveHandler(client *things.Client, store *preferenceStore) func(context.Context, *gomcp.CallToolRequest, MoveInput) (*gomcp.CallToolResult, any, error) {
+	return func(ctx context.Context, req *gomcp.CallToolRequest, input MoveInput) (*gomcp.CallToolResult, any, error) {
+		ref := strings.TrimSpace(input.ID)
+		if ref == "" {
+			return toolError("id is required"), nil, nil
+		}
+		list := strings.ToLower(strings.TrimSpace(input.List))
+		if list == "" && input.Project == "" && input.Heading == "" && input.Area == "" {
+			return toolError("give list, project, heading, or area"), nil, nil
+		}
+		if list == "inbox" {
+			return toolError("Things cannot move items back to the Inbox"), nil, nil
+		}
+		if list != "" && !containsList(list) {
+			return toolError("list must be one of %s, not %q", strings.Join(moveLists, ", "), input.List), nil, nil
+		}
+		if input.Project != "" && input.Area != "" {
+			return toolError("give project or area, not both"), nil, nil
+		}
+
+		db, err := things.OpenDB()
+		if err != nil {
+			return toolError("%v", err), nil, nil
+		}
+		item, err := db.FindItem(ref)
+		if err != nil {
+			return lookupError("to-do or project", ref, err), nil, nil
+		}
+
+		params := map[string]string{"id": item.ID}
+		setIfNonEmpty(params, "when", list)
+		opts := things.ExecuteOptions{RequiresAuth: true, UseAuthIfAvailable: true}
+		verbosity := store.get(req).Verbosity
+
+		switch item.Type {
+		case "project":
+			if input.Heading != "" {
+				return toolError("projects cannot be moved under a heading"), nil, nil
+			}
+			if input.Project != "" {
+				return toolError("projects cannot be moved into a project; give area"), nil, nil
+			}
+			if input.Area != "" {
+				area, err := db.FindArea(input.Area)
+				if err != nil {
+					return lookupError("area", input.Area, err), nil, nil
+				}
+				params["area-id"] = area.ID
+			}
+			return executeTool(ctx, req, client, "update-project", params, opts, verbosity)
+		case "to-do":
+		default:
+			return toolError("%q is a %s; only to-dos and projects can be moved", ref, item.Type), nil, nil
+		}
+
+		projectID := item.ProjectID
+		switch {
+		case input.Area != "":
+			area, err := db.FindArea(input.Area)
+			if err != nil {
+				return lookupError("area", input.Area, err), nil, nil
+			}
+			params["list-id"] = area.ID
+			projectID = ""
+		case input.Project != "":
+			project, err := db.FindProject(input.Project)
+			if err != nil {
+				return lookupError("open project", input.Project, err), nil, nil
+			}
+			params["list-id"] = project.ID
+			projectID = project.ID
+		}
+
+		if input.Heading != "" {
+			if projectID == "" {
+				return toolError("heading needs a project: give project, or move a to-do that is already in one"), nil, nil
+			}
+			heading, err := db.FindHeading(projectID, input.Heading)
+			if err != nil {
+				return lookupError("heading", input.Heading, err), nil, nil
+			}
+			params["heading-id"] = heading.ID
+		}
+		return executeTool(ctx, req, client, "update", params, opts, verbosity)
+	}
+}
+
+func containsList(list string) bool {
+	for _, name := range moveLists {
+		if name == list {
+			return true
+		}
+	}
+	return false
+}
+
+// lookupError reports a failed database lookup, telling a missing row apart
+// from a query failure.
+func lookupError(kind, ref string, err error) *gomcp.CallToolResult {
+	if errors.Is(err, things.ErrNotFound) {
+		return toolError("no %s %q", kind, ref)
+	}
+	return toolError("looking up %s %q: %v", kind, ref, err)
+}
@@ -0,0 +1,58 @@
+package mcp
+
+import (
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/yourusername/things3-cli/pkg/things"
+)
+
+// ActionOutput is the structured result of the tools that run a Things
+// action. A destructive call held back for things_confirm reports the pending
+// action with the confirmation fields set instead of a result.
+type ActionOutput struct {
+	things.ActionResult
+	Params               map[string]string `json:"params,omitempty" jsonschema:"Parameters sent to Things, with verbosity verbose (auth token left out)"`
+	ConfirmationRequired bool              `json:"confirmation_required,omitempty" jsonschema:"True when the action was held back until things_confirm is called"`
+	Summary              string            `json:"summary,omitempty" jsonschema:"What the held action would do"`
+	Token                string            `json:"token,omitempty" jsonschema:"Token to pass to things_confirm"`
+	ExpiresAt            string            `json:"expires_at,omitempty" jsonschema:"When the token expires (RFC 3339)"`
+	NextStep             string            `json:"next_step,omitempty"`
+}
+
+// ProjectProgressOutput is the structured result of things_project_progress.
+type ProjectProgressOutput struct {
+	Count    int               `json:"count"`
+	Projects []projectProgress `json:"projects"`
+}
+
+// ItemOutput is the structured result of things_get_item: the full record of
+// an item with the checklist of a to-do or the contents of a project.
+type ItemOutput struct {
+	things.Item
+	Checklist []things.ChecklistItem `json:"checklist,omitempty"`
+	Items     []things.Item          `json:"items,omitempty" jsonschema:"Headings and to-dos of a project, including finished ones, in app order"`
+}
+
+// TagsOutput is the structured result of things_list_tags.
+type TagsOutput struct {
+	Count int               `json:"count"`
+	Tags  []things.TagUsage `json:"tags"`
+}
+
+// BuildJSONOutput is the structured result of things_build_json.
+type BuildJSONOutput struct {
+	Valid    bool                    `json:"valid"`
+	Data     string                  `json:"data" jsonschema:"The payload with date phrases resolved, to pass to things_json"`
+	Problems []things.PayloadProblem `json:"problems,omitempty"`
+	NextStep string                  `json:"next_step,omitempty"`
+}
+
+// outputSchema returns the JSON schema of a tool's structured result. Tools
+// declare it so clients can rely on the shape of structuredContent; error
+// results carry no structured content.
+func outputSchema[T any]() *jsonschema.Schema {
+	schema, err := jsonschema.For[T](nil)
+	if err != nil {
+		panic("mcp: output schema: " + err.Error())
+	}
+	return schema
+}
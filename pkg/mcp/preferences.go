@@ -0,0 +1,153 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	gomcp "github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/yourusername/things3-cli/pkg/util"
+)
+
+// Preferences are per-session defaults applied to subsequent tool calls.
+type Preferences struct {
+	DefaultArea string `json:"default_area,omitempty" jsonschema:"Area for new to-dos and projects when none is given"`
+	DefaultTags string `json:"default_tags,omitempty" jsonschema:"Comma-separated tags added to every new to-do and project"`
+	Timezone    string `json:"timezone,omitempty" jsonschema:"IANA timezone used to resolve relative dates, e.g. Europe/Berlin"`
+	Verbosity   string `json:"verbosity,omitempty" jsonschema:"Result detail: concise, normal (default), or verbose"`
+}
+
+type SetPreferencesInput struct {
+	Preferences
+	Reset bool `json:"reset,omitempty" jsonschema:"Clear all preferences before applying the given values"`
+}
+
+// preferenceStore keeps Preferences for each connected session.
+type preferenceStore struct {
+	mu        sync.Mutex
+	server    *gomcp.Server
+	bySession map[*gomcp.ServerSession]Preferences
+}
+
+func newPreferenceStore() *preferenceStore {
+	return &preferenceStore{bySession: make(map[*gomcp.ServerSession]Preferences)}
+}
+
+// get returns the preferences of the session making a request.
+func (s *preferenceStore) get(req *gomcp.CallToolRequest) Preferences {
+	if req == nil || req.Session == nil {
+		return Preferences{}
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.bySession[req.Session]
+}
+
+// set stores preferences for a session and forgets sessions that have disconnected.
+func (s *preferenceStore) set(session *gomcp.ServerSession, prefs Preferences) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.bySession[session] = prefs
+
+	if s.server == nil {
+		return
+	}
+	active := make(map[*gomcp.ServerSession]bool)
+	for ss := range s.server.Sessions() {
+		active[ss] = true
+	}
+	for ss := range s.bySession {
+		if !active[ss] {
+			delete(s.bySession, ss)
+		}
+	}
+}
+
+// applyCreateDefaults fills in the default area and merges default tags for new items.
+// listKey is the parameter that places the item ("list" for to-dos, "area" for projects).
+func (p Preferences) applyCreateDefaults(params map[string]string, listKey string) {
+	if p.DefaultArea != "" && params["list"] == "" && params["list-id"] == "" && params["area"] == "" && params["area-id"] == "" {
+		params[listKey] = p.DefaultArea
+	}
+	if p.DefaultTags != "" {
+		tags := append(util.ParseTags(params["tags"]), util.ParseTags(p.DefaultTags)...)
+		params["tags"] = util.JoinTags(util.DedupeTags(tags))
+	}
+}
+
+// now returns the current time in the session's timezone, or in the
+// configured one when the session has none.
+func (p Preferences) now() time.Time {
+	if p.Timezone != "" {
+		if loc, err := time.LoadLocation(p.Timezone); err == nil {
+			return time.Now().In(loc)
+		}
+	}
+	return util.Now()
+}
+
+// resolveDates expands relative when/deadline values in the session's timezone.
+// Without a session timezone the client resolves them using the configured one.
+func (p Preferences) resolveDates(params map[string]string) {
+	if p.Timezone == "" {
+		return
+	}
+	now := p.now()
+	if when, ok := params["when"]; ok {
+		params["when"] = util.ResolveWhen(when, now)
+	}
+	if deadline := params["deadline"]; deadline != "" {
+		params["deadline"] = util.ResolveDate(deadline, now)
+	}
+}
+
+// validate checks preference values before they are stored.
+func (p Preferences) validate() error {
+	if p.Timezone != "" {
+		if _, err := time.LoadLocation(p.Timezone); err != nil {
+			return fmt.Errorf("unknown timezone %q", p.Timezone)
+		}
+	}
+	switch p.Verbosity {
+	case "", "concise", "normal", "verbose":
+		return nil
+	default:
+		return fmt.Errorf("verbosity must be concise, normal, or verbose")
+	}
+}
+
+func makeSetPreferencesHandler(store *preferenceStore) func(context.Context, *gomcp.CallToolRequest, SetPreferencesInput) (*gomcp.CallToolResult, any, error) {
+	return func(ctx context.Context, req *gomcp.CallToolRequest, input SetPreferencesInput) (*gomcp.CallToolResult, any, error) {
+		if req.Session == nil {
+			return toolError("preferences require a session"), nil, nil
+		}
+
+		input.Verbosity = strings.ToLower(strings.TrimSpace(input.Verbosity))
+		if err := input.Preferences.validate(); err != nil {
+			return toolError("%v", err), nil, nil
+		}
+
+		prefs := store.get(req)
+		if input.Reset {
+			prefs = Preferences{}
+		}
+		if input.DefaultArea != "" {
+			prefs.DefaultArea = input.DefaultArea
+		}
+		if input.DefaultTags != "" {
+			prefs.DefaultTags = input.DefaultTags
+		}
+		if input.Timezone != "" {
+			prefs.Timezone = input.Timezone
+		}
+		if input.Verbosity != "" {
+			prefs.Verbosity = input.Verbosity
+		}
+		store.set(req.Session, prefs)
+
+		return jsonToolResult(prefs)
+	}
+}
@@ -0,0 +1,179 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	gomcp "github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/yourusername/things3-cli/pkg/things"
+	"github.com/yourusername/things3-cli/pkg/util"
+)
+
+// addPrompts registers the planning prompts, which gather the relevant lists
+// from the database into the prompt so the model starts with full context.
+func addPrompts(server *gomcp.Server) {
+	server.AddPrompt(&gomcp.Prompt{
+		Name:        "plan-my-day",
+		Title:       "Plan my day",
+		Description: "Plan today from the Today list, overdue and soon-due items, and the Inbox.",
+	}, planMyDayPrompt)
+
+	server.AddPrompt(&gomcp.Prompt{
+		Name:        "weekly-review",
+		Title:       "Weekly review",
+		Description: "Review the past week and plan the next from the Inbox, recent completions, deadlines, upcoming items, and open projects.",
+	}, weeklyReviewPrompt)
+
+	server.AddPrompt(&gomcp.Prompt{
+		Name:        "break-down-project",
+		Title:       "Break down a project",
+		Description: "Suggest headings and next actions for a project from its current contents.",
+		Arguments: []*gomcp.PromptArgument{{
+			Name:        "project",
+			Description: "Project ID, @alias, or title",
+			Required:    true,
+		}},
+	}, breakDownProjectPrompt)
+}
+
+func planMyDayPrompt(ctx context.Context, req *gomcp.GetPromptRequest) (*gomcp.GetPromptResult, error) {
+	db, err := things.OpenDB()
+	if err != nil {
+		return nil, err
+	}
+	now := util.Now()
+	items, err := db.Today(startOfToday())
+	if err != nil {
+		return nil, err
+	}
+	inbox, err := db.Inbox()
+	if err != nil {
+		return nil, err
+	}
+	open, err := db.OpenItems()
+	if err != nil {
+		return nil, err
+	}
+	overdue, dueSoon := deadlineBuckets(open, now)
+
+	return promptResult("Plan my day", fmt.Sprintf(`Help me plan my day for %s.
+
+Below are my Today list (items with when "evening" are for This Evening), open items that are overdue or due in the next %d days, and my Inbox. Suggest a realistic order for today, flag anything overdue that needs a decision, point out soon-due items that should start today, and suggest where Inbox items belong. Ask before changing anything in Things.`, now.Format(util.DateLayout), dueSoonDays), map[string]interface{}{
+		"today":    items,
+		"overdue":  overdue,
+		"due_soon": dueSoon,
+		"inbox":    inbox,
+	})
+}
+
+func weeklyReviewPrompt(ctx context.Context, req *gomcp.GetPromptRequest) (*gomcp.GetPromptResult, error) {
+	db, err := things.OpenDB()
+	if err != nil {
+		return nil, err
+	}
+	now := util.Now()
+	items, err := db.AllItems()
+	if err != nil {
+		return nil, err
+	}
+
+	_, soon := summaryWindow(now)
+	weekAgo := now.AddDate(0, 0, -dueSoonDays)
+	open, inbox, completed, upcoming, projects := []things.Item{}, []things.Item{}, []things.Item{}, []things.Item{}, []things.Item{}
+	for _, item := range items {
+		switch item.Status {
+		case "completed":
+			if at, err := time.Parse(time.RFC3339, item.CompletedAt); err == nil && at.After(weekAgo) {
+				completed = append(completed, item)
+			}
+			continue
+		case "open":
+		default:
+			continue
+		}
+		open = append(open, item)
+		if item.Type == "project" {
+			projects = append(projects, item)
+		}
+		if item.When == "inbox" {
+			inbox = append(inbox, item)
+		}
+		if item.When == "upcoming" && item.StartDate < soon {
+			upcoming = append(upcoming, item)
+		}
+	}
+	overdue, dueSoon := deadlineBuckets(open, now)
+
+	return promptResult("Weekly review", fmt.Sprintf(`Help me do my weekly review for the week of %s.
+
+Below are my Inbox, what I completed in the last %d days, open items that are overdue or due in the next %d days, items scheduled to start in the next %d days, and my open projects. Walk me through clearing the Inbox, celebrate what got done, decide what to do about overdue items, check that every open project has a clear next action, and point out anything that looks stalled. Ask before changing anything in Things.`, now.Format(util.DateLayout), dueSoonDays, dueSoonDays, dueSoonDays), map[string]interface{}{
+		"inbox":         inbox,
+		"completed":     completed,
+		"overdue":       overdue,
+		"due_soon":      dueSoon,
+		"upcoming":      upcoming,
+		"open_projects": projects,
+	})
+}
+
+func breakDownProjectPrompt(ctx context.Context, req *gomcp.GetPromptRequest) (*gomcp.GetPromptResult, error) {
+	ref := strings.TrimSpace(req.Params.Arguments["project"])
+	if ref == "" {
+		return nil, fmt.Errorf("project is required")
+	}
+	db, err := things.OpenDB()
+	if err != nil {
+		return nil, err
+	}
+	project, err := db.FindProject(ref)
+	if err != nil {
+		return nil, fmt.Errorf("project %q: %w", ref, err)
+	}
+	outline, err := db.ProjectOutline(project.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return promptResult("Break down "+project.Title, fmt.Sprintf(`Help me break down the project %q (ID %s).
+
+Below are the project and its current headings and to-dos, including completed ones. Suggest headings for the phases of the work and concrete next actions under each, each small enough to finish in one sitting, without repeating what is already there. Once I agree, add them with things_add using list_id %s and the heading titles.`, project.Title, project.ID, project.ID), map[string]interface{}{
+		"project": project,
+		"items":   outline,
+	})
+}
+
+// deadlineBuckets splits open items into those past their deadline and those
+// due within dueSoonDays.
+func deadlineBuckets(items []things.Item, now time.Time) (overdue, dueSoon []things.Item) {
+	today, soon := summaryWindow(now)
+	overdue, dueSoon = []things.Item{}, []things.Item{}
+	for _, item := range items {
+		switch {
+		case item.Status != "open" || item.Deadline == "":
+		case item.Deadline < today:
+			overdue = append(overdue, item)
+		case item.Deadline < soon:
+			dueSoon = append(dueSoon, item)
+		}
+	}
+	return overdue, dueSoon
+}
+
+// promptResult builds a single user message from the instructions followed
+// by the data as JSON.
+func promptResult(description, instructions string, data interface{}) (*gomcp.GetPromptResult, error) {
+	encoded, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return &gomcp.GetPromptResult{
+		Description: description,
+		Messages: []*gomcp.PromptMessage{{
+			Role:    "user",
+			Content: &gomcp.TextContent{Text: instructions + "\n\n```json\n" + string(encoded) + "\n```"},
+		}},
+	}, nil
+}
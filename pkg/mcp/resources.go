@@ -0,0 +1,153 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+
+	gomcp "github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/yourusername/things3-cli/pkg/things"
+	"github.com/yourusername/things3-cli/pkg/util"
+)
+
+// pinnedResourceURI is the resource listing the working set kept by things pin.
+const pinnedResourceURI = "things://pinned"
+
+// URIs of the list resources, and the template for the contents of one project.
+const (
+	todayResourceURI    = "things://today"
+	inboxResourceURI    = "things://inbox"
+	upcomingResourceURI = "things://upcoming"
+	projectResourceURI  = "things://project/{id}"
+)
+
+// addResources registers the list resources and the project template.
+func addResources(server *gomcp.Server) {
+	server.AddResource(&gomcp.Resource{
+		URI:         pinnedResourceURI,
+		Name:        "pinned",
+		Title:       "Working set",
+		Description: "The to-dos currently in flight, pinned with things pin.",
+		MIMEType:    "application/json",
+	}, readPinnedResource)
+
+	server.AddResource(&gomcp.Resource{
+		URI:         todayResourceURI,
+		Name:        "today",
+		Title:       "Today",
+		Description: "The open to-dos and projects in Today, including This Evening.",
+		MIMEType:    "application/json",
+	}, listResource("today", func(db *things.DB) ([]things.Item, error) { return db.Today(startOfToday()) }))
+
+	server.AddResource(&gomcp.Resource{
+		URI:         inboxResourceURI,
+		Name:        "inbox",
+		Title:       "Inbox",
+		Description: "The open to-dos in the Inbox.",
+		MIMEType:    "application/json",
+	}, listResource("inbox", (*things.DB).Inbox))
+
+	server.AddResource(&gomcp.Resource{
+		URI:         upcomingResourceURI,
+		Name:        "upcoming",
+		Title:       "Upcoming",
+		Description: "The open to-dos and projects scheduled after today, soonest first.",
+		MIMEType:    "application/json",
+	}, listResource("upcoming", func(db *things.DB) ([]things.Item, error) { return db.Upcoming(startOfToday()) }))
+
+	server.AddResourceTemplate(&gomcp.ResourceTemplate{
+		URITemplate: projectResourceURI,
+		Name:        "project",
+		Title:       "Project",
+		Description: "A project with its headings and open and completed to-dos, by project ID.",
+		MIMEType:    "application/json",
+	}, readProjectResource)
+}
+
+// readPinnedResource returns the open items in the working set as JSON.
+func readPinnedResource(ctx context.Context, req *gomcp.ReadResourceRequest) (*gomcp.ReadResourceResult, error) {
+	db, err := things.OpenDB()
+	if err != nil {
+		return nil, err
+	}
+	items, err := db.PinnedItems()
+	if err != nil {
+		return nil, err
+	}
+	return jsonResource(pinnedResourceURI, map[string]interface{}{
+		"count":  len(items),
+		"pinned": items,
+	})
+}
+
+// listResource returns a handler reading one list from the database, with the
+// items under key.
+func listResource(key string, read func(*things.DB) ([]things.Item, error)) gomcp.ResourceHandler {
+	return func(ctx context.Context, req *gomcp.ReadResourceRequest) (*gomcp.ReadResourceResult, error) {
+		db, err := things.OpenDB()
+		if err != nil {
+			return nil, err
+		}
+		items, err := read(db)
+		if err != nil {
+			return nil, err
+		}
+		return jsonResource(req.Params.URI, map[string]interface{}{
+			"count": len(items),
+			key:     items,
+		})
+	}
+}
+
+// readProjectResource returns a project and its outline, including completed
+// and canceled to-dos.
+func readProjectResource(ctx context.Context, req *gomcp.ReadResourceRequest) (*gomcp.ReadResourceResult, error) {
+	uri := req.Params.URI
+	id, ok := strings.CutPrefix(uri, strings.TrimSuffix(projectResourceURI, "{id}"))
+	if !ok || id == "" || strings.Contains(id, "/") {
+		return nil, gomcp.ResourceNotFoundError(uri)
+	}
+	db, err := things.OpenDB()
+	if err != nil {
+		return nil, err
+	}
+	project, err := db.FindItem(id)
+	if errors.Is(err, things.ErrNotFound) || (err == nil && project.Type != "project") {
+		return nil, gomcp.ResourceNotFoundError(uri)
+	}
+	if err != nil {
+		return nil, err
+	}
+	items, err := db.ProjectOutline(project.ID)
+	if err != nil {
+		return nil, err
+	}
+	return jsonResource(uri, map[string]interface{}{
+		"project": project,
+		"count":   len(items),
+		"items":   items,
+	})
+}
+
+// jsonResource returns v as the JSON contents of the resource at uri.
+func jsonResource(uri string, v interface{}) (*gomcp.ReadResourceResult, error) {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return &gomcp.ReadResourceResult{
+		Contents: []*gomcp.ResourceContents{{
+			URI:      uri,
+			MIMEType: "application/json",
+			Text:     string(data),
+		}},
+	}, nil
+}
+
+// startOfToday returns midnight today in the configured timezone.
+func startOfToday() time.Time {
+	now := util.Now()
+	return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+}
@@ -1,73 +1,386 @@
 package mcp
 
 import (
+	"context"
 	"fmt"
 	"log"
+	"net"
 	"net/http"
+	"os"
+	"os/signal"
+	"sort"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
 
 	gomcp "github.com/modelcontextprotocol/go-sdk/mcp"
 	"github.com/yourusername/things3-cli/pkg/things"
+	"github.com/yourusername/things3-cli/pkg/util"
 )
 
-func NewThingsServer() (*gomcp.Server, error) {
+// ServerOptions selects what the MCP server exposes.
+type ServerOptions struct {
+	// Guest registers only the count and progress tools, with no item-level
+	// reads or writes, for assistants shared with other people.
+	Guest bool
+
+	// AuthToken, when set, is required as a bearer token in the
+	// Authorization header of every request to /mcp.
+	AuthToken string
+
+	// TLSCert and TLSKey serve HTTPS with the given PEM files. TLSClientCA
+	// additionally requires client certificates signed by that CA.
+	TLSCert     string
+	TLSKey      string
+	TLSClientCA string
+
+	// Tools, when set, limits the server to the named tools, such as
+	// add,search,show; names may leave off the things_ prefix.
+	Tools []string
+
+	// Host and Port are the address to listen on; an empty Host listens on
+	// every interface. Path is where the MCP endpoint is served, /mcp when
+	// empty.
+	Host string
+	Port int
+	Path string
+}
+
+func NewThingsServer(opts ServerOptions) (*gomcp.Server, error) {
 	client, err := things.NewClient()
 	if err != nil {
 		return nil, fmt.Errorf("failed to create Things client: %w", err)
 	}
+	// Sessions run tools concurrently, so each action listens on its own
+	// free port rather than contending for the configured one.
+	client.CallbackPort = 0
 
+	config, err := util.LoadConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load config: %w", err)
+	}
+
+	guidance := newToolGuidance(config.MCPToolGuidance)
+
+	watcher := newResourceWatcher()
 	server := gomcp.NewServer(
 		&gomcp.Implementation{
 			Name:    "things3",
 			Version: "1.0.0",
 		},
-		nil,
+		&gomcp.ServerOptions{
+			SubscribeHandler:   watcher.subscribe,
+			UnsubscribeHandler: watcher.unsubscribe,
+		},
 	)
+	watcher.server = server
 
 	gomcp.AddTool(server, &gomcp.Tool{
-		Name:        "things_add",
-		Description: "Add a new to-do in Things 3. Supports title, notes, tags, scheduling, checklist items, and more.",
-	}, makeAddHandler(client))
+		Name:         "things_summary",
+		Description:  guidance.describe("things_summary", "Get counts of open work: open to-dos and projects, Inbox, Today, This Evening, overdue, due in the next 7 days, and completed in the last 7 days. Returns numbers only, no item details."),
+		OutputSchema: outputSchema[workloadSummary](),
+		Annotations:  readOnlyTool,
+	}, makeSummaryHandler())
 
 	gomcp.AddTool(server, &gomcp.Tool{
-		Name:        "things_add_project",
-		Description: "Add a new project in Things 3. Supports title, notes, tags, area, and initial to-dos.",
-	}, makeAddProjectHandler(client))
+		Name:         "things_project_progress",
+		Description:  guidance.describe("things_project_progress", "Get progress for one project or all open projects: open, completed, and canceled to-do counts, percent done, the project deadline, the next to-do deadline, and how many to-dos are overdue or due in the next 7 days. Returns project titles and numbers only, no to-do details."),
+		OutputSchema: outputSchema[ProjectProgressOutput](),
+		Annotations:  readOnlyTool,
+	}, makeProjectProgressHandler())
+
+	if opts.Guest {
+		if err := restrictTools(server, guidance.described, opts.Tools); err != nil {
+			return nil, err
+		}
+		return server, nil
+	}
+
+	prefs := newPreferenceStore()
+	prefs.server = server
+	confirmations := newConfirmationStore()
+
+	gomcp.AddTool(server, &gomcp.Tool{
+		Name:         "things_add",
+		Description:  guidance.describe("things_add", "Add a new to-do in Things 3. Supports title, notes, tags, scheduling, checklist items, and more."),
+		OutputSchema: outputSchema[ActionOutput](),
+		Annotations:  additiveTool,
+	}, makeAddHandler(client, prefs))
+
+	gomcp.AddTool(server, &gomcp.Tool{
+		Name:         "things_add_project",
+		Description:  guidance.describe("things_add_project", "Add a new project in Things 3. Supports title, notes, tags, area, and initial to-dos."),
+		OutputSchema: outputSchema[ActionOutput](),
+		Annotations:  additiveTool,
+	}, makeAddProjectHandler(client, prefs))
+
+	gomcp.AddTool(server, &gomcp.Tool{
+		Name:         "things_update",
+		Description:  guidance.describe("things_update", "Update an existing to-do in Things 3 by ID. Requires an auth token to be configured."),
+		OutputSchema: outputSchema[ActionOutput](),
+		Annotations:  destructiveTool,
+	}, makeUpdateHandler(client, prefs))
+
+	gomcp.AddTool(server, &gomcp.Tool{
+		Name:         "things_update_project",
+		Description:  guidance.describe("things_update_project", "Update an existing project in Things 3 by ID. Requires an auth token to be configured. Canceling a project returns a confirmation token for things_confirm instead of acting."),
+		OutputSchema: outputSchema[ActionOutput](),
+		Annotations:  destructiveTool,
+	}, makeUpdateProjectHandler(client, prefs, confirmations))
 
 	gomcp.AddTool(server, &gomcp.Tool{
-		Name:        "things_update",
-		Description: "Update an existing to-do in Things 3 by ID. Requires an auth token to be configured.",
-	}, makeUpdateHandler(client))
+		Name:         "things_move",
+		Description:  guidance.describe("things_move", "Move a to-do to another project, heading, area, or built-in list (today, evening, anytime, someday), or a project to another area or list. Destinations are checked against the local database before anything is sent. Requires an auth token to be configured."),
+		OutputSchema: outputSchema[ActionOutput](),
+		Annotations:  destructiveTool,
+	}, makeMoveHandler(client, prefs))
 
 	gomcp.AddTool(server, &gomcp.Tool{
-		Name:        "things_update_project",
-		Description: "Update an existing project in Things 3 by ID. Requires an auth token to be configured.",
-	}, makeUpdateProjectHandler(client))
+		Name:         "things_complete",
+		Description:  guidance.describe("things_complete", "Complete one or more to-dos or projects by ID. Requires an auth token to be configured. Completing more than one item returns a confirmation token for things_confirm instead of acting."),
+		OutputSchema: outputSchema[ActionOutput](),
+		Annotations:  closingTool,
+	}, makeCompleteHandler(client, prefs, confirmations))
 
 	gomcp.AddTool(server, &gomcp.Tool{
-		Name:        "things_show",
-		Description: "Show a list or specific item in Things 3. Use query for lists (Inbox, Today, Upcoming, Anytime, Someday, Logbook) or id for a specific item.",
-	}, makeShowHandler(client))
+		Name:         "things_cancel",
+		Description:  guidance.describe("things_cancel", "Cancel one or more to-dos or projects by ID, optionally recording a reason in their notes. Requires an auth token to be configured. Canceling a project or more than one item returns a confirmation token for things_confirm instead of acting."),
+		OutputSchema: outputSchema[ActionOutput](),
+		Annotations:  closingTool,
+	}, makeCancelHandler(client, prefs, confirmations))
 
 	gomcp.AddTool(server, &gomcp.Tool{
-		Name:        "things_search",
-		Description: "Search for items in Things 3 using a text query.",
-	}, makeSearchHandler(client))
+		Name:         "things_delete",
+		Description:  guidance.describe("things_delete", "Move a to-do or project to the Trash by ID. Requires confirm: true, which should only be set after the user agrees; the item can be restored from the Trash in Things. Projects go to the Trash with their to-dos."),
+		OutputSchema: outputSchema[ActionOutput](),
+		Annotations:  destructiveTool,
+	}, makeDeleteHandler())
 
 	gomcp.AddTool(server, &gomcp.Tool{
-		Name:        "things_json",
-		Description: "Send a JSON payload to Things 3 for batch creation or updates. See Things URL scheme docs for payload format.",
-	}, makeJSONHandler(client))
+		Name:         "things_show",
+		Description:  guidance.describe("things_show", "Show a list or specific item in Things 3. Use query for lists (Inbox, Today, Upcoming, Anytime, Someday, Logbook) or id for a specific item."),
+		OutputSchema: outputSchema[ActionOutput](),
+		Annotations:  readOnlyTool,
+	}, makeShowHandler(client, prefs))
 
 	gomcp.AddTool(server, &gomcp.Tool{
-		Name:        "things_version",
-		Description: "Get the Things URL scheme version and client version.",
-	}, makeVersionHandler(client))
+		Name:         "things_search",
+		Description:  guidance.describe("things_search", "Search to-dos and projects in the local database, including the Logbook. Words match titles and notes; add status:open to skip finished items, or status:logged or completed:2024-* for the Logbook only. Returns items paged like things_list: pass next_cursor back as cursor, or offset, to read further."),
+		OutputSchema: outputSchema[ShapedResult[things.Item]](),
+		Annotations:  readOnlyTool,
+	}, makeSearchHandler())
+
+	gomcp.AddTool(server, &gomcp.Tool{
+		Name:         "things_semantic_search",
+		Description:  guidance.describe("things_semantic_search", "Find open to-dos and projects by meaning rather than exact words, e.g. \"things I promised the landlord\". Uses embeddings from a local Ollama server. Results are ranked by score and paged like things_list."),
+		OutputSchema: outputSchema[ShapedResult[things.ScoredItem]](),
+		Annotations:  readOnlyTool,
+	}, makeSemanticSearchHandler())
+
+	gomcp.AddTool(server, &gomcp.Tool{
+		Name:         "things_list",
+		Description:  guidance.describe("things_list", "List the to-dos in a built-in Things 3 list (inbox, today, evening) from the local database. Results are paged in the database: pass max_items/max_chars to limit size, and the returned next_cursor or an offset to fetch more."),
+		OutputSchema: outputSchema[ShapedResult[things.Item]](),
+		Annotations:  readOnlyTool,
+	}, makeListHandler())
+
+	gomcp.AddTool(server, &gomcp.Tool{
+		Name:         "things_get_item",
+		Description:  guidance.describe("things_get_item", "Get the full record of a to-do, project, or heading by ID: notes, tags, status, when, start and deadline dates, created/modified/completed times, and its project, heading, and area. To-dos include their checklist; projects include their headings and to-dos. Read this before updating an item."),
+		OutputSchema: outputSchema[ItemOutput](),
+		Annotations:  readOnlyTool,
+	}, makeGetItemHandler())
+
+	gomcp.AddTool(server, &gomcp.Tool{
+		Name:         "things_list_tags",
+		Description:  guidance.describe("things_list_tags", "List every tag in the order shown in Things, with its parent tag, the number of to-dos and projects that carry it (items), and how many of those are open. Check here before tagging new items so existing tags are reused instead of near-duplicates."),
+		OutputSchema: outputSchema[TagsOutput](),
+		Annotations:  readOnlyTool,
+	}, makeListTagsHandler())
+
+	gomcp.AddTool(server, &gomcp.Tool{
+		Name:         "things_get_today",
+		Description:  guidance.describe("things_get_today", "Get the open to-dos and projects in Today, including This Evening (when is \"evening\"), with notes, tags, dates, and project. Read from the local database and paged like things_list."),
+		OutputSchema: outputSchema[ShapedResult[things.Item]](),
+		Annotations:  readOnlyTool,
+	}, makeListPageHandler((*things.DB).TodayCount, (*things.DB).Today))
+
+	gomcp.AddTool(server, &gomcp.Tool{
+		Name:         "things_get_inbox",
+		Description:  guidance.describe("things_get_inbox", "Get the open to-dos in the Inbox, in their order in Things. Read from the local database and paged like things_list."),
+		OutputSchema: outputSchema[ShapedResult[things.Item]](),
+		Annotations:  readOnlyTool,
+	}, makeListPageHandler(
+		func(db *things.DB, _ time.Time) (int, error) { return db.InboxCount() },
+		func(db *things.DB, _ time.Time) ([]things.Item, error) { return db.Inbox() }))
+
+	gomcp.AddTool(server, &gomcp.Tool{
+		Name:         "things_get_upcoming",
+		Description:  guidance.describe("things_get_upcoming", "Get the open to-dos and projects scheduled after today, soonest first, with their start_date. Read from the local database and paged like things_list."),
+		OutputSchema: outputSchema[ShapedResult[things.Item]](),
+		Annotations:  readOnlyTool,
+	}, makeListPageHandler((*things.DB).UpcomingCount, (*things.DB).Upcoming))
+
+	gomcp.AddTool(server, &gomcp.Tool{
+		Name:         "things_get_logbook",
+		Description:  guidance.describe("things_get_logbook", "Get completed and canceled to-dos and projects, most recently finished first, with completed_at. Read from the local database and paged like things_list; use things_search with completed:2024-* to narrow by date."),
+		OutputSchema: outputSchema[ShapedResult[things.Item]](),
+		Annotations:  readOnlyTool,
+	}, makeListPageHandler(
+		func(db *things.DB, _ time.Time) (int, error) { return db.LogbookCount() },
+		func(db *things.DB, _ time.Time) ([]things.Item, error) { return db.Logbook() }))
+
+	gomcp.AddTool(server, &gomcp.Tool{
+		Name:         "things_json",
+		Description:  guidance.describe("things_json", "Send a JSON payload to Things 3 for batch creation or updates. See Things URL scheme docs for payload format. Payloads that complete or cancel more than one item return a confirmation token for things_confirm instead of acting."),
+		OutputSchema: outputSchema[ActionOutput](),
+		Annotations:  destructiveTool,
+	}, makeJSONHandler(client, prefs, confirmations))
+
+	gomcp.AddTool(server, &gomcp.Tool{
+		Name:         "things_build_json",
+		Description:  guidance.describe("things_build_json", "Check a things_json payload without sending it. Resolves date phrases in when and deadline (such as next monday or +3d) to dates, then checks entry types, operations, attribute names and types, update IDs, and dates. Returns valid, the resolved data to pass to things_json, and each problem with the path to the offending value."),
+		OutputSchema: outputSchema[BuildJSONOutput](),
+		Annotations:  readOnlyTool,
+	}, makeBuildJSONHandler(prefs))
+
+	gomcp.AddTool(server, &gomcp.Tool{
+		Name:         "things_confirm",
+		Description:  guidance.describe("things_confirm", "Carry out a destructive action held back for confirmation. Canceling a project or completing/canceling several items in one things_json call returns a token instead of acting; pass it here after checking with the user. Tokens are single use and expire after 5 minutes."),
+		OutputSchema: outputSchema[ActionOutput](),
+		Annotations:  destructiveTool,
+	}, makeConfirmHandler(client, prefs, confirmations))
+
+	gomcp.AddTool(server, &gomcp.Tool{
+		Name:         "things_version",
+		Description:  guidance.describe("things_version", "Get the Things URL scheme version and client version."),
+		OutputSchema: outputSchema[ActionOutput](),
+		Annotations:  readOnlyTool,
+	}, makeVersionHandler(client, prefs))
+
+	gomcp.AddTool(server, &gomcp.Tool{
+		Name:         "things_set_preferences",
+		Description:  guidance.describe("things_set_preferences", "Set preferences for this session: a default area and default tags for new items, a timezone for relative dates, and result verbosity (concise, normal, verbose). Only the given fields change; pass reset to clear them first. Returns the current preferences."),
+		OutputSchema: outputSchema[Preferences](),
+		Annotations:  additiveTool,
+	}, makeSetPreferencesHandler(prefs))
+
+	addResources(server)
+	addPrompts(server)
+
+	for _, name := range guidance.unknown() {
+		log.Printf("Warning: mcp_tool_guidance references unknown tool %q", name)
+	}
+	if err := restrictTools(server, guidance.described, opts.Tools); err != nil {
+		return nil, err
+	}
 
 	return server, nil
 }
 
-func Serve(port int) error {
-	server, err := NewThingsServer()
+// toolGuidance appends operator guidance from config to tool descriptions, so
+// deployed agents follow house rules without patching the server. It remembers
+// which tools were described so entries for unknown tools can be reported.
+type toolGuidance struct {
+	entries   map[string]string
+	described map[string]bool
+}
+
+func newToolGuidance(entries map[string]string) *toolGuidance {
+	return &toolGuidance{entries: entries, described: make(map[string]bool)}
+}
+
+// describe returns the tool description with any configured guidance appended
+func (g *toolGuidance) describe(name, description string) string {
+	g.described[name] = true
+	extra := strings.TrimSpace(g.entries[name])
+	if extra == "" {
+		return description
+	}
+	return description + "\n\n" + extra
+}
+
+// unknown returns configured tool names that no registered tool matched
+func (g *toolGuidance) unknown() []string {
+	var names []string
+	for name := range g.entries {
+		if !g.described[name] {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// endpointPath returns the path of the MCP endpoint, which must not clash
+// with /healthz.
+func (opts ServerOptions) endpointPath() (string, error) {
+	path := strings.TrimSpace(opts.Path)
+	if path == "" {
+		return "/mcp", nil
+	}
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+	if path == "/healthz" {
+		return "", fmt.Errorf("MCP path cannot be /healthz")
+	}
+	return path, nil
+}
+
+// restrictTools removes every registered tool that the allowlist does not
+// name, so operators can run a read-only or create-only server. An empty
+// allowlist keeps every tool; a name that matches no registered tool is an
+// error rather than a silently wider or narrower server.
+func restrictTools(server *gomcp.Server, registered map[string]bool, allow []string) error {
+	if len(allow) == 0 {
+		return nil
+	}
+	keep := make(map[string]bool, len(allow))
+	for _, name := range allow {
+		name = toolName(name)
+		if name == "" {
+			continue
+		}
+		if !registered[name] {
+			return fmt.Errorf("tool allowlist: unknown or unavailable tool %q", name)
+		}
+		keep[name] = true
+	}
+	if len(keep) == 0 {
+		return fmt.Errorf("tool allowlist names no tools")
+	}
+	var removed []string
+	for name := range registered {
+		if !keep[name] {
+			removed = append(removed, name)
+		}
+	}
+	server.RemoveTools(removed...)
+	return nil
+}
+
+// toolName returns the full name of a tool given with or without its
+// things_ prefix.
+func toolName(name string) string {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "" || strings.HasPrefix(name, "things_") {
+		return name
+	}
+	return "things_" + name
+}
+
+func Serve(opts ServerOptions) error {
+	path, err := opts.endpointPath()
+	if err != nil {
+		return err
+	}
+	tlsConfig, err := opts.tlsConfig()
+	if err != nil {
+		return err
+	}
+	server, err := NewThingsServer(opts)
 	if err != nil {
 		return err
 	}
@@ -76,11 +389,71 @@ func Serve(port int) error {
 		return server
 	}, nil)
 
-	addr := fmt.Sprintf(":%d", port)
-	log.Printf("Things MCP server listening on http://localhost:%d/mcp", port)
+	if opts.Guest {
+		log.Printf("Guest mode: only summary tools are available")
+	}
+	if len(opts.Tools) > 0 {
+		names := make([]string, 0, len(opts.Tools))
+		for _, name := range opts.Tools {
+			if name = toolName(name); name != "" {
+				names = append(names, name)
+			}
+		}
+		log.Printf("Tools limited to %s", strings.Join(names, ", "))
+	}
+	scheme := "http"
+	if tlsConfig != nil {
+		scheme = "https"
+		if tlsConfig.ClientCAs != nil {
+			log.Printf("Requiring client certificates signed by %s", opts.TLSClientCA)
+		}
+	}
+
+	var endpoint http.Handler = handler
+	if opts.AuthToken != "" {
+		endpoint = requireBearerToken(opts.AuthToken, handler)
+	} else if tlsConfig == nil || tlsConfig.ClientCAs == nil {
+		log.Printf("Warning: no auth token set; anything that can reach port %d can read and change Things", opts.Port)
+	}
+
+	tracker := &callTracker{}
+	server.AddReceivingMiddleware(tracker.middleware)
 
 	mux := http.NewServeMux()
-	mux.Handle("/mcp", handler)
+	mux.Handle(path, endpoint)
+	mux.Handle("/healthz", healthHandler(tracker))
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	addr := net.JoinHostPort(opts.Host, strconv.Itoa(opts.Port))
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+	host := opts.Host
+	if host == "" || host == "0.0.0.0" || host == "::" {
+		host = "localhost"
+	}
+	log.Printf("Things MCP server listening on %s://%s%s", scheme, net.JoinHostPort(host, strconv.Itoa(opts.Port)), path)
 
-	return http.ListenAndServe(addr, mux)
+	httpServer := &http.Server{Addr: addr, Handler: mux, TLSConfig: tlsConfig}
+	errs := make(chan error, 1)
+	go func() {
+		if tlsConfig != nil {
+			errs <- httpServer.ServeTLS(listener, "", "")
+		} else {
+			errs <- httpServer.Serve(listener)
+		}
+	}()
+
+	select {
+	case err := <-errs:
+		return err
+	case <-ctx.Done():
+	}
+	// a second signal stops the process without waiting
+	stop()
+	log.Printf("Shutting down; waiting up to %s for tool calls in flight", shutdownTimeout)
+	return shutdown(httpServer, server, tracker)
 }
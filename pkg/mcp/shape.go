@@ -0,0 +1,126 @@
+package mcp
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const (
+	defaultMaxItems = 50
+	defaultMaxChars = 20000
+)
+
+// ReadLimits are the result-shaping parameters accepted by every read tool.
+type ReadLimits struct {
+	MaxItems int    `json:"max_items,omitempty" jsonschema:"Maximum number of items to return (default 50)"`
+	MaxChars int    `json:"max_chars,omitempty" jsonschema:"Maximum size of the returned JSON in characters (default 20000)"`
+	Offset   int    `json:"offset,omitempty" jsonschema:"Number of items to skip before the first one returned; use instead of cursor to jump to a page"`
+	Cursor   string `json:"cursor,omitempty" jsonschema:"Cursor from a previous truncated result to fetch the next page"`
+}
+
+// ShapedResult is a page of items trimmed to fit the caller's limits.
+type ShapedResult[T any] struct {
+	Items      []T    `json:"items"`
+	Count      int    `json:"count"`
+	Total      int    `json:"total"`
+	Truncated  bool   `json:"truncated"`
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+// shapeItems returns the page of items starting at the cursor, limited to
+// MaxItems entries and MaxChars of serialized JSON. At least one item is
+// always returned when any remain, so paging always makes progress.
+func shapeItems[T any](items []T, limits ReadLimits) (ShapedResult[T], error) {
+	offset, err := limits.offset()
+	if err != nil {
+		return ShapedResult[T]{}, err
+	}
+	if offset > len(items) {
+		offset = len(items)
+	}
+	return shapeWindow(items[offset:], offset, len(items), limits)
+}
+
+// shapeWindow shapes the items from offset onwards out of total, such as a
+// page already limited in SQL, the same way as shapeItems.
+func shapeWindow[T any](window []T, offset, total int, limits ReadLimits) (ShapedResult[T], error) {
+	maxChars := limits.MaxChars
+	if maxChars <= 0 {
+		maxChars = defaultMaxChars
+	}
+	if n := limits.maxItems(); len(window) > n {
+		window = window[:n]
+	}
+
+	page := ShapedResult[T]{Items: window, Total: total}
+	for len(page.Items) > 1 {
+		data, err := json.Marshal(page)
+		if err != nil {
+			return ShapedResult[T]{}, err
+		}
+		if len(data) <= maxChars {
+			break
+		}
+		page.Items = page.Items[:len(page.Items)-1]
+	}
+
+	page.Count = len(page.Items)
+	if next := offset + page.Count; next < total {
+		page.Truncated = true
+		page.NextCursor = encodeCursor(next)
+	}
+	if page.Items == nil {
+		page.Items = []T{}
+	}
+	return page, nil
+}
+
+// maxItems returns MaxItems, or the default when it isn't set.
+func (limits ReadLimits) maxItems() int {
+	if limits.MaxItems <= 0 {
+		return defaultMaxItems
+	}
+	return limits.MaxItems
+}
+
+// offset returns the number of items to skip, from the cursor or Offset.
+func (limits ReadLimits) offset() (int, error) {
+	if limits.Cursor != "" && limits.Offset != 0 {
+		return 0, fmt.Errorf("use cursor or offset, not both")
+	}
+	if limits.Offset < 0 {
+		return 0, fmt.Errorf("offset cannot be negative")
+	}
+	if limits.Cursor == "" {
+		return limits.Offset, nil
+	}
+	return decodeCursor(limits.Cursor)
+}
+
+// encodeCursor produces an opaque cursor for the given offset.
+func encodeCursor(offset int) string {
+	return base64.RawURLEncoding.EncodeToString([]byte("offset:" + strconv.Itoa(offset)))
+}
+
+// decodeCursor returns the offset stored in a cursor; an empty cursor is offset zero.
+func decodeCursor(cursor string) (int, error) {
+	if cursor == "" {
+		return 0, nil
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0, fmt.Errorf("invalid cursor")
+	}
+	value, ok := strings.CutPrefix(string(raw), "offset:")
+	if !ok {
+		return 0, fmt.Errorf("invalid cursor")
+	}
+	offset, err := strconv.Atoi(value)
+	if err != nil || offset < 0 {
+		return 0, fmt.Errorf("invalid cursor")
+	}
+	return offset, nil
+}
@@ -0,0 +1,99 @@
+package mcp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	gomcp "github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/yourusername/things3-cli/pkg/things"
+)
+
+type CompleteInput struct {
+	IDs []string `json:"ids" jsonschema:"IDs or @aliases of the to-dos or projects to complete"`
+}
+
+type CancelInput struct {
+	IDs    []string `json:"ids" jsonschema:"IDs or @aliases of the to-dos or projects to cancel"`
+	Reason string   `json:"reason,omitempty" jsonschema:"Why the items are canceled; recorded in their notes"`
+}
+
+func makeCompleteHandler(client *things.Client, store *preferenceStore, confirmations *confirmationStore) func(context.Context, *gomcp.CallToolRequest, CompleteInput) (*gomcp.CallToolResult, any, error) {
+	return func(ctx context.Context, req *gomcp.CallToolRequest, input CompleteInput) (*gomcp.CallToolResult, any, error) {
+		return closeItems(ctx, req, client, store, confirmations, input.IDs, "completed", "")
+	}
+}
+
+func makeCancelHandler(client *things.Client, store *preferenceStore, confirmations *confirmationStore) func(context.Context, *gomcp.CallToolRequest, CancelInput) (*gomcp.CallToolResult, any, error) {
+	return func(ctx context.Context, req *gomcp.CallToolRequest, input CancelInput) (*gomcp.CallToolResult, any, error) {
+		return closeItems(ctx, req, client, store, confirmations, input.IDs, "canceled", input.Reason)
+	}
+}
+
+// closeItems completes or cancels the items with the given IDs in one json
+// action. status is the update attribute to set, "completed" or "canceled".
+// Like things_json, closing more than one item, or canceling a project, is
+// held for things_confirm.
+func closeItems(ctx context.Context, req *gomcp.CallToolRequest, client *things.Client, store *preferenceStore, confirmations *confirmationStore, ids []string, status, reason string) (*gomcp.CallToolResult, any, error) {
+	if len(ids) == 0 {
+		return toolError("ids is required"), nil, nil
+	}
+	db, err := things.OpenDB()
+	if err != nil {
+		return toolError("%v", err), nil, nil
+	}
+
+	var ops []things.JSONOperation
+	var titles []string
+	seen := make(map[string]bool)
+	projects := 0
+	for _, ref := range ids {
+		item, err := db.FindItem(strings.TrimSpace(ref))
+		if errors.Is(err, things.ErrNotFound) {
+			return toolError("no to-do or project with ID %q", ref), nil, nil
+		}
+		if err != nil {
+			return toolError("%v", err), nil, nil
+		}
+		if item.Type != "to-do" && item.Type != "project" {
+			return toolError("%q is a %s; only to-dos and projects can be %s", ref, item.Type, status), nil, nil
+		}
+		if seen[item.ID] {
+			continue
+		}
+		seen[item.ID] = true
+		if item.Type == "project" {
+			projects++
+		}
+
+		attributes := map[string]interface{}{status: true}
+		if reason != "" {
+			params := map[string]string{"id": item.ID}
+			things.AppendCancelReason(params, reason)
+			attributes["append-notes"] = params["append-notes"]
+		}
+		ops = append(ops, things.NewUpdateOperation(item.Type, item.ID, attributes))
+		titles = append(titles, fmt.Sprintf("%q", item.Title))
+	}
+
+	data, err := things.EncodeJSONPayload(ops)
+	if err != nil {
+		return toolError("%v", err), nil, nil
+	}
+	params := map[string]string{"data": data}
+	opts := things.ExecuteOptions{RequiresAuth: true, UseAuthIfAvailable: true}
+
+	verb := "Complete"
+	if status == "canceled" {
+		verb = "Cancel"
+	}
+	if len(ops) > 1 || (status == "canceled" && projects > 0) {
+		summary := fmt.Sprintf("%s %d items: %s", verb, len(ops), strings.Join(titles, ", "))
+		if len(ops) == 1 {
+			summary = fmt.Sprintf("%s the project %s", verb, titles[0])
+		}
+		return confirmations.hold(req, "json", params, opts, summary)
+	}
+	return executeTool(ctx, req, client, "json", params, opts, store.get(req).Verbosity)
+}
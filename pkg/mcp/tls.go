@@ -0,0 +1,46 @@
+package mcp
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+)
+
+// tlsConfig returns the TLS settings for the listener, or nil when the server
+// should speak plain HTTP. With a client CA, clients must present a
+// certificate signed by it.
+func (opts ServerOptions) tlsConfig() (*tls.Config, error) {
+	if opts.TLSCert == "" && opts.TLSKey == "" {
+		if opts.TLSClientCA != "" {
+			return nil, fmt.Errorf("--tls-client-ca requires --tls-cert and --tls-key")
+		}
+		return nil, nil
+	}
+	if opts.TLSCert == "" || opts.TLSKey == "" {
+		return nil, fmt.Errorf("--tls-cert and --tls-key must be given together")
+	}
+
+	cert, err := tls.LoadX509KeyPair(opts.TLSCert, opts.TLSKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load TLS certificate: %w", err)
+	}
+	config := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS12,
+	}
+
+	if opts.TLSClientCA != "" {
+		data, err := os.ReadFile(opts.TLSClientCA)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read client CA: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(data) {
+			return nil, fmt.Errorf("no PEM certificates found in %s", opts.TLSClientCA)
+		}
+		config.ClientCAs = pool
+		config.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+	return config, nil
+}
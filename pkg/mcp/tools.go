@@ -2,33 +2,103 @@ package mcp
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
+	"strings"
+	"time"
 
 	gomcp "github.com/modelcontextprotocol/go-sdk/mcp"
 	"github.com/yourusername/things3-cli/pkg/things"
+	"github.com/yourusername/things3-cli/pkg/util"
 )
 
-func executeTool(client *things.Client, action string, params map[string]string, opts things.ExecuteOptions) (*gomcp.CallToolResult, error) {
+func executeTool(ctx context.Context, req *gomcp.CallToolRequest, client *things.Client, action string, params map[string]string, opts things.ExecuteOptions, verbosity string) (*gomcp.CallToolResult, any, error) {
+	opts.Progress = toolProgress(ctx, req)
 	callback, err := client.Execute(action, params, opts)
 	if err != nil {
-		return &gomcp.CallToolResult{
-			Content: []gomcp.Content{&gomcp.TextContent{Text: fmt.Sprintf("Error: %v", err)}},
-			IsError: true,
-		}, nil
+		return toolError("%v", err), nil, nil
 	}
 
-	result := things.NormalizeResponse(action, callback)
-	data, err := json.MarshalIndent(result, "", "  ")
-	if err != nil {
-		return &gomcp.CallToolResult{
-			Content: []gomcp.Content{&gomcp.TextContent{Text: fmt.Sprintf("Error marshaling result: %v", err)}},
-			IsError: true,
-		}, nil
+	output := ActionOutput{ActionResult: things.NormalizeResponse(action, callback)}
+	switch verbosity {
+	case "concise":
+		full := output.ActionResult
+		output.ActionResult = things.ActionResult{Action: full.Action, ThingsID: full.ThingsID, ThingsIDs: full.ThingsIDs}
+	case "verbose":
+		output.Params = make(map[string]string, len(params))
+		for key, value := range params {
+			if key != "auth-token" {
+				output.Params[key] = value
+			}
+		}
+	}
+	return jsonToolResult(output)
+}
+
+// toolProgress returns a Progress callback that reports the steps of a Things
+// action to the client: as log messages once the client has set a log level,
+// and as progress notifications when the call carries a progress token.
+func toolProgress(ctx context.Context, req *gomcp.CallToolRequest) func(string) {
+	if req == nil || req.Session == nil {
+		return nil
 	}
+	token := req.Params.GetProgressToken()
+	step := 0
+	return func(message string) {
+		req.Session.Log(ctx, &gomcp.LoggingMessageParams{
+			Level:  "info",
+			Logger: "things",
+			Data:   message,
+		})
+		if token != nil {
+			step++
+			req.Session.NotifyProgress(ctx, &gomcp.ProgressNotificationParams{
+				ProgressToken: token,
+				Progress:      float64(step),
+				Message:       message,
+			})
+		}
+	}
+}
+
+// toolError builds an error result shown to the model instead of failing the call
+func toolError(format string, args ...any) *gomcp.CallToolResult {
 	return &gomcp.CallToolResult{
-		Content: []gomcp.Content{&gomcp.TextContent{Text: string(data)}},
-	}, nil
+		Content: []gomcp.Content{&gomcp.TextContent{Text: "Error: " + fmt.Sprintf(format, args...)}},
+		IsError: true,
+	}
+}
+
+// shapedToolResult trims a list of items to the caller's limits and returns it as JSON
+func shapedToolResult[T any](items []T, limits ReadLimits) (*gomcp.CallToolResult, any, error) {
+	page, err := shapeItems(items, limits)
+	if err != nil {
+		return toolError("%v", err), nil, nil
+	}
+	return jsonToolResult(page)
+}
+
+// pagedToolResult reads one page of items from the database, pushing the
+// offset and item limit down to SQL, and returns it like shapedToolResult
+// with the total counted separately.
+func pagedToolResult(db *things.DB, limits ReadLimits, count func(*things.DB) (int, error), read func(*things.DB) ([]things.Item, error)) (*gomcp.CallToolResult, any, error) {
+	offset, err := limits.offset()
+	if err != nil {
+		return toolError("%v", err), nil, nil
+	}
+	total, err := count(db)
+	if err != nil {
+		return toolError("%v", err), nil, nil
+	}
+	items, err := read(db.Paged(things.Page{Limit: limits.maxItems(), Offset: offset}))
+	if err != nil {
+		return toolError("%v", err), nil, nil
+	}
+
+	page, err := shapeWindow(items, min(offset, total), total, limits)
+	if err != nil {
+		return toolError("%v", err), nil, nil
+	}
+	return jsonToolResult(page)
 }
 
 func setIfNonEmpty(params map[string]string, key, value string) {
@@ -41,11 +111,11 @@ type AddInput struct {
 	Title          string `json:"title,omitempty" jsonschema:"To-do title"`
 	Titles         string `json:"titles,omitempty" jsonschema:"Newline-separated list of to-do titles (for batch creation)"`
 	Notes          string `json:"notes,omitempty" jsonschema:"Notes for the to-do"`
-	When           string `json:"when,omitempty" jsonschema:"When to schedule: today, tonight, anytime, someday, or YYYY-MM-DD"`
-	Deadline       string `json:"deadline,omitempty" jsonschema:"Deadline date (YYYY-MM-DD)"`
+	When           string `json:"when,omitempty" jsonschema:"When to schedule: today, evening (or tonight), tomorrow, anytime, someday, YYYY-MM-DD, a phrase like next monday or in 3 days, or an offset like +3d or +1w"`
+	Deadline       string `json:"deadline,omitempty" jsonschema:"Deadline date (YYYY-MM-DD, a phrase like end of month, or an offset like +1w)"`
 	Tags           string `json:"tags,omitempty" jsonschema:"Comma-separated tags"`
 	List           string `json:"list,omitempty" jsonschema:"List name or project title"`
-	ListID         string `json:"list_id,omitempty" jsonschema:"List or project ID"`
+	ListID         string `json:"list_id,omitempty" jsonschema:"List or project ID, or an @alias"`
 	Heading        string `json:"heading,omitempty" jsonschema:"Heading title"`
 	HeadingID      string `json:"heading_id,omitempty" jsonschema:"Heading ID"`
 	ChecklistItems string `json:"checklist_items,omitempty" jsonschema:"Newline-separated checklist items"`
@@ -60,11 +130,11 @@ type AddInput struct {
 type AddProjectInput struct {
 	Title          string `json:"title,omitempty" jsonschema:"Project title"`
 	Notes          string `json:"notes,omitempty" jsonschema:"Project notes"`
-	When           string `json:"when,omitempty" jsonschema:"When to schedule: today, tonight, anytime, someday, or YYYY-MM-DD"`
-	Deadline       string `json:"deadline,omitempty" jsonschema:"Deadline date (YYYY-MM-DD)"`
+	When           string `json:"when,omitempty" jsonschema:"When to schedule: today, evening (or tonight), tomorrow, anytime, someday, YYYY-MM-DD, a phrase like next monday or in 3 days, or an offset like +3d or +1w"`
+	Deadline       string `json:"deadline,omitempty" jsonschema:"Deadline date (YYYY-MM-DD, a phrase like end of month, or an offset like +1w)"`
 	Tags           string `json:"tags,omitempty" jsonschema:"Comma-separated tags"`
 	Area           string `json:"area,omitempty" jsonschema:"Area name"`
-	AreaID         string `json:"area_id,omitempty" jsonschema:"Area ID"`
+	AreaID         string `json:"area_id,omitempty" jsonschema:"Area ID or @alias"`
 	ToDos          string `json:"to_dos,omitempty" jsonschema:"Newline-separated to-do titles for the project"`
 	Completed      bool   `json:"completed,omitempty" jsonschema:"Mark as completed"`
 	Canceled       bool   `json:"canceled,omitempty" jsonschema:"Mark as canceled"`
@@ -74,12 +144,12 @@ type AddProjectInput struct {
 }
 
 type UpdateInput struct {
-	ID                    string `json:"id" jsonschema:"To-do ID (required)"`
+	ID                    string `json:"id" jsonschema:"To-do ID or @alias (required)"`
 	Title                 string `json:"title,omitempty" jsonschema:"Updated title"`
 	Notes                 string `json:"notes,omitempty" jsonschema:"Replace notes"`
 	PrependNotes          string `json:"prepend_notes,omitempty" jsonschema:"Prepend to notes"`
 	AppendNotes           string `json:"append_notes,omitempty" jsonschema:"Append to notes"`
-	When                  string `json:"when,omitempty" jsonschema:"Update schedule"`
+	When                  string `json:"when,omitempty" jsonschema:"Update schedule: today, evening (or tonight), tomorrow, anytime, someday, YYYY-MM-DD, or a phrase"`
 	Deadline              string `json:"deadline,omitempty" jsonschema:"Update deadline"`
 	Tags                  string `json:"tags,omitempty" jsonschema:"Replace tags (comma-separated)"`
 	AddTags               string `json:"add_tags,omitempty" jsonschema:"Add tags (comma-separated)"`
@@ -87,11 +157,12 @@ type UpdateInput struct {
 	PrependChecklistItems string `json:"prepend_checklist_items,omitempty" jsonschema:"Prepend checklist items (newline-separated)"`
 	AppendChecklistItems  string `json:"append_checklist_items,omitempty" jsonschema:"Append checklist items (newline-separated)"`
 	List                  string `json:"list,omitempty" jsonschema:"Move to list by name"`
-	ListID                string `json:"list_id,omitempty" jsonschema:"Move to list by ID"`
+	ListID                string `json:"list_id,omitempty" jsonschema:"Move to list by ID or @alias"`
 	Heading               string `json:"heading,omitempty" jsonschema:"Move to heading by name"`
 	HeadingID             string `json:"heading_id,omitempty" jsonschema:"Move to heading by ID"`
 	Completed             bool   `json:"completed,omitempty" jsonschema:"Mark as completed"`
 	Canceled              bool   `json:"canceled,omitempty" jsonschema:"Mark as canceled"`
+	Reason                string `json:"reason,omitempty" jsonschema:"Why the to-do is canceled; recorded in its notes (requires canceled)"`
 	Reveal                bool   `json:"reveal,omitempty" jsonschema:"Reveal the updated to-do"`
 	Duplicate             bool   `json:"duplicate,omitempty" jsonschema:"Duplicate the to-do"`
 	CreationDate          string `json:"creation_date,omitempty" jsonschema:"Set creation date (ISO 8601)"`
@@ -99,19 +170,20 @@ type UpdateInput struct {
 }
 
 type UpdateProjectInput struct {
-	ID             string `json:"id" jsonschema:"Project ID (required)"`
+	ID             string `json:"id" jsonschema:"Project ID or @alias (required)"`
 	Title          string `json:"title,omitempty" jsonschema:"Updated title"`
 	Notes          string `json:"notes,omitempty" jsonschema:"Replace notes"`
 	PrependNotes   string `json:"prepend_notes,omitempty" jsonschema:"Prepend to notes"`
 	AppendNotes    string `json:"append_notes,omitempty" jsonschema:"Append to notes"`
-	When           string `json:"when,omitempty" jsonschema:"Update schedule"`
+	When           string `json:"when,omitempty" jsonschema:"Update schedule: today, evening (or tonight), tomorrow, anytime, someday, YYYY-MM-DD, or a phrase"`
 	Deadline       string `json:"deadline,omitempty" jsonschema:"Update deadline"`
 	Tags           string `json:"tags,omitempty" jsonschema:"Replace tags (comma-separated)"`
 	AddTags        string `json:"add_tags,omitempty" jsonschema:"Add tags (comma-separated)"`
 	Area           string `json:"area,omitempty" jsonschema:"Move to area by name"`
-	AreaID         string `json:"area_id,omitempty" jsonschema:"Move to area by ID"`
+	AreaID         string `json:"area_id,omitempty" jsonschema:"Move to area by ID or @alias"`
 	Completed      bool   `json:"completed,omitempty" jsonschema:"Mark as completed"`
 	Canceled       bool   `json:"canceled,omitempty" jsonschema:"Mark as canceled"`
+	Reason         string `json:"reason,omitempty" jsonschema:"Why the project is canceled; recorded in its notes (requires canceled)"`
 	Reveal         bool   `json:"reveal,omitempty" jsonschema:"Reveal the updated project"`
 	Duplicate      bool   `json:"duplicate,omitempty" jsonschema:"Duplicate the project"`
 	CreationDate   string `json:"creation_date,omitempty" jsonschema:"Set creation date (ISO 8601)"`
@@ -119,12 +191,18 @@ type UpdateProjectInput struct {
 }
 
 type ShowInput struct {
-	ID    string `json:"id,omitempty" jsonschema:"Item ID to show"`
+	ID    string `json:"id,omitempty" jsonschema:"Item ID or @alias to show"`
 	Query string `json:"query,omitempty" jsonschema:"List query: Inbox, Today, Upcoming, Anytime, Someday, Logbook"`
 }
 
 type SearchInput struct {
-	Query string `json:"query" jsonschema:"Search query"`
+	Query string `json:"query" jsonschema:"Search query. Words match titles and notes; predicates status:open|completed|canceled|logged, completed:2024-* (date glob), project:'Title', when:inbox|today|evening|upcoming|anytime|someday (today includes This Evening), and overdue narrow the results"`
+	ReadLimits
+}
+
+type SemanticSearchInput struct {
+	Query string `json:"query" jsonschema:"What to look for, in natural language"`
+	ReadLimits
 }
 
 type JSONInput struct {
@@ -132,10 +210,31 @@ type JSONInput struct {
 	Reveal bool   `json:"reveal,omitempty" jsonschema:"Reveal created items"`
 }
 
+type BuildJSONInput struct {
+	Data string `json:"data" jsonschema:"JSON array of to-dos and projects to check, in the Things JSON format used by things_json"`
+}
+
 type VersionInput struct{}
 
-func makeAddHandler(client *things.Client) func(context.Context, *gomcp.CallToolRequest, AddInput) (*gomcp.CallToolResult, any, error) {
+// ListPageInput is the input of the read tools for a single list.
+type ListPageInput struct {
+	ReadLimits
+}
+
+type GetItemInput struct {
+	ID string `json:"id" jsonschema:"ID or @alias of the to-do, project, or heading"`
+}
+
+type ListTagsInput struct{}
+
+type ListInput struct {
+	List string `json:"list" jsonschema:"Built-in list to read: inbox, today (including This Evening), or evening"`
+	ReadLimits
+}
+
+func makeAddHandler(client *things.Client, store *preferenceStore) func(context.Context, *gomcp.CallToolRequest, AddInput) (*gomcp.CallToolResult, any, error) {
 	return func(ctx context.Context, req *gomcp.CallToolRequest, input AddInput) (*gomcp.CallToolResult, any, error) {
+		prefs := store.get(req)
 		params := make(map[string]string)
 		if input.Titles != "" {
 			params["titles"] = input.Titles
@@ -165,13 +264,15 @@ func makeAddHandler(client *things.Client) func(context.Context, *gomcp.CallTool
 		if input.Reveal {
 			params["reveal"] = "true"
 		}
-		result, err := executeTool(client, "add", params, things.ExecuteOptions{})
-		return result, nil, err
+		prefs.applyCreateDefaults(params, "list")
+		prefs.resolveDates(params)
+		return executeTool(ctx, req, client, "add", params, things.ExecuteOptions{}, prefs.Verbosity)
 	}
 }
 
-func makeAddProjectHandler(client *things.Client) func(context.Context, *gomcp.CallToolRequest, AddProjectInput) (*gomcp.CallToolResult, any, error) {
+func makeAddProjectHandler(client *things.Client, store *preferenceStore) func(context.Context, *gomcp.CallToolRequest, AddProjectInput) (*gomcp.CallToolResult, any, error) {
 	return func(ctx context.Context, req *gomcp.CallToolRequest, input AddProjectInput) (*gomcp.CallToolResult, any, error) {
+		prefs := store.get(req)
 		params := make(map[string]string)
 		setIfNonEmpty(params, "title", input.Title)
 		setIfNonEmpty(params, "notes", input.Notes)
@@ -192,12 +293,13 @@ func makeAddProjectHandler(client *things.Client) func(context.Context, *gomcp.C
 		if input.Reveal {
 			params["reveal"] = "true"
 		}
-		result, err := executeTool(client, "add-project", params, things.ExecuteOptions{})
-		return result, nil, err
+		prefs.applyCreateDefaults(params, "area")
+		prefs.resolveDates(params)
+		return executeTool(ctx, req, client, "add-project", params, things.ExecuteOptions{}, prefs.Verbosity)
 	}
 }
 
-func makeUpdateHandler(client *things.Client) func(context.Context, *gomcp.CallToolRequest, UpdateInput) (*gomcp.CallToolResult, any, error) {
+func makeUpdateHandler(client *things.Client, store *preferenceStore) func(context.Context, *gomcp.CallToolRequest, UpdateInput) (*gomcp.CallToolResult, any, error) {
 	return func(ctx context.Context, req *gomcp.CallToolRequest, input UpdateInput) (*gomcp.CallToolResult, any, error) {
 		if input.ID == "" {
 			return &gomcp.CallToolResult{
@@ -235,12 +337,19 @@ func makeUpdateHandler(client *things.Client) func(context.Context, *gomcp.CallT
 		if input.Duplicate {
 			params["duplicate"] = "true"
 		}
-		result, err := executeTool(client, "update", params, things.ExecuteOptions{RequiresAuth: true, UseAuthIfAvailable: true})
-		return result, nil, err
+		if input.Reason != "" {
+			if !input.Canceled {
+				return toolError("reason requires canceled"), nil, nil
+			}
+			things.AppendCancelReason(params, input.Reason)
+		}
+		prefs := store.get(req)
+		prefs.resolveDates(params)
+		return executeTool(ctx, req, client, "update", params, things.ExecuteOptions{RequiresAuth: true, UseAuthIfAvailable: true}, prefs.Verbosity)
 	}
 }
 
-func makeUpdateProjectHandler(client *things.Client) func(context.Context, *gomcp.CallToolRequest, UpdateProjectInput) (*gomcp.CallToolResult, any, error) {
+func makeUpdateProjectHandler(client *things.Client, store *preferenceStore, confirmations *confirmationStore) func(context.Context, *gomcp.CallToolRequest, UpdateProjectInput) (*gomcp.CallToolResult, any, error) {
 	return func(ctx context.Context, req *gomcp.CallToolRequest, input UpdateProjectInput) (*gomcp.CallToolResult, any, error) {
 		if input.ID == "" {
 			return &gomcp.CallToolResult{
@@ -273,12 +382,23 @@ func makeUpdateProjectHandler(client *things.Client) func(context.Context, *gomc
 		if input.Duplicate {
 			params["duplicate"] = "true"
 		}
-		result, err := executeTool(client, "update-project", params, things.ExecuteOptions{RequiresAuth: true, UseAuthIfAvailable: true})
-		return result, nil, err
+		if input.Reason != "" {
+			if !input.Canceled {
+				return toolError("reason requires canceled"), nil, nil
+			}
+			things.AppendCancelReason(params, input.Reason)
+		}
+		prefs := store.get(req)
+		prefs.resolveDates(params)
+		opts := things.ExecuteOptions{RequiresAuth: true, UseAuthIfAvailable: true}
+		if input.Canceled {
+			return confirmations.hold(req, "update-project", params, opts, "Cancel project "+input.ID+" and its open to-dos")
+		}
+		return executeTool(ctx, req, client, "update-project", params, opts, prefs.Verbosity)
 	}
 }
 
-func makeShowHandler(client *things.Client) func(context.Context, *gomcp.CallToolRequest, ShowInput) (*gomcp.CallToolResult, any, error) {
+func makeShowHandler(client *things.Client, store *preferenceStore) func(context.Context, *gomcp.CallToolRequest, ShowInput) (*gomcp.CallToolResult, any, error) {
 	return func(ctx context.Context, req *gomcp.CallToolRequest, input ShowInput) (*gomcp.CallToolResult, any, error) {
 		params := make(map[string]string)
 		setIfNonEmpty(params, "id", input.ID)
@@ -289,26 +409,47 @@ func makeShowHandler(client *things.Client) func(context.Context, *gomcp.CallToo
 				IsError: true,
 			}, nil, nil
 		}
-		result, err := executeTool(client, "show", params, things.ExecuteOptions{})
-		return result, nil, err
+		return executeTool(ctx, req, client, "show", params, things.ExecuteOptions{}, store.get(req).Verbosity)
 	}
 }
 
-func makeSearchHandler(client *things.Client) func(context.Context, *gomcp.CallToolRequest, SearchInput) (*gomcp.CallToolResult, any, error) {
+func makeSearchHandler() func(context.Context, *gomcp.CallToolRequest, SearchInput) (*gomcp.CallToolResult, any, error) {
 	return func(ctx context.Context, req *gomcp.CallToolRequest, input SearchInput) (*gomcp.CallToolResult, any, error) {
-		if input.Query == "" {
-			return &gomcp.CallToolResult{
-				Content: []gomcp.Content{&gomcp.TextContent{Text: "Error: query is required"}},
-				IsError: true,
-			}, nil, nil
+		query, err := things.ParseQuery(input.Query)
+		if err != nil {
+			return toolError("%v", err), nil, nil
+		}
+		if len(query.Words) == 0 && !query.HasPredicates() {
+			return toolError("query is required"), nil, nil
+		}
+		db, err := things.OpenDB()
+		if err != nil {
+			return toolError("%v", err), nil, nil
+		}
+		return pagedToolResult(db, input.ReadLimits,
+			func(db *things.DB) (int, error) { return db.SearchCount(query) },
+			func(db *things.DB) ([]things.Item, error) { return db.Search(query) })
+	}
+}
+
+func makeSemanticSearchHandler() func(context.Context, *gomcp.CallToolRequest, SemanticSearchInput) (*gomcp.CallToolResult, any, error) {
+	return func(ctx context.Context, req *gomcp.CallToolRequest, input SemanticSearchInput) (*gomcp.CallToolResult, any, error) {
+		if strings.TrimSpace(input.Query) == "" {
+			return toolError("query is required"), nil, nil
+		}
+		db, err := things.OpenDB()
+		if err != nil {
+			return toolError("%v", err), nil, nil
 		}
-		params := map[string]string{"query": input.Query}
-		result, err := executeTool(client, "search", params, things.ExecuteOptions{})
-		return result, nil, err
+		results, err := things.SemanticSearch(db, things.NewEmbedder(), input.Query)
+		if err != nil {
+			return toolError("%v", err), nil, nil
+		}
+		return shapedToolResult(results, input.ReadLimits)
 	}
 }
 
-func makeJSONHandler(client *things.Client) func(context.Context, *gomcp.CallToolRequest, JSONInput) (*gomcp.CallToolResult, any, error) {
+func makeJSONHandler(client *things.Client, store *preferenceStore, confirmations *confirmationStore) func(context.Context, *gomcp.CallToolRequest, JSONInput) (*gomcp.CallToolResult, any, error) {
 	return func(ctx context.Context, req *gomcp.CallToolRequest, input JSONInput) (*gomcp.CallToolResult, any, error) {
 		if input.Data == "" {
 			return &gomcp.CallToolResult{
@@ -320,14 +461,122 @@ func makeJSONHandler(client *things.Client) func(context.Context, *gomcp.CallToo
 		if input.Reveal {
 			params["reveal"] = "true"
 		}
-		result, err := executeTool(client, "json", params, things.ExecuteOptions{UseAuthIfAvailable: true})
-		return result, nil, err
+		opts := things.ExecuteOptions{UseAuthIfAvailable: true}
+		if summary := destructiveJSONSummary(input.Data); summary != "" {
+			return confirmations.hold(req, "json", params, opts, summary)
+		}
+		return executeTool(ctx, req, client, "json", params, opts, store.get(req).Verbosity)
+	}
+}
+
+func makeBuildJSONHandler(store *preferenceStore) func(context.Context, *gomcp.CallToolRequest, BuildJSONInput) (*gomcp.CallToolResult, any, error) {
+	return func(ctx context.Context, req *gomcp.CallToolRequest, input BuildJSONInput) (*gomcp.CallToolResult, any, error) {
+		if strings.TrimSpace(input.Data) == "" {
+			return toolError("data is required"), nil, nil
+		}
+		data := things.ResolvePayloadDates(input.Data, store.get(req).now())
+		if problems := things.ValidatePayload(data); len(problems) > 0 {
+			return jsonToolResult(BuildJSONOutput{Data: data, Problems: problems})
+		}
+		return jsonToolResult(BuildJSONOutput{
+			Valid:    true,
+			Data:     data,
+			NextStep: "Pass data to things_json to send it.",
+		})
 	}
 }
 
-func makeVersionHandler(client *things.Client) func(context.Context, *gomcp.CallToolRequest, VersionInput) (*gomcp.CallToolResult, any, error) {
+func makeVersionHandler(client *things.Client, store *preferenceStore) func(context.Context, *gomcp.CallToolRequest, VersionInput) (*gomcp.CallToolResult, any, error) {
 	return func(ctx context.Context, req *gomcp.CallToolRequest, input VersionInput) (*gomcp.CallToolResult, any, error) {
-		result, err := executeTool(client, "version", map[string]string{}, things.ExecuteOptions{})
-		return result, nil, err
+		return executeTool(ctx, req, client, "version", map[string]string{}, things.ExecuteOptions{}, store.get(req).Verbosity)
+	}
+}
+
+func makeListHandler() func(context.Context, *gomcp.CallToolRequest, ListInput) (*gomcp.CallToolResult, any, error) {
+	return func(ctx context.Context, req *gomcp.CallToolRequest, input ListInput) (*gomcp.CallToolResult, any, error) {
+		db, err := things.OpenDB()
+		if err != nil {
+			return toolError("%v", err), nil, nil
+		}
+
+		now := util.Now()
+		today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+		switch strings.ToLower(strings.TrimSpace(input.List)) {
+		case "inbox":
+			return pagedToolResult(db, input.ReadLimits,
+				func(db *things.DB) (int, error) { return db.InboxCount() },
+				func(db *things.DB) ([]things.Item, error) { return db.Inbox() })
+		case "today":
+			return pagedToolResult(db, input.ReadLimits,
+				func(db *things.DB) (int, error) { return db.TodayCount(today) },
+				func(db *things.DB) ([]things.Item, error) { return db.Today(today) })
+		case "evening":
+			return pagedToolResult(db, input.ReadLimits,
+				func(db *things.DB) (int, error) { return db.EveningCount(today) },
+				func(db *things.DB) ([]things.Item, error) { return db.Evening(today) })
+		}
+		return toolError("list must be inbox, today, or evening"), nil, nil
+	}
+}
+
+// makeListPageHandler serves a read tool for one list in the local database,
+// such as things_get_upcoming. count and read are given the start of today.
+func makeListPageHandler(count func(*things.DB, time.Time) (int, error), read func(*things.DB, time.Time) ([]things.Item, error)) func(context.Context, *gomcp.CallToolRequest, ListPageInput) (*gomcp.CallToolResult, any, error) {
+	return func(ctx context.Context, req *gomcp.CallToolRequest, input ListPageInput) (*gomcp.CallToolResult, any, error) {
+		db, err := things.OpenDB()
+		if err != nil {
+			return toolError("%v", err), nil, nil
+		}
+		today := startOfToday()
+		return pagedToolResult(db, input.ReadLimits,
+			func(db *things.DB) (int, error) { return count(db, today) },
+			func(db *things.DB) ([]things.Item, error) { return read(db, today) })
+	}
+}
+
+func makeListTagsHandler() func(context.Context, *gomcp.CallToolRequest, ListTagsInput) (*gomcp.CallToolResult, any, error) {
+	return func(ctx context.Context, req *gomcp.CallToolRequest, input ListTagsInput) (*gomcp.CallToolResult, any, error) {
+		db, err := things.OpenDB()
+		if err != nil {
+			return toolError("%v", err), nil, nil
+		}
+		tags, err := db.TagUsage()
+		if err != nil {
+			return toolError("%v", err), nil, nil
+		}
+		return jsonToolResult(TagsOutput{Count: len(tags), Tags: tags})
+	}
+}
+
+// makeGetItemHandler returns everything the database holds about one item, so
+// an agent can see the current notes, checklist, tags, and dates before
+// changing them.
+func makeGetItemHandler() func(context.Context, *gomcp.CallToolRequest, GetItemInput) (*gomcp.CallToolResult, any, error) {
+	return func(ctx context.Context, req *gomcp.CallToolRequest, input GetItemInput) (*gomcp.CallToolResult, any, error) {
+		ref := strings.TrimSpace(input.ID)
+		if ref == "" {
+			return toolError("id is required"), nil, nil
+		}
+		db, err := things.OpenDB()
+		if err != nil {
+			return toolError("%v", err), nil, nil
+		}
+		item, err := db.FindItem(ref)
+		if err != nil {
+			return lookupError("item", ref, err), nil, nil
+		}
+
+		output := ItemOutput{Item: *item}
+		switch item.Type {
+		case "to-do":
+			if output.Checklist, err = db.Checklist(item.ID); err != nil {
+				return toolError("%v", err), nil, nil
+			}
+		case "project":
+			if output.Items, err = db.ProjectOutline(item.ID); err != nil {
+				return toolError("%v", err), nil, nil
+			}
+		}
+		return jsonToolResult(output)
 	}
 }
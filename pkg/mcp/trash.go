@@ -0,0 +1,52 @@
+package mcp
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	gomcp "github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/yourusername/things3-cli/pkg/things"
+)
+
+type DeleteInput struct {
+	ID      string `json:"id" jsonschema:"ID or @alias of the to-do or project to move to the Trash"`
+	Confirm bool   `json:"confirm" jsonschema:"Must be true; set it only after the user has agreed to trash the item"`
+}
+
+// makeDeleteHandler moves one to-do or project to the Trash. The URL scheme
+// cannot delete, so this uses AppleScript; the item stays restorable from the
+// Trash in Things.
+func makeDeleteHandler() func(context.Context, *gomcp.CallToolRequest, DeleteInput) (*gomcp.CallToolResult, any, error) {
+	return func(ctx context.Context, req *gomcp.CallToolRequest, input DeleteInput) (*gomcp.CallToolResult, any, error) {
+		ref := strings.TrimSpace(input.ID)
+		if ref == "" {
+			return toolError("id is required"), nil, nil
+		}
+		db, err := things.OpenDB()
+		if err != nil {
+			return toolError("%v", err), nil, nil
+		}
+		item, err := db.FindItem(ref)
+		if errors.Is(err, things.ErrNotFound) {
+			return toolError("no to-do or project with ID %q", ref), nil, nil
+		}
+		if err != nil {
+			return toolError("%v", err), nil, nil
+		}
+		if item.Type != "to-do" && item.Type != "project" {
+			return toolError("%q is a %s; only to-dos and projects can be trashed", ref, item.Type), nil, nil
+		}
+		if !input.Confirm {
+			return toolError("trashing the %s %q needs confirm: true; check with the user first", item.Type, item.Title), nil, nil
+		}
+
+		if progress := toolProgress(ctx, req); progress != nil {
+			progress("moving the " + item.Type + " " + item.ID + " to the Trash")
+		}
+		if err := things.TrashItems([]string{item.ID}); err != nil {
+			return toolError("%v", err), nil, nil
+		}
+		return jsonToolResult(ActionOutput{ActionResult: things.ActionResult{Action: "trash", ThingsID: item.ID}})
+	}
+}
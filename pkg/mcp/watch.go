@@ -0,0 +1,148 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	gomcp "github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/yourusername/things3-cli/pkg/things"
+)
+
+// watchInterval is how often the database is checked for changes while
+// clients are subscribed to resources.
+const watchInterval = 5 * time.Second
+
+// resourceWatcher notifies subscribed clients when the Things database
+// changes. Things gives no finer signal than the database files being
+// written, so every subscribed resource is reported as updated on any change.
+// The database is only polled while some session is subscribed, so the poller
+// stops when the last subscriber unsubscribes or disconnects, including when
+// shutdown closes every session.
+type resourceWatcher struct {
+	server *gomcp.Server
+
+	mu sync.Mutex
+	// subscriptions holds the URIs each session is subscribed to. A session
+	// stays listed with no URIs until it closes, so it is watched only once.
+	subscriptions map[*gomcp.ServerSession]map[string]bool
+	// stop ends the poller; nil while it is not running
+	stop chan struct{}
+}
+
+func newResourceWatcher() *resourceWatcher {
+	return &resourceWatcher{subscriptions: make(map[*gomcp.ServerSession]map[string]bool)}
+}
+
+// subscribe records a session's subscription to a resource and starts
+// polling the database on the first one.
+func (w *resourceWatcher) subscribe(ctx context.Context, req *gomcp.SubscribeRequest) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	uris, ok := w.subscriptions[req.Session]
+	if !ok {
+		uris = make(map[string]bool)
+		w.subscriptions[req.Session] = uris
+		go w.dropOnClose(req.Session)
+	}
+	uris[req.Params.URI] = true
+	if w.stop == nil {
+		w.stop = make(chan struct{})
+		go w.poll(w.stop)
+	}
+	return nil
+}
+
+// unsubscribe drops a session's subscription to a resource, stopping the
+// poller if no session is subscribed to anything.
+func (w *resourceWatcher) unsubscribe(ctx context.Context, req *gomcp.UnsubscribeRequest) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	delete(w.subscriptions[req.Session], req.Params.URI)
+	w.stopIfIdle()
+	return nil
+}
+
+// dropOnClose forgets a session's subscriptions once it disconnects.
+func (w *resourceWatcher) dropOnClose(session *gomcp.ServerSession) {
+	session.Wait()
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	delete(w.subscriptions, session)
+	w.stopIfIdle()
+}
+
+// stopIfIdle stops the poller when no session is subscribed. w.mu must be
+// held.
+func (w *resourceWatcher) stopIfIdle() {
+	if w.stop == nil {
+		return
+	}
+	for _, uris := range w.subscriptions {
+		if len(uris) > 0 {
+			return
+		}
+	}
+	close(w.stop)
+	w.stop = nil
+}
+
+// subscribed returns every URI some session is subscribed to.
+func (w *resourceWatcher) subscribed() []string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	seen := make(map[string]bool)
+	var uris []string
+	for _, session := range w.subscriptions {
+		for uri := range session {
+			if !seen[uri] {
+				seen[uri] = true
+				uris = append(uris, uri)
+			}
+		}
+	}
+	return uris
+}
+
+// poll checks the database files every watchInterval until stop is closed
+// and notifies the subscribed resources when they change. The server sends
+// each notification only to the sessions subscribed to that resource.
+func (w *resourceWatcher) poll(stop <-chan struct{}) {
+	last := databaseVersion()
+	ticker := time.NewTicker(watchInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+		}
+		version := databaseVersion()
+		if version == last {
+			continue
+		}
+		last = version
+
+		for _, uri := range w.subscribed() {
+			w.server.ResourceUpdated(context.Background(), &gomcp.ResourceUpdatedNotificationParams{URI: uri})
+		}
+	}
+}
+
+// databaseVersion returns the modification times and sizes of the database
+// and its write-ahead log, which change whenever Things saves.
+func databaseVersion() [2]string {
+	var version [2]string
+	db, err := things.OpenDB()
+	if err != nil {
+		return version
+	}
+	for i, path := range []string{db.Path, db.Path + "-wal"} {
+		if info, err := os.Stat(path); err == nil {
+			version[i] = info.ModTime().String() + "/" + fmt.Sprint(info.Size())
+		}
+	}
+	return version
+}
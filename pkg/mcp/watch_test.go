@@ -0,0 +1,106 @@
+package mcp
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	gomcp "github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// watchedServer returns a server whose subscriptions go to a new watcher.
+func watchedServer(t *testing.T) (*gomcp.Server, *resourceWatcher) {
+	t.Helper()
+	t.Setenv("THINGS_DB_PATH", t.TempDir()+"/missing.sqlite")
+	watcher := newResourceWatcher()
+	server := gomcp.NewServer(&gomcp.Implementation{Name: "things3", Version: "test"}, &gomcp.ServerOptions{
+		SubscribeHandler:   watcher.subscribe,
+		UnsubscribeHandler: watcher.unsubscribe,
+	})
+	server.AddResource(&gomcp.Resource{URI: "things://today", Name: "today"}, func(context.Context, *gomcp.ReadResourceRequest) (*gomcp.ReadResourceResult, error) {
+		return &gomcp.ReadResourceResult{}, nil
+	})
+	watcher.server = server
+	return server, watcher
+}
+
+// connect opens a client session to server.
+func connect(t *testing.T, server *gomcp.Server) *gomcp.ClientSession {
+	t.Helper()
+	ctx := context.Background()
+	clientTransport, serverTransport := gomcp.NewInMemoryTransports()
+	if _, err := server.Connect(ctx, serverTransport, nil); err != nil {
+		t.Fatal(err)
+	}
+	session, err := gomcp.NewClient(&gomcp.Implementation{Name: "test", Version: "test"}, nil).Connect(ctx, clientTransport, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return session
+}
+
+func (w *resourceWatcher) polling() bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.stop != nil
+}
+
+// waitFor fails the test unless cond becomes true within a second.
+func waitFor(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+	for deadline := time.Now().Add(time.Second); time.Now().Before(deadline); time.Sleep(5 * time.Millisecond) {
+		if cond() {
+			return
+		}
+	}
+	t.Fatalf("timed out waiting for %s", what)
+}
+
+func TestWatcherStopsWhenLastSubscriberUnsubscribes(t *testing.T) {
+	server, watcher := watchedServer(t)
+	ctx := context.Background()
+	first, second := connect(t, server), connect(t, server)
+	defer first.Close()
+	defer second.Close()
+
+	for _, session := range []*gomcp.ClientSession{first, second} {
+		if err := session.Subscribe(ctx, &gomcp.SubscribeParams{URI: "things://today"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if !watcher.polling() {
+		t.Fatal("not polling after subscribe")
+	}
+
+	if err := first.Unsubscribe(ctx, &gomcp.UnsubscribeParams{URI: "things://today"}); err != nil {
+		t.Fatal(err)
+	}
+	if !watcher.polling() {
+		t.Fatal("stopped polling while a session is still subscribed")
+	}
+
+	if err := second.Unsubscribe(ctx, &gomcp.UnsubscribeParams{URI: "things://today"}); err != nil {
+		t.Fatal(err)
+	}
+	if watcher.polling() {
+		t.Fatal("still polling after the last unsubscribe")
+	}
+}
+
+func TestWatcherDropsSubscriptionsOfClosedSessions(t *testing.T) {
+	server, watcher := watchedServer(t)
+	ctx := context.Background()
+	session := connect(t, server)
+
+	if err := session.Subscribe(ctx, &gomcp.SubscribeParams{URI: "things://today"}); err != nil {
+		t.Fatal(err)
+	}
+	if got := watcher.subscribed(); len(got) != 1 {
+		t.Fatalf("subscribed = %v, want things://today", got)
+	}
+
+	session.Close()
+	waitFor(t, "the closed session to be dropped", func() bool {
+		return !watcher.polling() && len(watcher.subscribed()) == 0
+	})
+}
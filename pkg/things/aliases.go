@@ -0,0 +1,125 @@
+package things
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/yourusername/things3-cli/pkg/util"
+)
+
+// aliasIDKeys are the parameters and json attributes that hold Things IDs.
+var aliasIDKeys = map[string]bool{
+	"id": true, "list-id": true, "area-id": true, "heading-id": true,
+}
+
+// ResolveAlias returns the ID registered for an @alias. References without a
+// leading @ are returned unchanged. Unknown aliases wrap ErrNotFound.
+func ResolveAlias(ref string) (string, error) {
+	if !strings.HasPrefix(ref, "@") {
+		return ref, nil
+	}
+	config, err := util.LoadConfig()
+	if err != nil {
+		return "", err
+	}
+	return lookupAlias(ref, config.Aliases)
+}
+
+func lookupAlias(ref string, aliases map[string]string) (string, error) {
+	if id, ok := aliases[strings.TrimPrefix(ref, "@")]; ok {
+		return id, nil
+	}
+	return "", fmt.Errorf("unknown alias %s: %w", ref, ErrNotFound)
+}
+
+// resolveAliasParams replaces @aliases in ID parameters and json payload
+// attributes. A list or area given as a registered @alias becomes list-id or
+// area-id, while unregistered ones are left as names.
+func resolveAliasParams(action string, params map[string]string) error {
+	if !hasAliasParam(action, params) {
+		return nil
+	}
+
+	config, err := util.LoadConfig()
+	if err != nil {
+		return err
+	}
+
+	for key, value := range params {
+		if aliasIDKeys[key] && strings.HasPrefix(value, "@") {
+			id, err := lookupAlias(value, config.Aliases)
+			if err != nil {
+				return err
+			}
+			params[key] = id
+		}
+	}
+
+	for name, idKey := range map[string]string{"list": "list-id", "area": "area-id"} {
+		if value := params[name]; strings.HasPrefix(value, "@") && params[idKey] == "" {
+			if id, err := lookupAlias(value, config.Aliases); err == nil {
+				delete(params, name)
+				params[idKey] = id
+			}
+		}
+	}
+
+	if action == "json" && strings.Contains(params["data"], `"@`) {
+		var payload interface{}
+		if err := json.Unmarshal([]byte(params["data"]), &payload); err != nil {
+			// Leave malformed payloads for Things to report
+			return nil
+		}
+		if err := resolvePayloadAliases(payload, config.Aliases); err != nil {
+			return err
+		}
+		data, err := json.Marshal(payload)
+		if err != nil {
+			return err
+		}
+		params["data"] = string(data)
+	}
+	return nil
+}
+
+// hasAliasParam reports whether any parameter might contain an alias, so the
+// config is only read when needed.
+func hasAliasParam(action string, params map[string]string) bool {
+	for key, value := range params {
+		if (aliasIDKeys[key] || key == "list" || key == "area") && strings.HasPrefix(value, "@") {
+			return true
+		}
+	}
+	return action == "json" && strings.Contains(params["data"], `"@`)
+}
+
+// resolvePayloadAliases walks a decoded json payload, replacing @aliases in
+// ID attributes in place.
+func resolvePayloadAliases(node interface{}, aliases map[string]string) error {
+	switch v := node.(type) {
+	case map[string]interface{}:
+		for key, value := range v {
+			if s, ok := value.(string); ok {
+				if aliasIDKeys[key] && strings.HasPrefix(s, "@") {
+					id, err := lookupAlias(s, aliases)
+					if err != nil {
+						return err
+					}
+					v[key] = id
+				}
+				continue
+			}
+			if err := resolvePayloadAliases(value, aliases); err != nil {
+				return err
+			}
+		}
+	case []interface{}:
+		for _, value := range v {
+			if err := resolvePayloadAliases(value, aliases); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
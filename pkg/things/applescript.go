@@ -0,0 +1,95 @@
+package things
+
+import (
+	"bytes"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// runAppleScript executes a script with osascript and returns its output.
+// AppleScript covers operations the URL scheme lacks, such as moving items to the Trash.
+func runAppleScript(script string) (string, error) {
+	cmd := exec.Command("osascript", "-e", script)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	out, err := cmd.Output()
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", fmt.Errorf("AppleScript failed: %s", msg)
+		}
+		return "", fmt.Errorf("AppleScript failed: %w", err)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// runAppleScriptChange runs a script that changes Things or another app. In
+// dry-run mode it returns a DryRunError carrying the script instead, so no
+// AppleScript change escapes --dry-run.
+func runAppleScriptChange(action, script string) (string, error) {
+	if dryRun {
+		return "", &DryRunError{Action: action, Script: script}
+	}
+	return runAppleScript(script)
+}
+
+// appleScriptString quotes s as an AppleScript string literal.
+func appleScriptString(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, `"`, `\"`)
+	return `"` + s + `"`
+}
+
+// TrashItems moves the to-dos or projects with the given IDs to the Trash.
+func TrashItems(ids []string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	var script strings.Builder
+	script.WriteString("tell application \"Things3\"\n")
+	for _, id := range ids {
+		fmt.Fprintf(&script, "\tdelete to do id %s\n", appleScriptString(id))
+	}
+	script.WriteString("end tell")
+
+	_, err := runAppleScriptChange("trash", script.String())
+	return err
+}
+
+// LogCompleted moves completed and canceled items to the Logbook. Things only
+// offers this for all lists at once.
+func LogCompleted() error {
+	_, err := runAppleScriptChange("log-completed", "tell application \"Things3\" to log completed now")
+	return err
+}
+
+// CreateArea creates an area and returns its ID.
+func CreateArea(title string) (string, error) {
+	return runAppleScriptChange("create-area", fmt.Sprintf("tell application \"Things3\" to get id of (make new area with properties {name:%s})", appleScriptString(title)))
+}
+
+// RenameArea changes the title of an area.
+func RenameArea(id, title string) error {
+	_, err := runAppleScriptChange("rename-area", fmt.Sprintf("tell application \"Things3\" to set name of area id %s to %s", appleScriptString(id), appleScriptString(title)))
+	return err
+}
+
+// DeleteArea deletes an area. Things moves its projects and to-dos to the Trash.
+func DeleteArea(id string) error {
+	_, err := runAppleScriptChange("delete-area", fmt.Sprintf("tell application \"Things3\" to delete area id %s", appleScriptString(id)))
+	return err
+}
+
+// DeleteTag deletes a tag. Things removes it from every item that carries it.
+func DeleteTag(name string) error {
+	_, err := runAppleScriptChange("delete-tag", fmt.Sprintf("tell application \"Things3\" to delete tag %s", appleScriptString(name)))
+	return err
+}
+
+// EmptyTrash permanently deletes everything in the Trash.
+func EmptyTrash() error {
+	_, err := runAppleScriptChange("empty-trash", "tell application \"Things3\" to empty trash")
+	return err
+}
@@ -0,0 +1,94 @@
+package things
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// estimateTagPattern matches time estimate tags such as 15m, 1h, or 1h30m.
+var estimateTagPattern = regexp.MustCompile(`^(?:(\d+)h)?(?:(\d+)m)?$`)
+
+// CalendarBlock is a span of time reserved for one item.
+type CalendarBlock struct {
+	ItemID string    `json:"item_id"`
+	Title  string    `json:"title"`
+	Start  time.Time `json:"start"`
+	End    time.Time `json:"end"`
+}
+
+// Estimate returns the time estimate given by an item's tags, such as 30m or
+// 1h30m. When several tags hold estimates the first wins.
+func Estimate(item Item) (time.Duration, bool) {
+	for _, tag := range item.Tags {
+		match := estimateTagPattern.FindStringSubmatch(strings.ToLower(strings.TrimSpace(tag)))
+		if match == nil || (match[1] == "" && match[2] == "") {
+			continue
+		}
+		hours, _ := strconv.Atoi(match[1])
+		minutes, _ := strconv.Atoi(match[2])
+		if d := time.Duration(hours)*time.Hour + time.Duration(minutes)*time.Minute; d > 0 {
+			return d, true
+		}
+	}
+	return 0, false
+}
+
+// PlanBlocks lays out back-to-back blocks from start for the items that have
+// estimates, in the order given. Items without an estimate are skipped.
+func PlanBlocks(items []Item, start time.Time) []CalendarBlock {
+	blocks := []CalendarBlock{}
+	next := start
+	for _, item := range items {
+		estimate, ok := Estimate(item)
+		if !ok {
+			continue
+		}
+		blocks = append(blocks, CalendarBlock{
+			ItemID: item.ID,
+			Title:  item.Title,
+			Start:  next,
+			End:    next.Add(estimate),
+		})
+		next = next.Add(estimate)
+	}
+	return blocks
+}
+
+// AddCalendarBlocks creates a tentative Calendar.app event for each block in
+// the named calendar. Each event links back to its item in Things.
+func AddCalendarBlocks(calendar string, blocks []CalendarBlock) error {
+	if len(blocks) == 0 {
+		return nil
+	}
+
+	var script strings.Builder
+	for i, block := range blocks {
+		writeAppleScriptDate(&script, fmt.Sprintf("start%d", i), block.Start)
+		writeAppleScriptDate(&script, fmt.Sprintf("end%d", i), block.End)
+	}
+	script.WriteString("tell application \"Calendar\"\n")
+	fmt.Fprintf(&script, "\ttell calendar %s\n", appleScriptString(calendar))
+	for i, block := range blocks {
+		fmt.Fprintf(&script, "\t\tmake new event at end with properties {summary:%s, start date:start%d, end date:end%d, description:%s, status:tentative}\n",
+			appleScriptString(block.Title), i, i, appleScriptString(ShowURL(block.ItemID)))
+	}
+	script.WriteString("\tend tell\nend tell")
+
+	_, err := runAppleScriptChange("block-calendar", script.String())
+	return err
+}
+
+// writeAppleScriptDate emits statements setting name to t in local time.
+// The day is reset first so changing the month cannot overflow.
+func writeAppleScriptDate(script *strings.Builder, name string, t time.Time) {
+	t = t.Local()
+	fmt.Fprintf(script, "set %s to current date\n", name)
+	fmt.Fprintf(script, "set day of %s to 1\n", name)
+	fmt.Fprintf(script, "set year of %s to %d\n", name, t.Year())
+	fmt.Fprintf(script, "set month of %s to %d\n", name, int(t.Month()))
+	fmt.Fprintf(script, "set day of %s to %d\n", name, t.Day())
+	fmt.Fprintf(script, "set time of %s to %d\n", name, t.Hour()*3600+t.Minute()*60+t.Second())
+}
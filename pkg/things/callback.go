@@ -2,30 +2,60 @@ package things
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
 	"fmt"
 	"net"
 	"net/http"
+	"net/url"
+	"strconv"
 	"sync"
 	"time"
 )
 
+// ErrTimeout is wrapped by errors returned when Things does not respond in time.
+var ErrTimeout = errors.New("timed out")
+
 // CallbackServer handles receiving x-callback-url responses from Things via HTTP
 // Things will request our local server with response parameters
 // after completing an action.
 type CallbackServer struct {
-	Port     int
-	server   *http.Server
-	response chan map[string]string
-	mu       sync.Mutex
-	started  bool
+	// Port is the port to listen on; 0 lets the system choose a free one,
+	// and Start sets it to the port actually used.
+	Port int
+	// RequestID tags the callback URLs of this server. Callbacks without it
+	// are refused, so a callback meant for another action is never taken as
+	// this one's response.
+	RequestID string
+	server    *http.Server
+	response  chan map[string]string
+	mu        sync.Mutex
+	started   bool
 }
 
 // NewCallbackServer creates a new callback server instance
 func NewCallbackServer(port int) *CallbackServer {
 	return &CallbackServer{
-		Port:     port,
-		response: make(chan map[string]string, 1),
+		Port:      port,
+		RequestID: newRequestID(),
+		response:  make(chan map[string]string, 1),
+	}
+}
+
+// newRequestID returns a random ID to correlate a callback with its action.
+func newRequestID() string {
+	buf := make([]byte, 8)
+	if _, err := rand.Read(buf); err != nil {
+		return strconv.FormatInt(time.Now().UnixNano(), 36)
 	}
+	return hex.EncodeToString(buf)
+}
+
+// CallbackURL returns the x-success or x-error URL that reports result to
+// this server.
+func (s *CallbackServer) CallbackURL(result string) string {
+	return fmt.Sprintf("http://localhost:%d/callback?result=%s&request=%s", s.Port, url.QueryEscape(result), s.RequestID)
 }
 
 // Start begins listening for x-callback responses
@@ -39,8 +69,15 @@ func (s *CallbackServer) Start() error {
 
 	mux := http.NewServeMux()
 	mux.HandleFunc("/callback", func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if query.Get("request") != s.RequestID {
+			http.Error(w, "Unknown callback request", http.StatusNotFound)
+			return
+		}
+		query.Del("request")
+
 		params := make(map[string]string)
-		for key, values := range r.URL.Query() {
+		for key, values := range query {
 			if len(values) > 0 {
 				params[key] = values[0]
 			}
@@ -83,28 +120,27 @@ setTimeout(function(){document.body.innerHTML='<div class="msg"><h1>✓ Success<
 		}
 	})
 
+	// Listen before returning so a busy port is reported instead of leaving
+	// the callback to whichever process holds it.
+	listener, err := net.Listen("tcp", fmt.Sprintf("localhost:%d", s.Port))
+	if err != nil {
+		return err
+	}
+	s.Port = listener.Addr().(*net.TCPAddr).Port
+
 	s.server = &http.Server{
-		Addr:         fmt.Sprintf("localhost:%d", s.Port),
+		Addr:         listener.Addr().String(),
 		Handler:      mux,
 		ReadTimeout:  5 * time.Second,
 		WriteTimeout: 5 * time.Second,
 	}
 
-	ready := make(chan struct{})
 	go func() {
-		listener, err := net.Listen("tcp", s.server.Addr)
-		if err != nil {
-			close(ready)
-			return
-		}
-		close(ready)
-
 		if err := s.server.Serve(listener); err != nil && err != http.ErrServerClosed {
 			// The callback may have already been received.
 		}
 	}()
 
-	<-ready
 	s.started = true
 	return nil
 }
@@ -135,7 +171,7 @@ func (s *CallbackServer) WaitForResponse(timeout time.Duration) (map[string]stri
 	case response := <-s.response:
 		return response, nil
 	case <-time.After(timeout):
-		return nil, fmt.Errorf("callback timeout: no response from Things within %v", timeout)
+		return nil, fmt.Errorf("callback %w: no response from Things within %v", ErrTimeout, timeout)
 	}
 }
 
@@ -0,0 +1,56 @@
+package things
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/yourusername/things3-cli/pkg/util"
+)
+
+// cancelReasonPattern matches the notes line written by AppendCancelReason
+var cancelReasonPattern = regexp.MustCompile(`(?m)^Canceled \d{4}-\d{2}-\d{2}: (.+)$`)
+
+// AppendCancelReason adds a line such as "Canceled 2024-06-01: superseded by
+// X" to the append-notes parameter of an update, after any text already
+// there. Things keeps no record of why an item was canceled, so the reason
+// lives in the notes, where CancelReason reads it back.
+func AppendCancelReason(params map[string]string, reason string) {
+	AppendNotesLine(params, "Canceled "+util.Now().Format(util.DateLayout)+": "+strings.Join(strings.Fields(reason), " "))
+}
+
+// AppendNotesLine adds line to the append-notes parameter of an update,
+// starting it on a new line unless the item has no notes yet.
+func AppendNotesLine(params map[string]string, line string) {
+	if params["append-notes"] != "" || hasNotes(params["id"]) {
+		line = "\n" + line
+	}
+	params["append-notes"] = params["append-notes"] + line
+}
+
+// CancelReason returns the most recent reason recorded by AppendCancelReason
+// in notes, or "" if there is none.
+func CancelReason(notes string) string {
+	matches := cancelReasonPattern.FindAllStringSubmatch(notes, -1)
+	if len(matches) == 0 {
+		return ""
+	}
+	return strings.TrimSpace(matches[len(matches)-1][1])
+}
+
+// hasNotes reports whether an item has notes. Items that cannot be read from
+// the database are assumed to have some, so the reason starts on its own line.
+func hasNotes(ref string) bool {
+	id, err := ResolveAlias(ref)
+	if err != nil {
+		return true
+	}
+	db, err := OpenDB()
+	if err != nil {
+		return true
+	}
+	item, err := db.FindItem(id)
+	if err != nil {
+		return true
+	}
+	return item.Notes != ""
+}
@@ -0,0 +1,108 @@
+package things
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/yourusername/things3-cli/pkg/things/quickadd"
+	"github.com/yourusername/things3-cli/pkg/util"
+)
+
+// MaxCaptureFileSize is the largest capture file that will be imported.
+const MaxCaptureFileSize = 64 * 1024
+
+// captureFile is the JSON form of a capture file.
+type captureFile struct {
+	Title     string      `json:"title"`
+	Notes     string      `json:"notes"`
+	When      string      `json:"when"`
+	Deadline  string      `json:"deadline"`
+	Tags      captureTags `json:"tags"`
+	Checklist []string    `json:"checklist"`
+}
+
+// captureTags accepts tags as a list or as a comma-separated string.
+type captureTags []string
+
+func (t *captureTags) UnmarshalJSON(data []byte) error {
+	var list []string
+	if err := json.Unmarshal(data, &list); err == nil {
+		*t = list
+		return nil
+	}
+	var joined string
+	if err := json.Unmarshal(data, &joined); err != nil {
+		return fmt.Errorf("tags must be a list or a comma-separated string")
+	}
+	*t = util.ParseTags(joined)
+	return nil
+}
+
+// IsCaptureFile reports whether a file name looks like a capture file.
+// Hidden files, such as iCloud placeholders and partial downloads, are ignored.
+func IsCaptureFile(name string) bool {
+	if strings.HasPrefix(name, ".") {
+		return false
+	}
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".txt", ".json":
+		return true
+	default:
+		return false
+	}
+}
+
+// ParseCaptureFile turns the contents of a capture file into add parameters.
+//
+// A .txt file's first line is read with quick-add syntax (#tag, @when,
+// !deadline, //notes) and any further lines become the notes. A .json file
+// holds title, notes, when, deadline, tags, and checklist fields.
+func ParseCaptureFile(name string, data []byte) (map[string]string, error) {
+	params := make(map[string]string)
+
+	if strings.EqualFold(filepath.Ext(name), ".json") {
+		var file captureFile
+		if err := json.Unmarshal(data, &file); err != nil {
+			return nil, fmt.Errorf("invalid capture JSON: %w", err)
+		}
+		if strings.TrimSpace(file.Title) == "" {
+			return nil, fmt.Errorf("capture file has no title")
+		}
+		params["title"] = strings.TrimSpace(file.Title)
+		setCaptureParam(params, "notes", file.Notes)
+		setCaptureParam(params, "when", file.When)
+		setCaptureParam(params, "deadline", file.Deadline)
+		if len(file.Tags) > 0 {
+			params["tags"] = util.JoinTags(file.Tags)
+		}
+		if len(file.Checklist) > 0 {
+			params["checklist-items"] = strings.Join(file.Checklist, "\n")
+		}
+		return params, nil
+	}
+
+	text := strings.TrimSpace(strings.ReplaceAll(string(data), "\r\n", "\n"))
+	first, rest, _ := strings.Cut(text, "\n")
+	parsed, err := quickadd.Parse(first)
+	if err != nil {
+		return nil, fmt.Errorf("capture file has no title")
+	}
+
+	params["title"] = parsed.Title
+	notes := strings.TrimSpace(strings.Join([]string{parsed.Notes, strings.TrimSpace(rest)}, "\n"))
+	setCaptureParam(params, "notes", notes)
+	setCaptureParam(params, "when", parsed.When)
+	setCaptureParam(params, "deadline", parsed.Deadline)
+	if len(parsed.Tags) > 0 {
+		params["tags"] = util.JoinTags(parsed.Tags)
+	}
+	return params, nil
+}
+
+func setCaptureParam(params map[string]string, key, value string) {
+	if value = strings.TrimSpace(value); value != "" {
+		params[key] = value
+	}
+}
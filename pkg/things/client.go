@@ -1,7 +1,9 @@
 package things
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os/exec"
 	"strings"
@@ -13,16 +15,49 @@ import (
 // Client handles communication with Things via the URL scheme.
 type Client struct {
 	AuthToken    string
+	// CallbackPort is where Things reports back after an action; 0 picks a
+	// free port for every action.
 	CallbackPort int
 	timeout      time.Duration
+
+	// SafeModeThreshold is the number of items an action may modify before
+	// Confirm must approve it. Zero or less disables the check.
+	SafeModeThreshold int
+	// Confirm is asked to approve mass mutations; nil rejects them.
+	Confirm func(count int) bool
+
+	// CompletionSound and CompletionShortcut give feedback when an action
+	// completes items: a sound played with afplay and a Shortcuts shortcut
+	// to run. Both are off when empty.
+	CompletionSound    string
+	CompletionShortcut string
+
+	// DryRun makes Execute return a DryRunError with the URL it would
+	// open instead of opening it.
+	DryRun bool
 }
 
 // ExecuteOptions controls how actions are executed.
 type ExecuteOptions struct {
 	RequiresAuth      bool
 	UseAuthIfAvailable bool
+	// NoWait opens the URL without callbacks and returns once it has been
+	// handed to Things, for actions like show that send no data back.
+	NoWait bool
+	// Progress, when set, is told about each step of the action as it
+	// happens: the URL opened, the wait for the callback, and its arrival.
+	Progress func(message string)
+	// Confirm, when set, approves mass mutations for this action in place of
+	// the client's Confirm, for actions the user has already approved.
+	Confirm func(count int) bool
 }
 
+// openTimeout bounds how long NoWait actions wait for the URL to be opened.
+const openTimeout = 5 * time.Second
+
+// ErrAuthRequired is returned when an action needs an auth token and none is configured.
+var ErrAuthRequired = errors.New("auth token required (set with things config set-token or THINGS_AUTH_TOKEN)")
+
 // CallbackError represents an error returned via the callback URL.
 type CallbackError struct {
 	Code     string
@@ -51,8 +86,12 @@ func NewClient() (*Client, error) {
 
 	return &Client{
 		AuthToken:    token,
-		CallbackPort: config.CallbackPort,
-		timeout:      time.Duration(config.CallbackTimeoutSeconds) * time.Second,
+		CallbackPort:      config.CallbackPort,
+		timeout:           time.Duration(config.CallbackTimeoutSeconds) * time.Second,
+		SafeModeThreshold: config.SafeModeThreshold,
+		CompletionSound:    config.CompletionSound,
+		CompletionShortcut: config.CompletionShortcut,
+		DryRun:             dryRun,
 	}, nil
 }
 
@@ -76,38 +115,61 @@ func (c *Client) Execute(action string, params map[string]string, opts ExecuteOp
 		if c.AuthToken != "" {
 			params["auth-token"] = c.AuthToken
 		} else if opts.RequiresAuth {
-			return nil, fmt.Errorf("auth token required (set with things config set-token or THINGS_AUTH_TOKEN)")
+			return nil, ErrAuthRequired
 		}
 	}
 
-	port := c.CallbackPort
-	if !IsPortAvailable(port) {
-		alt := FindAvailablePort(port + 1)
-		if alt < 0 {
-			return nil, fmt.Errorf("no available callback port found")
-		}
-		port = alt
+	if err := resolveAliasParams(action, params); err != nil {
+		return nil, err
+	}
+	normalizeTagParams(action, params)
+	normalizeNameParams(action, params)
+	resolveDateParams(action, params)
+
+	if c.DryRun {
+		return nil, c.dryRunResult(action, params)
 	}
 
-	params["x-success"] = fmt.Sprintf("http://localhost:%d/callback?result=success", port)
-	params["x-error"] = fmt.Sprintf("http://localhost:%d/callback?result=error", port)
+	if err := c.checkSafeMode(action, params, opts.Confirm); err != nil {
+		return nil, err
+	}
 
-	callbackServer := NewCallbackServer(port)
-	if err := callbackServer.Start(); err != nil {
-		return nil, fmt.Errorf("failed to start callback server: %w", err)
+	if opts.NoWait {
+		return nil, c.openWithoutCallback(action, params)
+	}
+
+	callbackServer, err := c.startCallbackServer()
+	if err != nil {
+		return nil, err
 	}
 	defer callbackServer.Stop()
+	port := callbackServer.Port
 
+	params["x-success"] = callbackServer.CallbackURL("success")
+	params["x-error"] = callbackServer.CallbackURL("error")
+
+	done := status.beginOperation(action, port)
+	defer done()
+
+	c.debugURL(action, params)
+	opts.progress("opening %s", c.buildThingsURL(action, redactParams(params)))
+	started := time.Now()
 	thingsURL := c.buildThingsURL(action, params)
 	cmd := exec.Command("open", thingsURL)
 	if err := cmd.Run(); err != nil {
 		return nil, fmt.Errorf("failed to execute Things URL: %w", err)
 	}
+	debugf("opened URL in %s, waiting up to %s for the callback", time.Since(started).Round(time.Millisecond), c.timeout)
+	opts.progress("waiting up to %s for Things to call back on port %d", c.timeout, port)
 
 	response, err := callbackServer.WaitForResponse(c.timeout)
 	if err != nil {
+		debugf("no callback after %s", time.Since(started).Round(time.Millisecond))
+		opts.progress("no callback after %s", time.Since(started).Round(time.Millisecond))
 		return nil, err
 	}
+	debugCallback(response, started)
+	opts.progress("callback received after %s (%s)", time.Since(started).Round(time.Millisecond), response["result"])
 
 	if response["result"] == "error" {
 		code := response["errorCode"]
@@ -118,9 +180,51 @@ func (c *Client) Execute(action string, params map[string]string, opts ExecuteOp
 		return response, &CallbackError{Code: code, Message: message, Callback: response}
 	}
 
+	recordCreated(action, params, NormalizeResponse(action, response))
+	c.completionFeedback(action, params)
 	return response, nil
 }
 
+// startCallbackServer listens for the callback of one action on the
+// configured port, or on a free port chosen by the system when that one is
+// taken or CallbackPort is 0. Each action gets its own server and request ID,
+// so concurrent actions never receive each other's callbacks.
+func (c *Client) startCallbackServer() (*CallbackServer, error) {
+	server := NewCallbackServer(c.CallbackPort)
+	err := server.Start()
+	if err != nil && c.CallbackPort != 0 {
+		debugf("callback port %d is busy, using a free port", c.CallbackPort)
+		server = NewCallbackServer(0)
+		err = server.Start()
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to start callback server: %w", err)
+	}
+	debugf("callback port: %d, request %s", server.Port, server.RequestID)
+	return server, nil
+}
+
+// openWithoutCallback opens a Things URL and returns without waiting for a response.
+func (c *Client) openWithoutCallback(action string, params map[string]string) error {
+	done := status.beginOperation(action, 0)
+	defer done()
+
+	c.debugURL(action, params)
+	started := time.Now()
+	defer func() { debugf("opened URL without callback in %s", time.Since(started).Round(time.Millisecond)) }()
+
+	ctx, cancel := context.WithTimeout(context.Background(), openTimeout)
+	defer cancel()
+
+	if err := exec.CommandContext(ctx, "open", c.buildThingsURL(action, params)).Run(); err != nil {
+		if ctx.Err() != nil {
+			return fmt.Errorf("%w opening Things URL", ErrTimeout)
+		}
+		return fmt.Errorf("failed to execute Things URL: %w", err)
+	}
+	return nil
+}
+
 // NormalizeResponse produces a structured result from a callback response.
 func NormalizeResponse(action string, callback map[string]string) ActionResult {
 	result := ActionResult{Action: action}
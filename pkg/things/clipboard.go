@@ -0,0 +1,79 @@
+package things
+
+import (
+	"bytes"
+	"fmt"
+	"net/url"
+	"os/exec"
+	"regexp"
+	"strings"
+)
+
+// clipboardURLPattern finds the first web link in clipboard text
+var clipboardURLPattern = regexp.MustCompile(`https?://[^\s<>"]+`)
+
+// ReadClipboard returns the text on the macOS clipboard.
+func ReadClipboard() (string, error) {
+	cmd := exec.Command("pbpaste")
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", fmt.Errorf("pbpaste failed: %s", msg)
+		}
+		return "", fmt.Errorf("pbpaste failed: %w", err)
+	}
+	return string(out), nil
+}
+
+// SplitClipboard turns clipboard text into a to-do title and notes: the first
+// non-empty line is the title and the remaining text the notes.
+//
+// With urlMode, a web link in the text is moved to the top of the notes on a
+// line of its own, and the title comes from the remaining text, or from the
+// link's host and path when the clipboard holds nothing but the link.
+func SplitClipboard(text string, urlMode bool) (title, notes string) {
+	text = strings.TrimSpace(strings.ReplaceAll(text, "\r\n", "\n"))
+
+	var link string
+	if urlMode {
+		if link = clipboardURLPattern.FindString(text); link != "" {
+			link = strings.TrimRight(link, ".,;:!?)]}'")
+			text = strings.TrimSpace(strings.Replace(text, link, "", 1))
+		}
+	}
+
+	lines := strings.Split(text, "\n")
+	for len(lines) > 0 && strings.TrimSpace(lines[0]) == "" {
+		lines = lines[1:]
+	}
+	if len(lines) > 0 {
+		title = strings.TrimSpace(lines[0])
+		notes = strings.TrimSpace(strings.Join(lines[1:], "\n"))
+	}
+
+	if link != "" {
+		// Drop separators left behind where the link was, as in "Read this: <link>"
+		title = strings.TrimRight(title, " \t:;,.|-–—")
+		if title == "" {
+			title = linkTitle(link)
+		}
+		if notes != "" {
+			notes = link + "\n\n" + notes
+		} else {
+			notes = link
+		}
+	}
+	return title, notes
+}
+
+// linkTitle names a link by its host and path, without the scheme, query, or
+// trailing slash.
+func linkTitle(link string) string {
+	parsed, err := url.Parse(link)
+	if err != nil || parsed.Host == "" {
+		return link
+	}
+	return strings.TrimPrefix(parsed.Host, "www.") + strings.TrimSuffix(parsed.Path, "/")
+}
@@ -0,0 +1,81 @@
+package things
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// WaitingTag marks items delegated to someone else.
+const WaitingTag = "waiting"
+
+// Contact is a person found in Contacts.app.
+type Contact struct {
+	Name      string `json:"name"`
+	FirstName string `json:"first_name,omitempty"`
+	Email     string `json:"email,omitempty"`
+}
+
+// LookupContact finds the first person in Contacts whose name contains name,
+// returning ErrNotFound when nobody matches.
+func LookupContact(name string) (*Contact, error) {
+	script := fmt.Sprintf(`tell application "Contacts"
+	set matches to (every person whose name contains %s)
+	if (count of matches) is 0 then return ""
+	set p to item 1 of matches
+	set theFirst to first name of p
+	if theFirst is missing value then set theFirst to ""
+	set theEmail to ""
+	if (count of emails of p) > 0 then set theEmail to value of item 1 of emails of p
+	return (name of p) & linefeed & theFirst & linefeed & theEmail
+end tell`, appleScriptString(name))
+
+	out, err := runAppleScript(script)
+	if err != nil {
+		return nil, err
+	}
+	if out == "" {
+		return nil, ErrNotFound
+	}
+
+	fields := strings.SplitN(out, "\n", 3)
+	for len(fields) < 3 {
+		fields = append(fields, "")
+	}
+	return &Contact{
+		Name:      strings.TrimSpace(fields[0]),
+		FirstName: strings.TrimSpace(fields[1]),
+		Email:     strings.TrimSpace(fields[2]),
+	}, nil
+}
+
+// Tag returns the tag naming this person on delegated items: the first name
+// in lower case, without spaces, as in "alice".
+func (c *Contact) Tag() string {
+	name := c.FirstName
+	if name == "" {
+		if fields := strings.Fields(c.Name); len(fields) > 0 {
+			name = fields[0]
+		}
+	}
+	return PersonTag(name)
+}
+
+// PersonTag normalizes a name given to --by or --delegate into a person tag.
+func PersonTag(name string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsSpace(r) {
+			return -1
+		}
+		return unicode.ToLower(r)
+	}, name)
+}
+
+// DelegationNote is the notes line recording who an item is waiting on,
+// with a mailto: link when the contact has an email address.
+func (c *Contact) DelegationNote() string {
+	if c.Email == "" {
+		return "Waiting for " + c.Name
+	}
+	return fmt.Sprintf("Waiting for [%s](mailto:%s)", c.Name, c.Email)
+}
@@ -0,0 +1,71 @@
+package things
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/yourusername/things3-cli/pkg/util"
+)
+
+// resolveDateParams expands natural-language when/deadline values into the
+// explicit dates Things expects, both in URL parameters and in json payloads.
+func resolveDateParams(action string, params map[string]string) {
+	now := util.Now()
+
+	if when, ok := params["when"]; ok {
+		params["when"] = util.ResolveWhen(when, now)
+	}
+	if deadline, ok := params["deadline"]; ok && deadline != "" {
+		params["deadline"] = util.ResolveDate(deadline, now)
+	}
+
+	if action == "json" && params["data"] != "" {
+		var payload interface{}
+		if err := json.Unmarshal([]byte(params["data"]), &payload); err != nil {
+			// Leave malformed payloads for Things to report
+			return
+		}
+		if resolvePayloadDates(payload, now) {
+			if data, err := json.Marshal(payload); err == nil {
+				params["data"] = string(data)
+			}
+		}
+	}
+}
+
+// resolvePayloadDates walks a decoded json payload, rewriting when and
+// deadline attributes in place. It reports whether anything changed.
+func resolvePayloadDates(node interface{}, now time.Time) bool {
+	changed := false
+	switch v := node.(type) {
+	case map[string]interface{}:
+		for key, value := range v {
+			if s, ok := value.(string); ok {
+				var resolved string
+				switch key {
+				case "when":
+					resolved = util.ResolveWhen(s, now)
+				case "deadline":
+					resolved = util.ResolveDate(s, now)
+				default:
+					continue
+				}
+				if resolved != s {
+					v[key] = resolved
+					changed = true
+				}
+				continue
+			}
+			if resolvePayloadDates(value, now) {
+				changed = true
+			}
+		}
+	case []interface{}:
+		for _, value := range v {
+			if resolvePayloadDates(value, now) {
+				changed = true
+			}
+		}
+	}
+	return changed
+}
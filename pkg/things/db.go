@@ -0,0 +1,608 @@
+package things
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/yourusername/things3-cli/pkg/util"
+)
+
+// ErrNotFound is returned when a database lookup matches no rows.
+var ErrNotFound = errors.New("not found")
+
+// DB provides read-only access to the local Things database.
+// Queries are run through the sqlite3 command-line tool that ships with macOS.
+type DB struct {
+	Path string
+
+	// sort overrides the order of list queries; see SetSort
+	sort itemSort
+	// page limits list queries; see Paged
+	page Page
+}
+
+// Page selects a window of a list query: Limit items after skipping Offset.
+// A zero Limit returns every item after Offset.
+type Page struct {
+	Limit  int
+	Offset int
+}
+
+// Paged returns a copy of db whose list queries return only the items in
+// page, limited in SQL rather than after reading every row.
+func (db *DB) Paged(page Page) *DB {
+	paged := *db
+	paged.page = page
+	return &paged
+}
+
+// itemColumns selects the fields needed to build an Item from TMTask.
+// To-dos under a heading have no project of their own, so the project
+// and area are resolved through the heading when necessary.
+const itemColumns = `t.uuid AS id, t.type AS type, t.title AS title, t.notes AS notes,
+	t.status AS status, t.start AS start, t.startDate AS start_date,
+	t.startBucket AS start_bucket, t.deadline AS deadline,
+	t.creationDate AS created, t.userModificationDate AS modified, t.stopDate AS stopped,
+	COALESCE(t.project, h.project) AS project_id, p.title AS project,
+	COALESCE(t.area, p.area) AS area_id, a.title AS area,
+	t.heading AS heading_id, h.title AS heading,
+	(SELECT group_concat(tg.title, char(31)) FROM TMTaskTag tt JOIN TMTag tg ON tg.uuid = tt.tags WHERE tt.tasks = t.uuid) AS tags`
+
+// itemJoins resolves the heading, project, and area of each task.
+const itemJoins = `TMTask t
+	LEFT JOIN TMTask h ON h.uuid = t.heading
+	LEFT JOIN TMTask p ON p.uuid = COALESCE(t.project, h.project)
+	LEFT JOIN TMArea a ON a.uuid = COALESCE(t.area, p.area)`
+
+// itemRow mirrors the columns selected by itemColumns.
+type itemRow struct {
+	ID          string  `json:"id"`
+	Type        int     `json:"type"`
+	Title       string  `json:"title"`
+	Notes       string  `json:"notes"`
+	Status      int     `json:"status"`
+	Start       int     `json:"start"`
+	StartDate   int64   `json:"start_date"`
+	StartBucket int     `json:"start_bucket"`
+	Deadline    int64   `json:"deadline"`
+	Created     float64 `json:"created"`
+	Modified    float64 `json:"modified"`
+	Stopped     float64 `json:"stopped"`
+	ProjectID   string  `json:"project_id"`
+	Project     string  `json:"project"`
+	AreaID      string  `json:"area_id"`
+	Area        string  `json:"area"`
+	HeadingID   string  `json:"heading_id"`
+	Heading     string  `json:"heading"`
+	Tags        string  `json:"tags"`
+}
+
+// OpenDB locates the Things database.
+// THINGS_DB_PATH overrides the default Group Containers location.
+func OpenDB() (*DB, error) {
+	if path := os.Getenv("THINGS_DB_PATH"); path != "" {
+		if _, err := os.Stat(path); err != nil {
+			return nil, fmt.Errorf("database not found at %s: %w", path, err)
+		}
+		return &DB{Path: path, sort: defaultSort}, nil
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return nil, err
+	}
+
+	container := filepath.Join(home, "Library", "Group Containers", "JLMPQHK86H.com.culturedcode.ThingsMac")
+	patterns := []string{
+		filepath.Join(container, "ThingsData-*", "Things Database.thingsdatabase", "main.sqlite"),
+		filepath.Join(container, "Things Database.thingsdatabase", "main.sqlite"),
+	}
+	for _, pattern := range patterns {
+		matches, _ := filepath.Glob(pattern)
+		if len(matches) > 0 {
+			return &DB{Path: matches[0], sort: defaultSort}, nil
+		}
+	}
+
+	return nil, fmt.Errorf("Things database not found (set THINGS_DB_PATH to override)")
+}
+
+// query runs a read-only SQL statement and decodes the JSON rows into dest.
+func (db *DB) query(sql string, dest interface{}) error {
+	done := status.beginQuery(sql)
+	defer done()
+
+	cmd := exec.Command("sqlite3", "-readonly", "-json", db.Path, sql)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	out, err := cmd.Output()
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return fmt.Errorf("database query failed: %s", msg)
+		}
+		return fmt.Errorf("database query failed: %w", err)
+	}
+
+	// sqlite3 prints nothing at all for an empty result set
+	if len(bytes.TrimSpace(out)) == 0 {
+		return nil
+	}
+
+	if err := json.Unmarshal(out, dest); err != nil {
+		return fmt.Errorf("failed to parse database output: %w", err)
+	}
+	return nil
+}
+
+// listItems runs the query behind a list the user reads, such as Today or a
+// search: the order chosen with SetSort replaces orderBy and the page from
+// Paged is applied.
+func (db *DB) listItems(where string, orderBy string) ([]Item, error) {
+	suffix := ""
+	if db.page.Limit > 0 || db.page.Offset > 0 {
+		limit := db.page.Limit
+		if limit <= 0 {
+			limit = -1
+		}
+		suffix = fmt.Sprintf(" LIMIT %d OFFSET %d", limit, db.page.Offset)
+	}
+	return db.selectItems(where, db.sort.orderBy(orderBy), suffix)
+}
+
+// queryItems runs an item query with the given WHERE clause and ordering.
+// Lookups and the queries other code walks in app order use it, so neither
+// --sort nor the page changes what they return.
+func (db *DB) queryItems(where string, orderBy string) ([]Item, error) {
+	return db.selectItems(where, orderBy, "")
+}
+
+// selectItems reads the items matching where in the given order, with suffix
+// appended to the query.
+func (db *DB) selectItems(where, orderBy, suffix string) ([]Item, error) {
+	sql := fmt.Sprintf("SELECT %s FROM %s WHERE %s", itemColumns, itemJoins, where)
+	if orderBy != "" {
+		sql += " ORDER BY " + orderBy
+	}
+	sql += suffix
+
+	var rows []itemRow
+	if err := db.query(sql, &rows); err != nil {
+		return nil, err
+	}
+
+	items := make([]Item, 0, len(rows))
+	for _, row := range rows {
+		items = append(items, row.toItem())
+	}
+	return items, nil
+}
+
+// PageOf returns the entries of a list read or built without the page that
+// fall in it, for lists filtered or grouped after the query.
+func PageOf[T any](page Page, entries []T) []T {
+	if page.Offset >= len(entries) {
+		return []T{}
+	}
+	entries = entries[page.Offset:]
+	if page.Limit > 0 && page.Limit < len(entries) {
+		entries = entries[:page.Limit]
+	}
+	return entries
+}
+
+// countItems returns the number of items matching a WHERE clause without
+// reading them.
+func (db *DB) countItems(where string) (int, error) {
+	var rows []struct {
+		Count int `json:"count"`
+	}
+	if err := db.query(fmt.Sprintf("SELECT COUNT(*) AS count FROM %s WHERE %s", itemJoins, where), &rows); err != nil {
+		return 0, err
+	}
+	if len(rows) == 0 {
+		return 0, nil
+	}
+	return rows[0].Count, nil
+}
+
+// FindItem returns the to-do, project, or heading with the given ID or @alias.
+func (db *DB) FindItem(id string) (*Item, error) {
+	id, err := ResolveAlias(id)
+	if err != nil {
+		return nil, err
+	}
+	items, err := db.queryItems(fmt.Sprintf("t.uuid = %s AND t.trashed = 0", sqlQuote(id)), "")
+	if err != nil {
+		return nil, err
+	}
+	if len(items) == 0 {
+		return nil, ErrNotFound
+	}
+	return &items[0], nil
+}
+
+// FindProject returns the open project matching ref by ID, @alias, or title.
+// Titles match without regard to case, diacritics, or emoji prefixes.
+func (db *DB) FindProject(ref string) (*Item, error) {
+	ref, err := ResolveAlias(ref)
+	if err != nil {
+		return nil, err
+	}
+	where := fmt.Sprintf("t.type = 1 AND t.trashed = 0 AND t.status = 0 AND (t.uuid = %s OR t.title = %s COLLATE NOCASE)", sqlQuote(ref), sqlQuote(ref))
+	items, err := db.queryItems(where, "t.uuid = "+sqlQuote(ref)+" DESC")
+	if err != nil {
+		return nil, err
+	}
+	if len(items) > 0 {
+		return &items[0], nil
+	}
+
+	projects, err := db.queryItems("t.type = 1 AND t.trashed = 0 AND t.status = 0", "")
+	if err != nil {
+		return nil, err
+	}
+	for i := range projects {
+		if util.NamesMatch(projects[i].Title, ref) {
+			return &projects[i], nil
+		}
+	}
+	return nil, ErrNotFound
+}
+
+// FindHeading returns the heading with the given ID or title inside a project.
+func (db *DB) FindHeading(projectID, ref string) (*Item, error) {
+	ref, err := ResolveAlias(ref)
+	if err != nil {
+		return nil, err
+	}
+	where := fmt.Sprintf("t.type = 2 AND t.trashed = 0 AND t.project = %s AND (t.uuid = %s OR t.title = %s COLLATE NOCASE)", sqlQuote(projectID), sqlQuote(ref), sqlQuote(ref))
+	items, err := db.queryItems(where, "")
+	if err != nil {
+		return nil, err
+	}
+	if len(items) == 0 {
+		return nil, ErrNotFound
+	}
+	return &items[0], nil
+}
+
+// FindArea returns the area matching ref by ID, @alias, or title.
+// Titles match without regard to case, diacritics, or emoji prefixes.
+func (db *DB) FindArea(ref string) (*Area, error) {
+	ref, err := ResolveAlias(ref)
+	if err != nil {
+		return nil, err
+	}
+	areas, err := db.ListAreas()
+	if err != nil {
+		return nil, err
+	}
+	for i := range areas {
+		if areas[i].ID == ref || strings.EqualFold(areas[i].Title, ref) {
+			return &areas[i], nil
+		}
+	}
+	for i := range areas {
+		if util.NamesMatch(areas[i].Title, ref) {
+			return &areas[i], nil
+		}
+	}
+	return nil, ErrNotFound
+}
+
+// ListAreas returns all areas ordered as they appear in the app.
+func (db *DB) ListAreas() ([]Area, error) {
+	var areas []Area
+	if err := db.query(`SELECT uuid AS id, title FROM TMArea ORDER BY "index"`, &areas); err != nil {
+		return nil, err
+	}
+	return areas, nil
+}
+
+// ScheduledBefore returns open to-dos whose start date is earlier than date.
+// Things shows these in Today even though they were planned for an earlier day.
+func (db *DB) ScheduledBefore(date time.Time) ([]Item, error) {
+	where := fmt.Sprintf("t.type = 0 AND t.trashed = 0 AND t.status = 0 AND t.start = 1 AND t.startDate IS NOT NULL AND t.startDate < %d", encodeThingsDate(date))
+	return db.listItems(where, "t.todayIndex")
+}
+
+// Today returns the open to-dos and projects in the Today list for date,
+// with the This Evening section last.
+func (db *DB) Today(date time.Time) ([]Item, error) {
+	return db.listItems(todayWhere(date), "t.startBucket, t.todayIndex")
+}
+
+// TodayCount returns the number of items Today would return for date.
+func (db *DB) TodayCount(date time.Time) (int, error) {
+	return db.countItems(todayWhere(date))
+}
+
+// Evening returns the items of Today in the This Evening section for date.
+func (db *DB) Evening(date time.Time) ([]Item, error) {
+	return db.listItems(eveningWhere(date), "t.todayIndex")
+}
+
+// EveningCount returns the number of items Evening would return for date.
+func (db *DB) EveningCount(date time.Time) (int, error) {
+	return db.countItems(eveningWhere(date))
+}
+
+// eveningWhere selects the items of Today in This Evening for date.
+func eveningWhere(date time.Time) string {
+	return todayWhere(date) + " AND t.startBucket = 1"
+}
+
+// todayWhere selects the open to-dos and projects in Today for date.
+func todayWhere(date time.Time) string {
+	return fmt.Sprintf("t.type IN (0, 1) AND t.trashed = 0 AND t.status = 0 AND t.start = 1 AND t.startDate IS NOT NULL AND t.startDate <= %d", encodeThingsDate(date))
+}
+
+// ScheduledOn returns the open to-dos and projects scheduled for exactly date,
+// in Today order with the This Evening section last.
+func (db *DB) ScheduledOn(date time.Time) ([]Item, error) {
+	where := fmt.Sprintf("t.type IN (0, 1) AND t.trashed = 0 AND t.status = 0 AND t.startDate = %d", encodeThingsDate(date))
+	return db.queryItems(where, "t.startBucket, t.todayIndex")
+}
+
+// ScheduledOrDueBetween returns the open to-dos and projects whose start date
+// or deadline falls from start through end inclusive, in date order.
+func (db *DB) ScheduledOrDueBetween(start, end time.Time) ([]Item, error) {
+	from, to := encodeThingsDate(start), encodeThingsDate(end)
+	where := fmt.Sprintf("t.type IN (0, 1) AND t.trashed = 0 AND t.status = 0 AND ((t.startDate BETWEEN %d AND %d) OR (t.deadline BETWEEN %d AND %d))", from, to, from, to)
+	return db.listItems(where, "MIN(COALESCE(t.startDate, t.deadline), COALESCE(t.deadline, t.startDate)), t.startBucket, t.todayIndex")
+}
+
+// Inbox returns the open to-dos in the Inbox in their app order.
+func (db *DB) Inbox() ([]Item, error) {
+	return db.listItems(inboxWhere, `t."index"`)
+}
+
+// InboxCount returns the number of open to-dos in the Inbox.
+func (db *DB) InboxCount() (int, error) {
+	return db.countItems(inboxWhere)
+}
+
+// inboxWhere selects the open to-dos in the Inbox
+const inboxWhere = `t.type = 0 AND t.trashed = 0 AND t.status = 0 AND t.start = 0 AND t.startDate IS NULL`
+
+// Upcoming returns the open to-dos and projects scheduled after date,
+// soonest first.
+func (db *DB) Upcoming(date time.Time) ([]Item, error) {
+	return db.listItems(upcomingWhere(date), "t.startDate, t.todayIndex")
+}
+
+// UpcomingCount returns the number of items Upcoming would return for date.
+func (db *DB) UpcomingCount(date time.Time) (int, error) {
+	return db.countItems(upcomingWhere(date))
+}
+
+// upcomingWhere selects the open to-dos and projects scheduled after date.
+func upcomingWhere(date time.Time) string {
+	return fmt.Sprintf("t.type IN (0, 1) AND t.trashed = 0 AND t.status = 0 AND t.startDate > %d", encodeThingsDate(date))
+}
+
+// Logbook returns the completed and canceled to-dos and projects, most
+// recently finished first.
+func (db *DB) Logbook() ([]Item, error) {
+	return db.listItems(logbookWhere, "t.stopDate DESC, t.creationDate DESC")
+}
+
+// LogbookCount returns the number of items in the Logbook.
+func (db *DB) LogbookCount() (int, error) {
+	return db.countItems(logbookWhere)
+}
+
+// logbookWhere selects the completed and canceled to-dos and projects
+const logbookWhere = `t.type IN (0, 1) AND t.trashed = 0 AND t.status IN (2, 3)`
+
+// ProjectContents returns the headings of a project and the to-dos in it,
+// including those under headings, in app order. Canceled to-dos are left out.
+func (db *DB) ProjectContents(projectID string) ([]Item, error) {
+	id := sqlQuote(projectID)
+	where := fmt.Sprintf(`t.trashed = 0 AND ((t.type = 2 AND t.project = %s) OR (t.type = 0 AND t.status != 2 AND (t.project = %s OR h.project = %s)))`, id, id, id)
+	return db.queryItems(where, `t."index"`)
+}
+
+// ProjectOutline returns the headings of a project and all of its to-dos,
+// including completed and canceled ones, in app order.
+func (db *DB) ProjectOutline(projectID string) ([]Item, error) {
+	id := sqlQuote(projectID)
+	where := fmt.Sprintf(`t.trashed = 0 AND ((t.type = 2 AND t.project = %s) OR (t.type = 0 AND (t.project = %s OR h.project = %s)))`, id, id, id)
+	return db.queryItems(where, `t."index"`)
+}
+
+// CompletedInProject returns the completed and canceled to-dos of a project,
+// including those under headings, most recently finished first.
+func (db *DB) CompletedInProject(projectID string) ([]Item, error) {
+	id := sqlQuote(projectID)
+	where := fmt.Sprintf(`t.type = 0 AND t.trashed = 0 AND t.status IN (2, 3) AND (t.project = %s OR h.project = %s)`, id, id)
+	return db.queryItems(where, "t.stopDate DESC")
+}
+
+// AllItems returns every to-do and project outside the Trash, including
+// completed and canceled ones from the Logbook.
+func (db *DB) AllItems() ([]Item, error) {
+	return db.queryItems(`t.type IN (0, 1) AND t.trashed = 0`, "")
+}
+
+// OpenProjects returns the open projects in app order.
+func (db *DB) OpenProjects() ([]Item, error) {
+	return db.queryItems(`t.type = 1 AND t.trashed = 0 AND t.status = 0`, `t."index"`)
+}
+
+// OpenItems returns every open to-do and project outside the Trash.
+func (db *DB) OpenItems() ([]Item, error) {
+	return db.listItems(`t.type IN (0, 1) AND t.trashed = 0 AND t.status = 0`, "")
+}
+
+// ListTags returns all tags ordered as they appear in the app.
+func (db *DB) ListTags() ([]Tag, error) {
+	var tags []Tag
+	if err := db.query(`SELECT uuid AS id, title FROM TMTag ORDER BY "index"`, &tags); err != nil {
+		return nil, err
+	}
+	return tags, nil
+}
+
+// TrashCount returns the number of to-dos and projects in the Trash.
+func (db *DB) TrashCount() (int, error) {
+	return db.countItems("t.type IN (0, 1) AND t.trashed = 1")
+}
+
+// TagUsage returns all tags ordered as they appear in the app, each with the
+// number of to-dos and projects outside the Trash that carry it, and how many
+// of those are open.
+func (db *DB) TagUsage() ([]TagUsage, error) {
+	tags := []TagUsage{}
+	err := db.query(`SELECT tg.uuid AS id, tg.title, p.title AS parent,
+		COUNT(t.uuid) AS items, COALESCE(SUM(t.status = 0), 0) AS open
+		FROM TMTag tg
+		LEFT JOIN TMTag p ON p.uuid = tg.parent
+		LEFT JOIN TMTaskTag tt ON tt.tags = tg.uuid
+		LEFT JOIN TMTask t ON t.uuid = tt.tasks AND t.trashed = 0 AND t.type IN (0, 1)
+		GROUP BY tg.uuid ORDER BY tg."index"`, &tags)
+	if err != nil {
+		return nil, err
+	}
+	return tags, nil
+}
+
+// Checklist returns the checklist of a to-do in display order, numbered from 1.
+func (db *DB) Checklist(taskID string) ([]ChecklistItem, error) {
+	var rows []struct {
+		ID     string `json:"id"`
+		Title  string `json:"title"`
+		Status int    `json:"status"`
+	}
+	sql := `SELECT uuid AS id, title, status FROM TMChecklistItem
+WHERE task = ` + sqlQuote(taskID) + ` ORDER BY "index"`
+	if err := db.query(sql, &rows); err != nil {
+		return nil, err
+	}
+
+	items := make([]ChecklistItem, len(rows))
+	for i, row := range rows {
+		items[i] = ChecklistItem{
+			Index:     i + 1,
+			ID:        row.ID,
+			Title:     row.Title,
+			Completed: row.Status == 3,
+			Canceled:  row.Status == 2,
+		}
+	}
+	return items, nil
+}
+
+// toItem converts a raw database row into an Item.
+func (r itemRow) toItem() Item {
+	item := Item{
+		ID:          r.ID,
+		Type:        itemTypeName(r.Type),
+		Title:       r.Title,
+		Notes:       r.Notes,
+		Status:      itemStatusName(r.Status),
+		StartDate:   decodeThingsDate(r.StartDate),
+		Deadline:    decodeThingsDate(r.Deadline),
+		ProjectID:   r.ProjectID,
+		Project:     r.Project,
+		AreaID:      r.AreaID,
+		Area:        r.Area,
+		HeadingID:   r.HeadingID,
+		Heading:     r.Heading,
+		CreatedAt:   formatTimestamp(r.Created),
+		ModifiedAt:  formatTimestamp(r.Modified),
+		CompletedAt: formatTimestamp(r.Stopped),
+		URL:         ShowURL(r.ID),
+	}
+
+	if r.Tags != "" {
+		item.Tags = strings.Split(r.Tags, "\x1f")
+	}
+
+	item.When = whenBucket(r.Start, item.StartDate, r.StartBucket)
+	if item.Status == "canceled" {
+		item.CancelReason = CancelReason(item.Notes)
+	}
+	return item
+}
+
+// whenBucket derives the Things list an item appears in from its start fields.
+// Today items in the This Evening section (startBucket 1) report "evening".
+func whenBucket(start int, startDate string, startBucket int) string {
+	today := util.Now().Format(util.DateLayout)
+	switch {
+	case startDate != "" && startDate <= today && startBucket == 1:
+		return "evening"
+	case startDate != "" && startDate <= today:
+		return "today"
+	case startDate != "":
+		return "upcoming"
+	case start == 0:
+		return "inbox"
+	case start == 2:
+		return "someday"
+	default:
+		return "anytime"
+	}
+}
+
+func itemTypeName(t int) string {
+	switch t {
+	case 1:
+		return "project"
+	case 2:
+		return "heading"
+	default:
+		return "to-do"
+	}
+}
+
+func itemStatusName(s int) string {
+	switch s {
+	case 2:
+		return "canceled"
+	case 3:
+		return "completed"
+	default:
+		return "open"
+	}
+}
+
+// decodeThingsDate unpacks the YYYYYYYYYYYMMMMDDDDD0000000 bit layout Things
+// uses for startDate and deadline columns.
+func decodeThingsDate(v int64) string {
+	if v == 0 {
+		return ""
+	}
+	year := v >> 16
+	month := (v >> 12) & 0xF
+	day := (v >> 7) & 0x1F
+	return fmt.Sprintf("%04d-%02d-%02d", year, month, day)
+}
+
+// encodeThingsDate packs a date into the integer format used by startDate and deadline.
+func encodeThingsDate(t time.Time) int64 {
+	return int64(t.Year())<<16 | int64(t.Month())<<12 | int64(t.Day())<<7
+}
+
+// formatTimestamp converts a Unix timestamp column into RFC 3339 in the
+// configured timezone.
+func formatTimestamp(v float64) string {
+	if v == 0 {
+		return ""
+	}
+	return time.Unix(int64(v), 0).In(util.Location()).Format(time.RFC3339)
+}
+
+// sqlQuote returns s as a single-quoted SQL string literal.
+func sqlQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
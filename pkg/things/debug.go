@@ -0,0 +1,62 @@
+package things
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// debugLog receives verbose diagnostics; nil turns them off
+var (
+	debugMu  sync.Mutex
+	debugLog io.Writer
+)
+
+// SetDebugLog sends diagnostics about each action to w: the URL with the auth
+// token redacted, the callback port, timings, and the raw callback
+// parameters. A nil writer turns them off.
+func SetDebugLog(w io.Writer) {
+	debugMu.Lock()
+	defer debugMu.Unlock()
+	debugLog = w
+}
+
+// debugf writes one diagnostic line when a debug log is set.
+func debugf(format string, args ...interface{}) {
+	debugMu.Lock()
+	defer debugMu.Unlock()
+	if debugLog == nil {
+		return
+	}
+	fmt.Fprintf(debugLog, "[things] "+format+"\n", args...)
+}
+
+// debugURL logs the URL for an action with the auth token redacted.
+func (c *Client) debugURL(action string, params map[string]string) {
+	debugf("url: %s", c.buildThingsURL(action, redactParams(params)))
+}
+
+// debugCallback logs the raw parameters of a callback and how long it took.
+func debugCallback(response map[string]string, started time.Time) {
+	keys := make([]string, 0, len(response))
+	for key := range response {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	pairs := make([]string, len(keys))
+	for i, key := range keys {
+		pairs[i] = key + "=" + response[key]
+	}
+	debugf("callback after %s: %s", time.Since(started).Round(time.Millisecond), strings.Join(pairs, " "))
+}
+
+// progress reports a step of an action to the Progress callback, if any.
+func (o ExecuteOptions) progress(format string, args ...interface{}) {
+	if o.Progress != nil {
+		o.Progress(fmt.Sprintf(format, args...))
+	}
+}
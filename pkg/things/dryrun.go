@@ -0,0 +1,65 @@
+package things
+
+import "encoding/json"
+
+// dryRun makes every new client build its URLs without opening them
+var dryRun bool
+
+// SetDryRun turns dry-run mode on or off for clients created afterwards.
+func SetDryRun(on bool) {
+	dryRun = on
+}
+
+// DryRunError is returned by Execute in dry-run mode in place of running the
+// action. It carries the URL that would have been opened, with the auth
+// token redacted, or for changes made through AppleScript, the script that
+// would have run.
+type DryRunError struct {
+	Action string
+	URL    string
+	Params map[string]string
+	Script string
+}
+
+func (e *DryRunError) Error() string {
+	if e.URL == "" {
+		return "dry run: " + e.Action + " (AppleScript)"
+	}
+	return "dry run: " + e.URL
+}
+
+// Payload returns the decoded json payload of a json action, or nil for
+// other actions.
+func (e *DryRunError) Payload() interface{} {
+	data, ok := e.Params["data"]
+	if e.Action != "json" || !ok {
+		return nil
+	}
+	var payload interface{}
+	if err := json.Unmarshal([]byte(data), &payload); err != nil {
+		return data
+	}
+	return payload
+}
+
+// redactParams returns a copy of params with the auth token hidden.
+func redactParams(params map[string]string) map[string]string {
+	redacted := make(map[string]string, len(params))
+	for key, value := range params {
+		if key == "auth-token" && value != "" {
+			value = "REDACTED"
+		}
+		redacted[key] = value
+	}
+	return redacted
+}
+
+// dryRunResult builds the DryRunError for an action.
+func (c *Client) dryRunResult(action string, params map[string]string) error {
+	redacted := redactParams(params)
+	return &DryRunError{
+		Action: action,
+		URL:    c.buildThingsURL(action, redacted),
+		Params: redacted,
+	}
+}
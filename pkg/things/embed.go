@@ -0,0 +1,85 @@
+package things
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/yourusername/things3-cli/pkg/util"
+)
+
+// embedBatchSize limits how many texts are sent in one embedding request.
+const embedBatchSize = 32
+
+// Embedder computes text embeddings with a local Ollama server.
+type Embedder struct {
+	URL   string
+	Model string
+	http  *http.Client
+}
+
+// NewEmbedder creates an Embedder using the embedding settings from config.
+func NewEmbedder() *Embedder {
+	config, err := util.LoadConfig()
+	if err != nil {
+		config = util.DefaultConfig()
+	}
+	return &Embedder{
+		URL:   strings.TrimRight(config.EmbeddingURL, "/"),
+		Model: config.EmbeddingModel,
+		http:  &http.Client{Timeout: 60 * time.Second},
+	}
+}
+
+// Embed returns one vector per input text, in order.
+func (e *Embedder) Embed(texts []string) ([][]float32, error) {
+	vectors := make([][]float32, 0, len(texts))
+	for start := 0; start < len(texts); start += embedBatchSize {
+		end := start + embedBatchSize
+		if end > len(texts) {
+			end = len(texts)
+		}
+		batch, err := e.embedBatch(texts[start:end])
+		if err != nil {
+			return nil, err
+		}
+		vectors = append(vectors, batch...)
+	}
+	return vectors, nil
+}
+
+func (e *Embedder) embedBatch(texts []string) ([][]float32, error) {
+	body, err := json.Marshal(map[string]interface{}{
+		"model": e.Model,
+		"input": texts,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := e.http.Post(e.URL+"/api/embed", "application/json", bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("embedding server not reachable at %s (is Ollama running?): %w", e.URL, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return nil, fmt.Errorf("embedding request failed: %s: %s", resp.Status, strings.TrimSpace(string(msg)))
+	}
+
+	var result struct {
+		Embeddings [][]float32 `json:"embeddings"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to parse embedding response: %w", err)
+	}
+	if len(result.Embeddings) != len(texts) {
+		return nil, fmt.Errorf("embedding server returned %d vectors for %d texts", len(result.Embeddings), len(texts))
+	}
+	return result.Embeddings, nil
+}
@@ -0,0 +1,73 @@
+package things
+
+import (
+	"encoding/json"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/yourusername/things3-cli/pkg/util"
+)
+
+// systemSoundsDir holds the alert sounds that completion_sound can name directly.
+const systemSoundsDir = "/System/Library/Sounds"
+
+// completionFeedback plays the configured sound and runs the configured
+// shortcut after an action completes items. It runs in the background and
+// never affects the result of the action.
+func (c *Client) completionFeedback(action string, params map[string]string) {
+	if c.CompletionSound == "" && c.CompletionShortcut == "" {
+		return
+	}
+	if !completesItems(action, params) {
+		return
+	}
+
+	if c.CompletionSound != "" {
+		startDetached("afplay", soundPath(c.CompletionSound))
+	}
+	if c.CompletionShortcut != "" {
+		startDetached("shortcuts", "run", c.CompletionShortcut)
+	}
+}
+
+// completesItems reports whether an action marks existing items as completed.
+func completesItems(action string, params map[string]string) bool {
+	switch action {
+	case "update", "update-project":
+		return params["completed"] == "true"
+	case "json":
+		var payload []map[string]interface{}
+		if err := json.Unmarshal([]byte(params["data"]), &payload); err != nil {
+			return false
+		}
+		for _, entry := range payload {
+			attrs, _ := entry["attributes"].(map[string]interface{})
+			if completed, _ := attrs["completed"].(bool); completed && entry["operation"] == "update" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// soundPath resolves a completion sound. A bare name such as "Glass" refers
+// to one of the macOS alert sounds.
+func soundPath(sound string) string {
+	if !strings.ContainsRune(sound, '/') && filepath.Ext(sound) == "" {
+		return filepath.Join(systemSoundsDir, sound+".aiff")
+	}
+	if path, err := util.ExpandHomePath(sound); err == nil {
+		return path
+	}
+	return sound
+}
+
+// startDetached starts a helper without waiting for it, reaping it in the background.
+func startDetached(name string, args ...string) {
+	cmd := exec.Command(name, args...)
+	if err := cmd.Start(); err != nil {
+		return
+	}
+	go cmd.Wait()
+}
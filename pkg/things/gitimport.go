@@ -0,0 +1,153 @@
+package things
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var hunkPattern = regexp.MustCompile(`^@@ -\d+(?:,\d+)? \+(\d+)(?:,\d+)? @@`)
+
+// GitTodo is a marker comment such as TODO or FIXME added by a commit.
+type GitTodo struct {
+	Text    string `json:"text"`
+	File    string `json:"file"`
+	Line    int    `json:"line"`
+	Commit  string `json:"commit"`
+	Subject string `json:"subject"`
+}
+
+// Location returns the file:line position of the comment.
+func (t GitTodo) Location() string {
+	return fmt.Sprintf("%s:%d", t.File, t.Line)
+}
+
+// GitRepoName returns the directory name of the repository containing dir.
+func GitRepoName(dir string) (string, error) {
+	top, err := gitTopLevel(dir)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Base(top), nil
+}
+
+// HarvestGitTodos returns the lines matching pattern that were added by
+// commits in the repository at dir since the given time, oldest first.
+// Lines that no longer appear in the working tree are skipped, and a comment
+// that moved between commits is reported once.
+func HarvestGitTodos(dir string, since time.Time, pattern *regexp.Regexp) ([]GitTodo, error) {
+	top, err := gitTopLevel(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	out, err := runGit(top, "log", "--reverse", "--no-color", "--no-ext-diff", "-p", "-U0",
+		"--since="+since.Format(time.RFC3339), "--pretty=format:commit %H %s")
+	if err != nil {
+		return nil, err
+	}
+
+	var todos []GitTodo
+	seen := make(map[string]bool)
+	current := make(map[string]string)
+	var commit, subject, file string
+	line := 0
+	// inHeader is true between a file's "diff --git" line and its first
+	// hunk, the only place a "+++ " line names the file rather than being
+	// an added line that starts with "++ ".
+	inHeader := false
+
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		text := scanner.Text()
+		switch {
+		case strings.HasPrefix(text, "commit "):
+			commit, subject, _ = strings.Cut(strings.TrimPrefix(text, "commit "), " ")
+			file, inHeader = "", false
+		case strings.HasPrefix(text, "diff --git "):
+			file, inHeader = "", true
+		case inHeader && strings.HasPrefix(text, "+++ "):
+			file = ""
+			if path, ok := strings.CutPrefix(text, "+++ b/"); ok {
+				file = path
+			}
+		case strings.HasPrefix(text, "@@ "):
+			inHeader = false
+			if match := hunkPattern.FindStringSubmatch(text); match != nil {
+				line, _ = strconv.Atoi(match[1])
+			}
+		case strings.HasPrefix(text, "+") && file != "":
+			added := text[1:]
+			if loc := pattern.FindStringIndex(added); loc != nil {
+				todo := GitTodo{
+					Text:    cleanTodoText(added[loc[0]:]),
+					File:    file,
+					Line:    line,
+					Commit:  commit,
+					Subject: subject,
+				}
+				key := todo.File + "\x00" + todo.Text
+				if !seen[key] && stillPresent(top, file, strings.TrimSpace(added), current) {
+					seen[key] = true
+					todos = append(todos, todo)
+				}
+			}
+			line++
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read git log: %w", err)
+	}
+	return todos, nil
+}
+
+// cleanTodoText strips trailing comment closers and whitespace from a marker comment.
+func cleanTodoText(text string) string {
+	text = strings.TrimSpace(text)
+	for _, closer := range []string{"*/", "-->", "#}", "%}"} {
+		text = strings.TrimSpace(strings.TrimSuffix(text, closer))
+	}
+	return text
+}
+
+// stillPresent reports whether line still appears in the working tree copy
+// of file. File contents are cached in current.
+func stillPresent(top, file, line string, current map[string]string) bool {
+	content, ok := current[file]
+	if !ok {
+		data, _ := os.ReadFile(filepath.Join(top, file))
+		content = string(data)
+		current[file] = content
+	}
+	return strings.Contains(content, line)
+}
+
+func gitTopLevel(dir string) (string, error) {
+	out, err := runGit(dir, "rev-parse", "--show-toplevel")
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+func runGit(dir string, args ...string) ([]byte, error) {
+	cmd := exec.Command("git", append([]string{"-C", dir}, args...)...)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("git %s: %s", args[0], msg)
+		}
+		return nil, fmt.Errorf("git %s: %w", args[0], err)
+	}
+	return out, nil
+}
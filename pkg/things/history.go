@@ -0,0 +1,74 @@
+package things
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/yourusername/things3-cli/pkg/util"
+)
+
+// lastCreatedFile holds the IDs created by the most recent creating action.
+const lastCreatedFile = "last_created.json"
+
+// CreatedRecord describes the items created by one action, for undo.
+type CreatedRecord struct {
+	Action    string    `json:"action"`
+	IDs       []string  `json:"ids"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// recordCreated remembers the IDs created by an action so they can be undone.
+// Actions that may have modified existing items are not recorded, since
+// undoing them would trash items the user already had.
+func recordCreated(action string, params map[string]string, result ActionResult) {
+	if !createsOnly(action, params) {
+		return
+	}
+
+	ids := result.ThingsIDs
+	if result.ThingsID != "" {
+		ids = append([]string{result.ThingsID}, ids...)
+	}
+	if len(ids) == 0 {
+		return
+	}
+
+	// Undo history is best-effort and must never fail the action itself
+	_ = util.SaveState(lastCreatedFile, CreatedRecord{Action: action, IDs: ids, CreatedAt: time.Now()})
+}
+
+// createsOnly reports whether an action only creates new items.
+func createsOnly(action string, params map[string]string) bool {
+	switch action {
+	case "add", "add-project":
+		return true
+	case "json":
+		var payload []map[string]interface{}
+		if err := json.Unmarshal([]byte(params["data"]), &payload); err != nil {
+			return false
+		}
+		for _, entry := range payload {
+			if op, _ := entry["operation"].(string); op != "" && op != "create" {
+				return false
+			}
+		}
+		return true
+	default:
+		return false
+	}
+}
+
+// LastCreated returns the most recently recorded creation, or nil if there is none.
+func LastCreated() (*CreatedRecord, error) {
+	var record CreatedRecord
+	found, err := util.LoadState(lastCreatedFile, &record)
+	if err != nil || !found {
+		return nil, err
+	}
+	return &record, nil
+}
+
+// ClearLastCreated forgets the recorded creation after it has been undone.
+func ClearLastCreated() error {
+	return util.ClearState(lastCreatedFile)
+}
@@ -0,0 +1,152 @@
+package things
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var (
+	markdownHeadingPattern = regexp.MustCompile(`^(#{1,6})\s+(.*?)\s*#*\s*$`)
+	markdownBulletPattern  = regexp.MustCompile(`^([ \t]*)[-*+]\s+(?:\[([ xX])\]\s*)?(.*)$`)
+)
+
+// OutlineSummary counts what a markdown outline turned into.
+type OutlineSummary struct {
+	Title          string `json:"title"`
+	Headings       int    `json:"headings"`
+	ToDos          int    `json:"todos"`
+	ChecklistItems int    `json:"checklist_items"`
+}
+
+// outlineSection is the text found between a heading and its first to-do.
+type outlineSection struct {
+	title string
+	lines []string
+}
+
+// ParseMarkdownOutline builds a project from a markdown outline:
+//
+//   - headings become project headings
+//   - top-level bullets ("- [ ] task", "- [x] done", or "- task") become to-dos
+//   - bullets nested under a to-do become its checklist
+//   - other text becomes notes of the preceding to-do; text before the
+//     first heading or to-do becomes the project notes
+//   - text between a heading and its first to-do becomes notes of the
+//     heading. Things headings have no notes of their own, so these are
+//     added to the project notes under the heading's title
+//
+// A single level-one heading used as a title above "##" sections becomes the
+// project title; otherwise the project is titled fallbackTitle.
+func ParseMarkdownOutline(text, fallbackTitle string) (JSONOperation, OutlineSummary, error) {
+	lines := strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n")
+
+	// Decide whether the level-one heading is the document title.
+	titleLevel := 0
+	var levelOne, deeper int
+	for _, line := range lines {
+		if match := markdownHeadingPattern.FindStringSubmatch(line); match != nil {
+			if len(match[1]) == 1 {
+				levelOne++
+			} else {
+				deeper++
+			}
+		}
+	}
+	if levelOne == 1 && deeper > 0 {
+		titleLevel = 1
+	}
+
+	summary := OutlineSummary{Title: fallbackTitle}
+	var projectNotes []string
+	var headingNotes []outlineSection
+	var items []JSONOperation
+	var todo map[string]interface{}
+	var todoNotes []string
+
+	flush := func() {
+		if todo == nil {
+			return
+		}
+		if len(todoNotes) > 0 {
+			todo["notes"] = strings.TrimSpace(strings.Join(todoNotes, "\n"))
+		}
+		todo, todoNotes = nil, nil
+	}
+
+	for _, line := range lines {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+
+		if match := markdownHeadingPattern.FindStringSubmatch(line); match != nil {
+			flush()
+			if len(match[1]) == titleLevel {
+				summary.Title = match[2]
+				continue
+			}
+			items = append(items, JSONOperation{Type: "heading", Attributes: map[string]interface{}{"title": match[2]}})
+			headingNotes = append(headingNotes, outlineSection{title: match[2]})
+			summary.Headings++
+			continue
+		}
+
+		if match := markdownBulletPattern.FindStringSubmatch(line); match != nil {
+			indent, box, title := match[1], match[2], strings.TrimSpace(match[3])
+			if title == "" {
+				continue
+			}
+			completed := box == "x" || box == "X"
+
+			if indent != "" && todo != nil {
+				checklist, _ := todo["checklist-items"].([]JSONOperation)
+				todo["checklist-items"] = append(checklist, NewChecklistEntry(title, completed))
+				summary.ChecklistItems++
+				continue
+			}
+
+			flush()
+			todo = map[string]interface{}{"title": title}
+			if completed {
+				todo["completed"] = true
+			}
+			items = append(items, JSONOperation{Type: "to-do", Attributes: todo})
+			summary.ToDos++
+			continue
+		}
+
+		switch {
+		case todo != nil:
+			todoNotes = append(todoNotes, strings.TrimSpace(line))
+		case len(headingNotes) > 0:
+			section := &headingNotes[len(headingNotes)-1]
+			section.lines = append(section.lines, strings.TrimSpace(line))
+		default:
+			projectNotes = append(projectNotes, strings.TrimSpace(line))
+		}
+	}
+	flush()
+
+	if summary.ToDos == 0 && summary.Headings == 0 {
+		return JSONOperation{}, summary, fmt.Errorf("no headings or to-do bullets found")
+	}
+	if strings.TrimSpace(summary.Title) == "" {
+		return JSONOperation{}, summary, fmt.Errorf("project title is empty")
+	}
+
+	notes := []string{}
+	if len(projectNotes) > 0 {
+		notes = append(notes, strings.Join(projectNotes, "\n"))
+	}
+	for _, section := range headingNotes {
+		if len(section.lines) > 0 {
+			notes = append(notes, section.title+":\n"+strings.Join(section.lines, "\n"))
+		}
+	}
+
+	attrs := map[string]interface{}{"title": summary.Title, "items": items}
+	if len(notes) > 0 {
+		attrs["notes"] = strings.Join(notes, "\n\n")
+	}
+	return JSONOperation{Type: "project", Attributes: attrs}, summary, nil
+}
@@ -1,5 +1,7 @@
 package things
 
+import "net/url"
+
 // ActionResult represents a normalized Things callback response.
 type ActionResult struct {
 	Action              string            `json:"action"`
@@ -9,3 +11,70 @@ type ActionResult struct {
 	ThingsClientVersion string            `json:"things_client_version,omitempty"`
 	Callback            map[string]string `json:"callback,omitempty"`
 }
+
+// Item represents a to-do, project, or heading read from the Things database.
+type Item struct {
+	ID          string   `json:"id"`
+	Type        string   `json:"type"`
+	Title       string   `json:"title"`
+	Notes       string   `json:"notes,omitempty"`
+	Status      string   `json:"status"`
+	When        string   `json:"when,omitempty"`
+	StartDate   string   `json:"start_date,omitempty"`
+	Deadline    string   `json:"deadline,omitempty"`
+	Tags        []string `json:"tags,omitempty"`
+	ProjectID   string   `json:"project_id,omitempty"`
+	Project     string   `json:"project,omitempty"`
+	AreaID      string   `json:"area_id,omitempty"`
+	Area        string   `json:"area,omitempty"`
+	HeadingID   string   `json:"heading_id,omitempty"`
+	Heading     string   `json:"heading,omitempty"`
+	CreatedAt   string   `json:"created_at,omitempty"`
+	ModifiedAt  string   `json:"modified_at,omitempty"`
+	CompletedAt string   `json:"completed_at,omitempty"`
+	// CancelReason is the reason recorded in the notes of a canceled item
+	CancelReason string `json:"cancel_reason,omitempty"`
+	// URL opens the item in Things; see ShowURL
+	URL string `json:"url,omitempty"`
+}
+
+// ShowURL returns the things:/// link that reveals an item in Things.
+func ShowURL(id string) string {
+	return "things:///show?id=" + url.QueryEscape(id)
+}
+
+// ScoredItem is an item ranked by how closely it matches a query.
+type ScoredItem struct {
+	Item
+	Score float64 `json:"score"`
+}
+
+// Area represents an area of responsibility read from the Things database.
+type Area struct {
+	ID    string `json:"id"`
+	Title string `json:"title"`
+}
+
+// ChecklistItem represents one checklist entry of a to-do.
+type ChecklistItem struct {
+	Index     int    `json:"index"`
+	ID        string `json:"id"`
+	Title     string `json:"title"`
+	Completed bool   `json:"completed"`
+	Canceled  bool   `json:"canceled,omitempty"`
+}
+
+// Tag represents a tag read from the Things database.
+type Tag struct {
+	ID    string `json:"id"`
+	Title string `json:"title"`
+}
+
+// TagUsage is a tag with the number of items that carry it. Parent is the
+// title of the tag it is nested under, if any.
+type TagUsage struct {
+	Tag
+	Parent string `json:"parent,omitempty"`
+	Items  int    `json:"items"`
+	Open   int    `json:"open"`
+}
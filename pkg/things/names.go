@@ -0,0 +1,55 @@
+package things
+
+import (
+	"github.com/yourusername/things3-cli/pkg/util"
+)
+
+// normalizeNameParams rewrites project and area names to the exact titles
+// stored in Things, since the URL scheme only matches titles verbatim.
+// Names that can't be resolved in the database fall back to the configured
+// emoji prefixes. New project titles also receive their configured prefix.
+func normalizeNameParams(action string, params map[string]string) {
+	_, hasList := params["list"]
+	_, hasArea := params["area"]
+	if !hasList && !hasArea && action != "add-project" {
+		return
+	}
+
+	config, err := util.LoadConfig()
+	if err != nil {
+		config = util.DefaultConfig()
+	}
+	db, dbErr := OpenDB()
+
+	if name := params["list"]; name != "" {
+		resolved := ""
+		if dbErr == nil {
+			if project, err := db.FindProject(name); err == nil {
+				resolved = project.Title
+			} else if area, err := db.FindArea(name); err == nil {
+				resolved = area.Title
+			}
+		}
+		if resolved == "" {
+			resolved = util.ApplyNamePrefix(name, config.NamePrefixes)
+		}
+		params["list"] = resolved
+	}
+
+	if name := params["area"]; name != "" {
+		resolved := ""
+		if dbErr == nil {
+			if area, err := db.FindArea(name); err == nil {
+				resolved = area.Title
+			}
+		}
+		if resolved == "" {
+			resolved = util.ApplyNamePrefix(name, config.NamePrefixes)
+		}
+		params["area"] = resolved
+	}
+
+	if action == "add-project" && params["title"] != "" {
+		params["title"] = util.ApplyNamePrefix(params["title"], config.NamePrefixes)
+	}
+}
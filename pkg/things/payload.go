@@ -0,0 +1,55 @@
+package things
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// JSONOperation is a single entry in a Things JSON payload.
+type JSONOperation struct {
+	Type       string                 `json:"type"`
+	Operation  string                 `json:"operation,omitempty"`
+	ID         string                 `json:"id,omitempty"`
+	Attributes map[string]interface{} `json:"attributes"`
+}
+
+// NewUpdateOperation builds an update operation for an existing item.
+// itemType is the Item.Type value ("to-do" or "project").
+func NewUpdateOperation(itemType, id string, attributes map[string]interface{}) JSONOperation {
+	return JSONOperation{
+		Type:       itemType,
+		Operation:  "update",
+		ID:         id,
+		Attributes: attributes,
+	}
+}
+
+// NewChecklistEntry builds a checklist item for a to-do's checklist-items attribute.
+func NewChecklistEntry(title string, completed bool) JSONOperation {
+	return JSONOperation{
+		Type: "checklist-item",
+		Attributes: map[string]interface{}{
+			"title":     title,
+			"completed": completed,
+		},
+	}
+}
+
+// ExistingChecklistEntry builds the checklist-items entry that keeps an
+// existing checklist item as it is, when a checklist is rewritten in full.
+func ExistingChecklistEntry(item ChecklistItem) JSONOperation {
+	entry := NewChecklistEntry(item.Title, item.Completed)
+	if item.Canceled {
+		entry.Attributes["canceled"] = true
+	}
+	return entry
+}
+
+// EncodeJSONPayload serializes operations for the json action's data parameter.
+func EncodeJSONPayload(ops []JSONOperation) (string, error) {
+	data, err := json.Marshal(ops)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode JSON payload: %w", err)
+	}
+	return string(data), nil
+}
@@ -0,0 +1,87 @@
+package things
+
+import (
+	"errors"
+
+	"github.com/yourusername/things3-cli/pkg/util"
+)
+
+// pinnedFile holds the IDs in the working set, in the order they were pinned.
+const pinnedFile = "pinned.json"
+
+type pinState struct {
+	IDs []string `json:"ids"`
+}
+
+// PinnedIDs returns the IDs in the working set.
+func PinnedIDs() ([]string, error) {
+	var state pinState
+	if _, err := util.LoadState(pinnedFile, &state); err != nil {
+		return nil, err
+	}
+	return state.IDs, nil
+}
+
+// Pin adds an ID to the end of the working set and returns the new set.
+// Pinning an ID that is already pinned leaves the set unchanged.
+func Pin(id string) ([]string, error) {
+	ids, err := PinnedIDs()
+	if err != nil {
+		return nil, err
+	}
+	for _, pinned := range ids {
+		if pinned == id {
+			return ids, nil
+		}
+	}
+	ids = append(ids, id)
+	return ids, util.SaveState(pinnedFile, pinState{IDs: ids})
+}
+
+// Unpin removes an ID from the working set, reporting whether it was pinned.
+func Unpin(id string) ([]string, bool, error) {
+	ids, err := PinnedIDs()
+	if err != nil {
+		return nil, false, err
+	}
+
+	kept := make([]string, 0, len(ids))
+	for _, pinned := range ids {
+		if pinned != id {
+			kept = append(kept, pinned)
+		}
+	}
+	if len(kept) == len(ids) {
+		return ids, false, nil
+	}
+	return kept, true, util.SaveState(pinnedFile, pinState{IDs: kept})
+}
+
+// ClearPins empties the working set.
+func ClearPins() error {
+	return util.ClearState(pinnedFile)
+}
+
+// PinnedItems returns the open items in the working set in pin order.
+// Pins whose items were completed, canceled, or deleted are skipped.
+func (db *DB) PinnedItems() ([]Item, error) {
+	ids, err := PinnedIDs()
+	if err != nil {
+		return nil, err
+	}
+
+	items := []Item{}
+	for _, id := range ids {
+		item, err := db.FindItem(id)
+		if errors.Is(err, ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if item.Status == "open" {
+			items = append(items, *item)
+		}
+	}
+	return items, nil
+}
@@ -0,0 +1,279 @@
+package things
+
+import (
+	"fmt"
+	"path"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/yourusername/things3-cli/pkg/util"
+)
+
+// Query is a parsed search query. Plain words must all appear in the title
+// or notes; key:value predicates narrow the search further. Terms may be
+// joined with AND, which is also implied between them, and values containing
+// spaces may be quoted.
+//
+//	status:open|completed|canceled|logged   logged matches completed or canceled
+//	completed:2024-*                         completion date glob (YYYY-MM-DD);
+//	                                         without wildcards it matches a prefix
+//	                                         such as completed:2024-05
+//	project:"Website"                        project title, ID, or @alias
+//	overdue                                  open items whose deadline has passed
+type Query struct {
+	Words     []string
+	Status    string
+	Completed string
+	Project   string
+	When      string
+	Overdue   bool
+}
+
+// queryStatuses maps status predicate values to TMTask status codes.
+var queryStatuses = map[string]string{
+	"open":      "0",
+	"completed": "3",
+	"canceled":  "2",
+	"cancelled": "2",
+	"logged":    "2, 3",
+}
+
+// queryWhens maps when predicate values to the list an item appears in, as
+// reported in its when field. today includes This Evening, as in the app.
+var queryWhens = map[string]string{
+	"inbox":    "inbox",
+	"today":    "today",
+	"evening":  "evening",
+	"tonight":  "evening",
+	"upcoming": "upcoming",
+	"anytime":  "anytime",
+	"someday":  "someday",
+}
+
+// ParseQuery splits a search string into words and predicates.
+func ParseQuery(input string) (Query, error) {
+	fields, err := splitQuery(input)
+	if err != nil {
+		return Query{}, err
+	}
+
+	var q Query
+	for _, field := range fields {
+		switch field {
+		case "AND":
+			continue
+		case "OR", "NOT":
+			return Query{}, fmt.Errorf("%s is not supported; all terms must match", field)
+		}
+
+		if strings.EqualFold(field, "overdue") {
+			q.Overdue = true
+			continue
+		}
+
+		key, value, ok := strings.Cut(field, ":")
+		if !ok || value == "" {
+			q.Words = append(q.Words, field)
+			continue
+		}
+
+		switch strings.ToLower(key) {
+		case "status":
+			value = strings.ToLower(value)
+			if _, known := queryStatuses[value]; !known {
+				return Query{}, fmt.Errorf("unknown status %q (use open, completed, canceled, or logged)", value)
+			}
+			q.Status = value
+		case "completed":
+			if !strings.ContainsAny(value, "*?[") {
+				value += "*"
+			}
+			if _, err := path.Match(value, ""); err != nil {
+				return Query{}, fmt.Errorf("invalid completed pattern %q", value)
+			}
+			q.Completed = value
+		case "project":
+			q.Project = value
+		case "when":
+			when, known := queryWhens[strings.ToLower(value)]
+			if !known {
+				return Query{}, fmt.Errorf("unknown when %q (use inbox, today, evening, upcoming, anytime, or someday)", value)
+			}
+			q.When = when
+		default:
+			q.Words = append(q.Words, field)
+		}
+	}
+	return q, nil
+}
+
+// HasPredicates reports whether the query uses any key:value predicates.
+func (q Query) HasPredicates() bool {
+	return q.Status != "" || q.Completed != "" || q.Project != "" || q.When != "" || q.Overdue
+}
+
+// splitQuery splits input on whitespace, keeping double-quoted text together
+// and removing the quotes.
+func splitQuery(input string) ([]string, error) {
+	var fields []string
+	var field strings.Builder
+	inQuotes, started := false, false
+	for _, r := range input {
+		switch {
+		case r == '"':
+			inQuotes = !inQuotes
+			started = true
+		case !inQuotes && unicode.IsSpace(r):
+			if started {
+				fields = append(fields, field.String())
+				field.Reset()
+				started = false
+			}
+		default:
+			field.WriteRune(r)
+			started = true
+		}
+	}
+	if inQuotes {
+		return nil, fmt.Errorf("unterminated quote in query")
+	}
+	if started {
+		fields = append(fields, field.String())
+	}
+	return fields, nil
+}
+
+// Search returns the to-dos and projects matching a query, most recently
+// completed first. Unlike the search action in the app, this reaches the
+// Logbook. Without a status predicate, completed: implies status:logged and
+// overdue implies status:open.
+func (db *DB) Search(q Query) ([]Item, error) {
+	where, q, err := searchWhere(q)
+	if err != nil {
+		return nil, err
+	}
+
+	if !q.filtersInGo() {
+		return db.listItems(where, "t.stopDate DESC, t.creationDate DESC")
+	}
+
+	// The page can only be cut once the filters below have run
+	unpaged := db.Paged(Page{})
+	items, err := unpaged.listItems(where, "t.stopDate DESC, t.creationDate DESC")
+	if err != nil {
+		return nil, err
+	}
+
+	// Dates are compared in the configured timezone and project titles
+	// matched loosely, so these filters run here rather than in SQL.
+	today := util.Now().Format(util.DateLayout)
+	matched := make([]Item, 0, len(items))
+	for _, item := range items {
+		if q.Overdue && (item.Deadline == "" || item.Deadline >= today) {
+			continue
+		}
+		if q.Project != "" && item.ProjectID != q.Project && !util.NamesMatch(item.Project, q.Project) {
+			continue
+		}
+		if q.Completed != "" {
+			completed, err := time.Parse(time.RFC3339, item.CompletedAt)
+			if err != nil {
+				continue
+			}
+			if ok, _ := path.Match(q.Completed, completed.In(util.Location()).Format(util.DateLayout)); !ok {
+				continue
+			}
+		}
+		matched = append(matched, item)
+	}
+	return PageOf(db.page, matched), nil
+}
+
+// SearchCount returns the number of items Search would return, counting in
+// SQL unless the query needs filters that run in Go.
+func (db *DB) SearchCount(q Query) (int, error) {
+	where, resolved, err := searchWhere(q)
+	if err != nil {
+		return 0, err
+	}
+	if !resolved.filtersInGo() {
+		return db.countItems(where)
+	}
+	items, err := db.Paged(Page{}).Search(q)
+	if err != nil {
+		return 0, err
+	}
+	return len(items), nil
+}
+
+// filtersInGo reports whether the query has predicates that Search applies
+// after reading the items.
+func (q Query) filtersInGo() bool {
+	return q.Completed != "" || q.Project != "" || q.Overdue
+}
+
+// searchWhere returns the WHERE clause for a query, along with the query
+// with its project alias resolved.
+func searchWhere(q Query) (string, Query, error) {
+	conditions := []string{"t.type IN (0, 1)", "t.trashed = 0"}
+
+	status := q.Status
+	if status == "" && q.Completed != "" {
+		status = "logged"
+	}
+	if status == "" && q.Overdue {
+		status = "open"
+	}
+	if status != "" {
+		conditions = append(conditions, "t.status IN ("+queryStatuses[status]+")")
+	}
+
+	if q.Project != "" {
+		project, err := ResolveAlias(q.Project)
+		if err != nil {
+			return "", q, err
+		}
+		conditions = append(conditions, "COALESCE(t.project, h.project) IS NOT NULL")
+		q.Project = project
+	}
+
+	for _, word := range q.Words {
+		like := sqlQuote("%" + escapeLike(word) + "%")
+		conditions = append(conditions, fmt.Sprintf(`(t.title LIKE %s ESCAPE '\' OR t.notes LIKE %s ESCAPE '\')`, like, like))
+	}
+
+	if q.When != "" {
+		conditions = append(conditions, whenCondition(q.When, util.Now()))
+	}
+
+	if q.Overdue {
+		conditions = append(conditions, "t.deadline IS NOT NULL AND t.deadline != 0")
+	}
+	return strings.Join(conditions, " AND "), q, nil
+}
+
+// whenCondition selects the items whose when field, as derived by whenBucket
+// for today, is when.
+func whenCondition(when string, today time.Time) string {
+	date := encodeThingsDate(today)
+	switch when {
+	case "today":
+		return fmt.Sprintf("t.startDate IS NOT NULL AND t.startDate <= %d", date)
+	case "evening":
+		return fmt.Sprintf("t.startDate IS NOT NULL AND t.startDate <= %d AND t.startBucket = 1", date)
+	case "upcoming":
+		return fmt.Sprintf("t.startDate IS NOT NULL AND t.startDate > %d", date)
+	case "inbox":
+		return "t.startDate IS NULL AND t.start = 0"
+	case "someday":
+		return "t.startDate IS NULL AND t.start = 2"
+	default:
+		return "t.startDate IS NULL AND t.start NOT IN (0, 2)"
+	}
+}
+
+// escapeLike escapes the LIKE wildcards in s.
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
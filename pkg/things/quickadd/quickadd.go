@@ -0,0 +1,124 @@
+// Package quickadd parses single-line task descriptions such as
+//
+//	Buy milk #errands @tomorrow !friday //pick up oat milk
+//
+// into the title, tags, schedule, deadline, and notes of a to-do.
+package quickadd
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// Result holds the fields parsed from a quick-add string.
+type Result struct {
+	Title    string   `json:"title"`
+	Notes    string   `json:"notes,omitempty"`
+	When     string   `json:"when,omitempty"`
+	Deadline string   `json:"deadline,omitempty"`
+	Tags     []string `json:"tags,omitempty"`
+}
+
+// ErrEmptyTitle is returned when nothing is left for the title after parsing.
+var ErrEmptyTitle = errors.New("quick-add text has no title")
+
+// notesSeparator matches "//" at the start of the input or after whitespace,
+// so URLs like https://example.com stay part of the title.
+var notesSeparator = regexp.MustCompile(`(^|\s)//`)
+
+// Parse splits input into a Result.
+//
+//	#tag          adds a tag
+//	@when         sets the schedule (today, tomorrow, evening, a date, ...)
+//	!deadline     sets the deadline
+//	//notes       everything after the separator becomes the notes
+//
+// Multi-word values can be quoted (@"next monday") or hyphenated (@next-monday);
+// hyphens in schedule and deadline values are read as spaces unless the value
+// is an ISO date. Later @ and ! tokens override earlier ones.
+func Parse(input string) (Result, error) {
+	var result Result
+
+	text := input
+	if loc := notesSeparator.FindStringIndex(text); loc != nil {
+		result.Notes = strings.TrimSpace(text[loc[1]:])
+		text = text[:loc[0]]
+	}
+
+	var titleWords []string
+	for _, token := range tokenize(text) {
+		if len(token) > 1 {
+			value := unquote(token[1:])
+			switch token[0] {
+			case '#':
+				if value != "" {
+					result.Tags = append(result.Tags, value)
+					continue
+				}
+			case '@':
+				if value != "" {
+					result.When = dehyphenate(value)
+					continue
+				}
+			case '!':
+				if value != "" {
+					result.Deadline = dehyphenate(value)
+					continue
+				}
+			}
+		}
+		titleWords = append(titleWords, token)
+	}
+
+	result.Title = strings.Join(titleWords, " ")
+	if result.Title == "" {
+		return result, ErrEmptyTitle
+	}
+	return result, nil
+}
+
+// tokenize splits on whitespace while keeping double-quoted sections together.
+func tokenize(text string) []string {
+	var tokens []string
+	var current strings.Builder
+	inQuotes := false
+
+	for _, r := range text {
+		switch {
+		case r == '"':
+			inQuotes = !inQuotes
+			current.WriteRune(r)
+		case unicode.IsSpace(r) && !inQuotes:
+			if current.Len() > 0 {
+				tokens = append(tokens, current.String())
+				current.Reset()
+			}
+		default:
+			current.WriteRune(r)
+		}
+	}
+	if current.Len() > 0 {
+		tokens = append(tokens, current.String())
+	}
+	return tokens
+}
+
+func unquote(value string) string {
+	if len(value) >= 2 && strings.HasPrefix(value, `"`) && strings.HasSuffix(value, `"`) {
+		return value[1 : len(value)-1]
+	}
+	return value
+}
+
+// isoDate matches YYYY-MM-DD, optionally followed by an @time reminder.
+var isoDate = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}`)
+
+// dehyphenate turns "next-monday" into "next monday" while leaving dates intact.
+func dehyphenate(value string) string {
+	if isoDate.MatchString(value) {
+		return value
+	}
+	return strings.ReplaceAll(value, "-", " ")
+}
@@ -0,0 +1,126 @@
+package quickadd
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  Result
+	}{
+		{
+			name:  "title only",
+			input: "Buy milk",
+			want:  Result{Title: "Buy milk"},
+		},
+		{
+			name:  "tags",
+			input: "Buy milk #errands #home",
+			want:  Result{Title: "Buy milk", Tags: []string{"errands", "home"}},
+		},
+		{
+			name:  "quoted tag",
+			input: `Call plumber #"house stuff"`,
+			want:  Result{Title: "Call plumber", Tags: []string{"house stuff"}},
+		},
+		{
+			name:  "when",
+			input: "Buy milk @tomorrow",
+			want:  Result{Title: "Buy milk", When: "tomorrow"},
+		},
+		{
+			name:  "hyphenated when",
+			input: "Review budget @next-monday",
+			want:  Result{Title: "Review budget", When: "next monday"},
+		},
+		{
+			name:  "quoted when",
+			input: `Review budget @"next monday"`,
+			want:  Result{Title: "Review budget", When: "next monday"},
+		},
+		{
+			name:  "later when wins",
+			input: "Buy milk @today @tomorrow",
+			want:  Result{Title: "Buy milk", When: "tomorrow"},
+		},
+		{
+			name:  "deadline",
+			input: "File taxes !friday",
+			want:  Result{Title: "File taxes", Deadline: "friday"},
+		},
+		{
+			name:  "hyphenated deadline",
+			input: "File taxes !end-of-month",
+			want:  Result{Title: "File taxes", Deadline: "end of month"},
+		},
+		{
+			name:  "quoted deadline",
+			input: `File taxes !"next friday"`,
+			want:  Result{Title: "File taxes", Deadline: "next friday"},
+		},
+		{
+			name:  "ISO dates keep their hyphens",
+			input: "Renew passport @2025-06-01 !2025-07-15",
+			want:  Result{Title: "Renew passport", When: "2025-06-01", Deadline: "2025-07-15"},
+		},
+		{
+			name:  "ISO date with reminder",
+			input: "Dentist @2025-06-01@9:30",
+			want:  Result{Title: "Dentist", When: "2025-06-01@9:30"},
+		},
+		{
+			name:  "notes separator",
+			input: "Buy milk #errands //pick up oat milk",
+			want:  Result{Title: "Buy milk", Tags: []string{"errands"}, Notes: "pick up oat milk"},
+		},
+		{
+			name:  "markers after the separator stay in the notes",
+			input: "Buy milk // #not-a-tag @not-a-date",
+			want:  Result{Title: "Buy milk", Notes: "#not-a-tag @not-a-date"},
+		},
+		{
+			name:  "URL is not a notes separator",
+			input: "Read https://example.com/post",
+			want:  Result{Title: "Read https://example.com/post"},
+		},
+		{
+			name:  "URL before the notes separator",
+			input: "Read https://example.com/post //from the newsletter",
+			want:  Result{Title: "Read https://example.com/post", Notes: "from the newsletter"},
+		},
+		{
+			name:  "everything",
+			input: "Buy milk #errands @tomorrow !friday //pick up oat milk",
+			want:  Result{Title: "Buy milk", Tags: []string{"errands"}, When: "tomorrow", Deadline: "friday", Notes: "pick up oat milk"},
+		},
+		{
+			name:  "bare markers stay in the title",
+			input: "Fix # and @ and !",
+			want:  Result{Title: "Fix # and @ and !"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(tt.input)
+			if err != nil {
+				t.Fatalf("Parse(%q) error: %v", tt.input, err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Parse(%q) = %+v, want %+v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseEmptyTitle(t *testing.T) {
+	for _, input := range []string{"", "   ", "#errands @tomorrow", "!friday //notes only", "//just notes"} {
+		if _, err := Parse(input); !errors.Is(err, ErrEmptyTitle) {
+			t.Errorf("Parse(%q) error = %v, want ErrEmptyTitle", input, err)
+		}
+	}
+}
@@ -0,0 +1,244 @@
+package things
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"html"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/yourusername/things3-cli/pkg/util"
+)
+
+// readingListFile records the URLs already imported by import reading-list.
+const readingListFile = "reading_list_imported.json"
+
+// safariReadingListTitle is the title of the Reading List folder in Safari's bookmarks.
+const safariReadingListTitle = "com.apple.ReadingList"
+
+// bookmarkTokenPattern matches the folder headings, nested lists, and links of
+// a Netscape bookmarks export.
+var bookmarkTokenPattern = regexp.MustCompile(`(?is)<h3[^>]*>(.*?)</h3>|<a\s[^>]*href="([^"]*)"[^>]*>(.*?)</a>|<dl>|</dl>`)
+
+var htmlTagPattern = regexp.MustCompile(`<[^>]*>`)
+
+// ReadingListEntry is a saved page to be read.
+type ReadingListEntry struct {
+	Title string `json:"title"`
+	URL   string `json:"url"`
+}
+
+// DefaultReadingListPath returns the location of Safari's bookmarks, which
+// include the Reading List.
+func DefaultReadingListPath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, "Library", "Safari", "Bookmarks.plist"), nil
+}
+
+// LoadReadingList reads entries from Safari's Bookmarks.plist or from a
+// Netscape bookmarks HTML export (.html or .htm). In an export, links inside a
+// "Reading List" folder are used when there is one, otherwise all links.
+func LoadReadingList(path string) ([]ReadingListEntry, error) {
+	ext := strings.ToLower(filepath.Ext(path))
+	if ext == ".html" || ext == ".htm" {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read bookmarks: %w", err)
+		}
+		return parseBookmarksHTML(string(data)), nil
+	}
+
+	// Bookmarks.plist is usually binary; plutil turns it into XML.
+	out, err := exec.Command("plutil", "-convert", "xml1", "-o", "-", path).Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s (Full Disk Access may be required): %w", path, err)
+	}
+	root, err := decodePlist(out)
+	if err != nil {
+		return nil, err
+	}
+	return safariReadingList(root), nil
+}
+
+// ImportedReadingListURLs returns the URLs recorded by earlier imports.
+func ImportedReadingListURLs() (map[string]bool, error) {
+	var urls []string
+	if _, err := util.LoadState(readingListFile, &urls); err != nil {
+		return nil, err
+	}
+	seen := make(map[string]bool, len(urls))
+	for _, url := range urls {
+		seen[url] = true
+	}
+	return seen, nil
+}
+
+// RecordReadingListURLs adds URLs to the record of imported entries.
+func RecordReadingListURLs(added []string) error {
+	var urls []string
+	if _, err := util.LoadState(readingListFile, &urls); err != nil {
+		return err
+	}
+	return util.SaveState(readingListFile, append(urls, added...))
+}
+
+func parseBookmarksHTML(doc string) []ReadingListEntry {
+	var all, reading []ReadingListEntry
+	var folders []string
+	pending := ""
+	inReadingList := func() bool {
+		for _, folder := range folders {
+			if strings.EqualFold(folder, "Reading List") {
+				return true
+			}
+		}
+		return false
+	}
+
+	for _, match := range bookmarkTokenPattern.FindAllStringSubmatch(doc, -1) {
+		token := strings.ToLower(match[0])
+		switch {
+		case strings.HasPrefix(token, "<h3"):
+			pending = bookmarkText(match[1])
+		case token == "<dl>":
+			folders = append(folders, pending)
+			pending = ""
+		case token == "</dl>":
+			if len(folders) > 0 {
+				folders = folders[:len(folders)-1]
+			}
+		default:
+			entry := ReadingListEntry{Title: bookmarkText(match[3]), URL: html.UnescapeString(match[2])}
+			if entry.URL == "" {
+				continue
+			}
+			if entry.Title == "" {
+				entry.Title = entry.URL
+			}
+			all = append(all, entry)
+			if inReadingList() {
+				reading = append(reading, entry)
+			}
+		}
+	}
+
+	if len(reading) > 0 {
+		return reading
+	}
+	return all
+}
+
+func bookmarkText(s string) string {
+	return strings.TrimSpace(html.UnescapeString(htmlTagPattern.ReplaceAllString(s, "")))
+}
+
+// safariReadingList extracts the Reading List entries from decoded Bookmarks.plist.
+func safariReadingList(root interface{}) []ReadingListEntry {
+	top, _ := root.(map[string]interface{})
+	children, _ := top["Children"].([]interface{})
+
+	var entries []ReadingListEntry
+	for _, child := range children {
+		folder, _ := child.(map[string]interface{})
+		if title, _ := folder["Title"].(string); title != safariReadingListTitle {
+			continue
+		}
+		items, _ := folder["Children"].([]interface{})
+		for _, item := range items {
+			bookmark, _ := item.(map[string]interface{})
+			url, _ := bookmark["URLString"].(string)
+			if url == "" {
+				continue
+			}
+			uri, _ := bookmark["URIDictionary"].(map[string]interface{})
+			title, _ := uri["title"].(string)
+			if title == "" {
+				title = url
+			}
+			entries = append(entries, ReadingListEntry{Title: strings.TrimSpace(title), URL: url})
+		}
+	}
+	return entries
+}
+
+// decodePlist decodes an XML property list into maps, slices, and strings.
+// Values of other types decode to nil, which is all the Reading List needs.
+func decodePlist(data []byte) (interface{}, error) {
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse bookmarks: %w", err)
+		}
+		if start, ok := token.(xml.StartElement); ok && start.Name.Local != "plist" {
+			return decodePlistValue(decoder, start)
+		}
+	}
+}
+
+func decodePlistValue(decoder *xml.Decoder, start xml.StartElement) (interface{}, error) {
+	switch start.Name.Local {
+	case "dict":
+		dict := make(map[string]interface{})
+		key := ""
+		for {
+			token, err := decoder.Token()
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse bookmarks: %w", err)
+			}
+			switch t := token.(type) {
+			case xml.StartElement:
+				if t.Name.Local == "key" {
+					if err := decoder.DecodeElement(&key, &t); err != nil {
+						return nil, fmt.Errorf("failed to parse bookmarks: %w", err)
+					}
+					continue
+				}
+				value, err := decodePlistValue(decoder, t)
+				if err != nil {
+					return nil, err
+				}
+				dict[key] = value
+			case xml.EndElement:
+				return dict, nil
+			}
+		}
+	case "array":
+		var array []interface{}
+		for {
+			token, err := decoder.Token()
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse bookmarks: %w", err)
+			}
+			switch t := token.(type) {
+			case xml.StartElement:
+				value, err := decodePlistValue(decoder, t)
+				if err != nil {
+					return nil, err
+				}
+				array = append(array, value)
+			case xml.EndElement:
+				return array, nil
+			}
+		}
+	case "string":
+		var s string
+		if err := decoder.DecodeElement(&s, &start); err != nil {
+			return nil, fmt.Errorf("failed to parse bookmarks: %w", err)
+		}
+		return s, nil
+	default:
+		if err := decoder.Skip(); err != nil && err != io.EOF {
+			return nil, fmt.Errorf("failed to parse bookmarks: %w", err)
+		}
+		return nil, nil
+	}
+}
@@ -0,0 +1,163 @@
+package things
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/yourusername/things3-cli/pkg/util"
+)
+
+// gitTodoNotesPattern matches the notes written by import git:
+// "file:line" followed by "Commit <hash>: <subject>" on the next line.
+var gitTodoNotesPattern = regexp.MustCompile(`(?m)^(\S+):(\d+)\nCommit ([0-9a-f]{7,40}):`)
+
+// GitCommit is a commit listed in release notes.
+type GitCommit struct {
+	Hash    string   `json:"hash"`
+	Subject string   `json:"subject"`
+	Date    string   `json:"date"`
+	Files   []string `json:"files,omitempty"`
+}
+
+// ShortHash returns the abbreviated commit hash.
+func (c GitCommit) ShortHash() string {
+	if len(c.Hash) > 7 {
+		return c.Hash[:7]
+	}
+	return c.Hash
+}
+
+// ReleaseNoteTodo is a to-do imported from a code comment and completed since
+// the tag, with the commits since the tag that changed the comment's file.
+type ReleaseNoteTodo struct {
+	ID          string   `json:"id"`
+	Title       string   `json:"title"`
+	CompletedAt string   `json:"completed_at"`
+	File        string   `json:"file"`
+	Line        int      `json:"line"`
+	Commits     []string `json:"commits"`
+}
+
+// ReleaseNotes is a draft changelog for the commits since a tag.
+type ReleaseNotes struct {
+	Repo        string            `json:"repo"`
+	Project     string            `json:"project"`
+	SinceTag    string            `json:"since_tag"`
+	TagDate     string            `json:"tag_date"`
+	GeneratedAt time.Time         `json:"generated_at"`
+	Resolved    []ReleaseNoteTodo `json:"resolved"`
+	Commits     []GitCommit       `json:"commits"`
+}
+
+// BuildReleaseNotes drafts release notes for the repository at dir. Commits
+// since tag are listed, and to-dos of project that were created by import
+// git from this repository and completed after the tag are matched to the
+// commits that touched their file.
+func BuildReleaseNotes(db *DB, project *Item, dir, tag string) (*ReleaseNotes, error) {
+	top, err := gitTopLevel(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	out, err := runGit(top, "log", "-1", "--format=%cI", tag, "--")
+	if err != nil {
+		return nil, err
+	}
+	tagTime, err := time.Parse(time.RFC3339, strings.TrimSpace(string(out)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read date of %s: %w", tag, err)
+	}
+
+	commits, err := commitsSince(top, tag)
+	if err != nil {
+		return nil, err
+	}
+
+	finished, err := db.CompletedInProject(project.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	notes := &ReleaseNotes{
+		Repo:        filepath.Base(top),
+		Project:     project.Title,
+		SinceTag:    tag,
+		TagDate:     tagTime.In(util.Location()).Format(util.DateLayout),
+		GeneratedAt: util.Now(),
+		Resolved:    []ReleaseNoteTodo{},
+		Commits:     commits,
+	}
+
+	for _, item := range finished {
+		if item.Status != "completed" {
+			continue
+		}
+		completed, err := time.Parse(time.RFC3339, item.CompletedAt)
+		if err != nil || completed.Before(tagTime) {
+			continue
+		}
+		match := gitTodoNotesPattern.FindStringSubmatch(item.Notes)
+		if match == nil {
+			continue
+		}
+		// The to-do belongs to this repository if its commit is known here
+		if _, err := runGit(top, "cat-file", "-e", match[3]+"^{commit}"); err != nil {
+			continue
+		}
+
+		line, _ := strconv.Atoi(match[2])
+		todo := ReleaseNoteTodo{
+			ID:          item.ID,
+			Title:       item.Title,
+			CompletedAt: item.CompletedAt,
+			File:        match[1],
+			Line:        line,
+			Commits:     []string{},
+		}
+		for _, commit := range commits {
+			for _, file := range commit.Files {
+				if file == todo.File {
+					todo.Commits = append(todo.Commits, commit.Hash)
+					break
+				}
+			}
+		}
+		notes.Resolved = append(notes.Resolved, todo)
+	}
+	return notes, nil
+}
+
+// commitsSince lists the non-merge commits after tag up to HEAD, newest
+// first, with the files each one changed.
+func commitsSince(top, tag string) ([]GitCommit, error) {
+	out, err := runGit(top, "log", "--no-merges", "--no-color", "--name-only",
+		"--format=%x1e%H%x1f%s%x1f%cI", tag+"..HEAD")
+	if err != nil {
+		return nil, err
+	}
+
+	commits := []GitCommit{}
+	for _, record := range bytes.Split(out, []byte{0x1e}) {
+		lines := strings.Split(strings.TrimSpace(string(record)), "\n")
+		fields := strings.Split(lines[0], "\x1f")
+		if len(fields) != 3 {
+			continue
+		}
+		commit := GitCommit{Hash: fields[0], Subject: fields[1], Date: fields[2]}
+		if date, err := time.Parse(time.RFC3339, fields[2]); err == nil {
+			commit.Date = date.In(util.Location()).Format(util.DateLayout)
+		}
+		for _, file := range lines[1:] {
+			if file = strings.TrimSpace(file); file != "" {
+				commit.Files = append(commit.Files, file)
+			}
+		}
+		commits = append(commits, commit)
+	}
+	return commits, nil
+}
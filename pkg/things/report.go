@@ -0,0 +1,127 @@
+package things
+
+import (
+	"sort"
+	"time"
+
+	"github.com/yourusername/things3-cli/pkg/util"
+)
+
+// maxReportMilestones caps the upcoming deadlines listed in a share report.
+const maxReportMilestones = 10
+
+// ShareReport summarizes a project for people who don't use Things.
+type ShareReport struct {
+	Project     string          `json:"project"`
+	Area        string          `json:"area,omitempty"`
+	Notes       string          `json:"notes,omitempty"`
+	Deadline    string          `json:"deadline,omitempty"`
+	GeneratedAt time.Time       `json:"generated_at"`
+	Since       string          `json:"since"`
+	Open        int             `json:"open"`
+	Completed   int             `json:"completed"`
+	Canceled    int             `json:"canceled"`
+	PercentDone int             `json:"percent_done"`
+	Sections    []ReportSection `json:"sections"`
+	Milestones  []ReportEntry   `json:"milestones"`
+	Recent      []ReportEntry   `json:"recently_completed"`
+}
+
+// ReportSection is the progress of one heading in a project.
+type ReportSection struct {
+	Title string `json:"title"`
+	Open  int    `json:"open"`
+	Done  int    `json:"done"`
+}
+
+// ReportEntry is a to-do listed in a share report with its relevant date.
+type ReportEntry struct {
+	Title string `json:"title"`
+	Date  string `json:"date"`
+	Notes string `json:"notes,omitempty"`
+}
+
+// BuildShareReport gathers the status of a project: counts, progress per
+// heading, the next open deadlines, and to-dos completed since the given
+// time. With redactNotes, no notes are included.
+func BuildShareReport(db *DB, project *Item, since time.Time, redactNotes bool) (*ShareReport, error) {
+	outline, err := db.ProjectOutline(project.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &ShareReport{
+		Project:     project.Title,
+		Area:        project.Area,
+		Deadline:    project.Deadline,
+		GeneratedAt: util.Now(),
+		Since:       since.Format(util.DateLayout),
+		Sections:    []ReportSection{},
+		Milestones:  []ReportEntry{},
+		Recent:      []ReportEntry{},
+	}
+	if !redactNotes {
+		report.Notes = project.Notes
+	}
+	notes := func(item Item) string {
+		if redactNotes {
+			return ""
+		}
+		return item.Notes
+	}
+
+	sections := make(map[string]int)
+	for _, item := range outline {
+		if item.Type == "heading" {
+			sections[item.ID] = len(report.Sections)
+			report.Sections = append(report.Sections, ReportSection{Title: item.Title})
+			continue
+		}
+		switch item.Status {
+		case "completed":
+			report.Completed++
+		case "canceled":
+			report.Canceled++
+		default:
+			report.Open++
+		}
+	}
+
+	for _, item := range outline {
+		if item.Type == "heading" {
+			continue
+		}
+		if i, ok := sections[item.HeadingID]; ok {
+			if item.Status == "open" {
+				report.Sections[i].Open++
+			} else if item.Status == "completed" {
+				report.Sections[i].Done++
+			}
+		}
+
+		if item.Status == "open" && item.Deadline != "" {
+			report.Milestones = append(report.Milestones, ReportEntry{Title: item.Title, Date: item.Deadline, Notes: notes(item)})
+		}
+		if item.Status == "completed" {
+			completed, err := time.Parse(time.RFC3339, item.CompletedAt)
+			if err == nil && !completed.Before(since) {
+				report.Recent = append(report.Recent, ReportEntry{
+					Title: item.Title,
+					Date:  completed.In(util.Location()).Format(util.DateLayout),
+					Notes: notes(item),
+				})
+			}
+		}
+	}
+
+	sort.SliceStable(report.Milestones, func(i, j int) bool { return report.Milestones[i].Date < report.Milestones[j].Date })
+	if len(report.Milestones) > maxReportMilestones {
+		report.Milestones = report.Milestones[:maxReportMilestones]
+	}
+	sort.SliceStable(report.Recent, func(i, j int) bool { return report.Recent[i].Date > report.Recent[j].Date })
+
+	if finished := report.Open + report.Completed; finished > 0 {
+		report.PercentDone = report.Completed * 100 / finished
+	}
+	return report, nil
+}
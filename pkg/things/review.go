@@ -0,0 +1,135 @@
+package things
+
+import (
+	"github.com/yourusername/things3-cli/pkg/util"
+)
+
+// reviewDeadlineDays is how far ahead the review looks for upcoming deadlines
+const reviewDeadlineDays = 14
+
+// ReviewStep is one stage of the weekly review and the items to go through in it.
+type ReviewStep struct {
+	Name  string `json:"name"`
+	Title string `json:"title"`
+	Items []Item `json:"items"`
+}
+
+// ReviewAction is what to do with an item during a review.
+type ReviewAction string
+
+// Actions that can be taken on a reviewed item
+const (
+	ReviewKeep     ReviewAction = "keep"
+	ReviewToday    ReviewAction = "today"
+	ReviewSomeday  ReviewAction = "someday"
+	ReviewComplete ReviewAction = "complete"
+	ReviewCancel   ReviewAction = "cancel"
+)
+
+// ReviewDecision records the action chosen for an item in a review step.
+type ReviewDecision struct {
+	Step   string       `json:"step"`
+	ID     string       `json:"id"`
+	Type   string       `json:"type"`
+	Title  string       `json:"title"`
+	Action ReviewAction `json:"action"`
+}
+
+// ReviewStepNames lists the review steps in the order they are walked through.
+var ReviewStepNames = []string{"inbox", "overdue", "deadlines", "stalled", "someday"}
+
+// BuildReview gathers the items for each named review step, in the order of
+// ReviewStepNames. An item appears only in the first step that includes it.
+//
+//	inbox      to-dos waiting in the Inbox
+//	overdue    open items whose deadline has passed
+//	deadlines  open items due in the next two weeks
+//	stalled    open projects without an open to-do to work on
+//	someday    items parked in Someday
+func BuildReview(db *DB, steps []string) ([]ReviewStep, error) {
+	wanted := make(map[string]bool, len(steps))
+	for _, name := range steps {
+		wanted[name] = true
+	}
+
+	open, err := db.OpenItems()
+	if err != nil {
+		return nil, err
+	}
+	inbox, err := db.Inbox()
+	if err != nil {
+		return nil, err
+	}
+
+	now := util.Now()
+	today := now.Format(util.DateLayout)
+	horizon := now.AddDate(0, 0, reviewDeadlineDays).Format(util.DateLayout)
+
+	active := make(map[string]bool)
+	for _, item := range open {
+		if item.Type == "to-do" && item.ProjectID != "" {
+			active[item.ProjectID] = true
+		}
+	}
+
+	candidates := map[string][]Item{"inbox": inbox}
+	for _, item := range open {
+		switch {
+		case item.Deadline != "" && item.Deadline < today:
+			candidates["overdue"] = append(candidates["overdue"], item)
+		case item.Deadline != "" && item.Deadline <= horizon:
+			candidates["deadlines"] = append(candidates["deadlines"], item)
+		}
+		if item.Type == "project" && !active[item.ID] {
+			candidates["stalled"] = append(candidates["stalled"], item)
+		}
+		if item.When == "someday" {
+			candidates["someday"] = append(candidates["someday"], item)
+		}
+	}
+
+	titles := map[string]string{
+		"inbox":     "Inbox",
+		"overdue":   "Overdue",
+		"deadlines": "Due in the next two weeks",
+		"stalled":   "Projects without a next action",
+		"someday":   "Someday",
+	}
+
+	seen := make(map[string]bool)
+	var review []ReviewStep
+	for _, name := range ReviewStepNames {
+		if !wanted[name] {
+			continue
+		}
+		step := ReviewStep{Name: name, Title: titles[name], Items: []Item{}}
+		for _, item := range candidates[name] {
+			if seen[item.ID] {
+				continue
+			}
+			seen[item.ID] = true
+			step.Items = append(step.Items, item)
+		}
+		review = append(review, step)
+	}
+	return review, nil
+}
+
+// Operation returns the json update carrying out a decision, or false for
+// decisions that leave the item as it is.
+func (d ReviewDecision) Operation() (JSONOperation, bool) {
+	var attrs map[string]interface{}
+	switch d.Action {
+	case ReviewToday:
+		attrs = map[string]interface{}{"when": "today"}
+	case ReviewSomeday:
+		attrs = map[string]interface{}{"when": "someday"}
+	case ReviewComplete:
+		attrs = map[string]interface{}{"completed": true}
+	case ReviewCancel:
+		attrs = map[string]interface{}{"canceled": true}
+	default:
+		return JSONOperation{}, false
+	}
+	return NewUpdateOperation(d.Type, d.ID, attrs), true
+}
@@ -0,0 +1,91 @@
+package things
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// ConfirmationError is returned when an action would modify more items than
+// the safe-mode threshold allows and the client's Confirm hook declined it.
+type ConfirmationError struct {
+	Count     int
+	Threshold int
+}
+
+func (e *ConfirmationError) Error() string {
+	return fmt.Sprintf("operation would modify %d items, exceeding the safe-mode threshold of %d", e.Count, e.Threshold)
+}
+
+// checkSafeMode enforces the mass-mutation threshold for an action. confirm
+// overrides the client's Confirm hook when it is set.
+func (c *Client) checkSafeMode(action string, params map[string]string, confirm func(count int) bool) error {
+	if c.SafeModeThreshold <= 0 {
+		return nil
+	}
+
+	count := mutationCount(action, params)
+	if count <= c.SafeModeThreshold {
+		return nil
+	}
+	if confirm == nil {
+		confirm = c.Confirm
+	}
+	if confirm != nil && confirm(count) {
+		return nil
+	}
+	return &ConfirmationError{Count: count, Threshold: c.SafeModeThreshold}
+}
+
+// mutationCount estimates how many items an action creates or modifies.
+func mutationCount(action string, params map[string]string) int {
+	switch action {
+	case "add":
+		if titles := params["titles"]; titles != "" {
+			return countLines(titles)
+		}
+		return 1
+	case "add-project":
+		return 1 + countLines(params["to-dos"])
+	case "update", "update-project":
+		return 1
+	case "json":
+		var payload []interface{}
+		if err := json.Unmarshal([]byte(params["data"]), &payload); err != nil {
+			return 0
+		}
+		return countPayloadItems(payload)
+	default:
+		return 0
+	}
+}
+
+// countPayloadItems counts json payload entries, including to-dos nested in projects.
+func countPayloadItems(entries []interface{}) int {
+	count := 0
+	for _, entry := range entries {
+		obj, ok := entry.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if obj["type"] != "heading" {
+			count++
+		}
+		if attrs, ok := obj["attributes"].(map[string]interface{}); ok {
+			if items, ok := attrs["items"].([]interface{}); ok {
+				count += countPayloadItems(items)
+			}
+		}
+	}
+	return count
+}
+
+func countLines(s string) int {
+	count := 0
+	for _, line := range strings.Split(s, "\n") {
+		if strings.TrimSpace(line) != "" {
+			count++
+		}
+	}
+	return count
+}
@@ -0,0 +1,120 @@
+package things
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/yourusername/things3-cli/pkg/util"
+)
+
+// screenshotsDirName is the directory next to the config file holding captured screenshots.
+const screenshotsDirName = "screenshots"
+
+// ErrCaptureCanceled is returned when the user cancels an interactive screenshot.
+var ErrCaptureCanceled = errors.New("screenshot canceled")
+
+// recognizeTextScript runs Vision text recognition on the image given as the
+// first argument and returns the recognized lines.
+const recognizeTextScript = `ObjC.import('Vision');
+function run(argv) {
+	const url = $.NSURL.fileURLWithPath(argv[0]);
+	const handler = $.VNImageRequestHandler.alloc.initWithURLOptions(url, $.NSDictionary.alloc.init);
+	const request = $.VNRecognizeTextRequest.alloc.init;
+	request.recognitionLevel = $.VNRequestTextRecognitionLevelAccurate;
+	request.usesLanguageCorrection = true;
+	if (!handler.performRequestsError($.NSArray.arrayWithObject(request), null)) {
+		throw new Error('text recognition failed');
+	}
+	const lines = [];
+	const results = request.results;
+	for (let i = 0; i < results.count; i++) {
+		const candidate = results.objectAtIndex(i).topCandidates(1).firstObject;
+		if (candidate) {
+			lines.push(candidate.string.js);
+		}
+	}
+	return lines.join('\n');
+}`
+
+// ScreenshotPath returns a new file path for a screenshot in dir, or in the
+// screenshots directory next to the config file when dir is empty.
+func ScreenshotPath(dir string) (string, error) {
+	if dir == "" {
+		var err error
+		if dir, err = util.StatePath(screenshotsDirName); err != nil {
+			return "", err
+		}
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create screenshots directory: %w", err)
+	}
+	return filepath.Join(dir, "capture-"+time.Now().Format("20060102-150405")+".png"), nil
+}
+
+// CaptureScreenshot lets the user select a window or region and saves it to
+// path, returning ErrCaptureCanceled if the selection was abandoned.
+func CaptureScreenshot(path string) error {
+	if err := runHelper("screencapture", "-i", "-x", path); err != nil {
+		return err
+	}
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		return ErrCaptureCanceled
+	}
+	return nil
+}
+
+// RecognizeText returns the text in an image using the macOS Vision framework.
+func RecognizeText(path string) (string, error) {
+	cmd := exec.Command("osascript", "-l", "JavaScript", "-e", recognizeTextScript, path)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	out, err := cmd.Output()
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", fmt.Errorf("text recognition failed: %s", msg)
+		}
+		return "", fmt.Errorf("text recognition failed: %w", err)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// RecognizeTextWithShortcut passes an image to a Shortcuts shortcut, such as
+// one built around the Extract Text from Image action, and returns its output.
+func RecognizeTextWithShortcut(shortcut, path string) (string, error) {
+	output, err := os.CreateTemp("", "things-ocr-*.txt")
+	if err != nil {
+		return "", err
+	}
+	output.Close()
+	defer os.Remove(output.Name())
+
+	if err := runHelper("shortcuts", "run", shortcut, "--input-path", path, "--output-path", output.Name()); err != nil {
+		return "", err
+	}
+	data, err := os.ReadFile(output.Name())
+	if err != nil {
+		return "", fmt.Errorf("failed to read shortcut output: %w", err)
+	}
+	return strings.TrimSpace(string(data)), nil
+}
+
+// runHelper runs a macOS command line tool, reporting its stderr on failure.
+func runHelper(name string, args ...string) error {
+	cmd := exec.Command(name, args...)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return fmt.Errorf("%s failed: %s", name, msg)
+		}
+		return fmt.Errorf("%s failed: %w", name, err)
+	}
+	return nil
+}
@@ -0,0 +1,139 @@
+package things
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/yourusername/things3-cli/pkg/util"
+)
+
+// semanticIndexFile stores item embeddings between searches.
+const semanticIndexFile = "semantic_index.json"
+
+// semanticIndex maps item IDs to the embedding of their title and notes.
+// Model records which embedding model produced the vectors, since vectors
+// from different models cannot be compared.
+type semanticIndex struct {
+	Model   string                `json:"model"`
+	Entries map[string]indexEntry `json:"entries"`
+}
+
+type indexEntry struct {
+	Hash   string    `json:"hash"`
+	Vector []float32 `json:"vector"`
+}
+
+// SemanticSearch ranks open to-dos and projects by meaning rather than by
+// matching words. The side index is refreshed first, embedding only items
+// that are new or whose title or notes changed since the last search.
+func SemanticSearch(db *DB, embedder *Embedder, query string) ([]ScoredItem, error) {
+	items, err := db.OpenItems()
+	if err != nil {
+		return nil, err
+	}
+
+	index, err := refreshSemanticIndex(items, embedder)
+	if err != nil {
+		return nil, err
+	}
+
+	vectors, err := embedder.Embed([]string{query})
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]ScoredItem, 0, len(items))
+	for _, item := range items {
+		entry, ok := index.Entries[item.ID]
+		if !ok {
+			continue
+		}
+		results = append(results, ScoredItem{Item: item, Score: cosineSimilarity(vectors[0], entry.Vector)})
+	}
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].Score > results[j].Score
+	})
+	return results, nil
+}
+
+// refreshSemanticIndex brings the stored index up to date with items and saves it.
+func refreshSemanticIndex(items []Item, embedder *Embedder) (*semanticIndex, error) {
+	var index semanticIndex
+	if _, err := util.LoadState(semanticIndexFile, &index); err != nil {
+		return nil, err
+	}
+	if index.Model != embedder.Model || index.Entries == nil {
+		index = semanticIndex{Model: embedder.Model, Entries: make(map[string]indexEntry)}
+	}
+
+	current := make(map[string]bool, len(items))
+	var staleIDs, staleTexts, staleHashes []string
+	for _, item := range items {
+		current[item.ID] = true
+		text := semanticText(item)
+		hash := textHash(text)
+		if entry, ok := index.Entries[item.ID]; ok && entry.Hash == hash {
+			continue
+		}
+		staleIDs = append(staleIDs, item.ID)
+		staleTexts = append(staleTexts, text)
+		staleHashes = append(staleHashes, hash)
+	}
+
+	changed := len(staleIDs) > 0
+	for id := range index.Entries {
+		if !current[id] {
+			delete(index.Entries, id)
+			changed = true
+		}
+	}
+
+	if len(staleTexts) > 0 {
+		vectors, err := embedder.Embed(staleTexts)
+		if err != nil {
+			return nil, err
+		}
+		for i, id := range staleIDs {
+			index.Entries[id] = indexEntry{Hash: staleHashes[i], Vector: vectors[i]}
+		}
+	}
+
+	if changed {
+		if err := util.SaveState(semanticIndexFile, index); err != nil {
+			return nil, fmt.Errorf("failed to save semantic index: %w", err)
+		}
+	}
+	return &index, nil
+}
+
+// semanticText is the text embedded for an item.
+func semanticText(item Item) string {
+	return strings.TrimSpace(item.Title + "\n" + item.Notes)
+}
+
+func textHash(text string) string {
+	sum := sha256.Sum256([]byte(text))
+	return hex.EncodeToString(sum[:8])
+}
+
+// cosineSimilarity returns the cosine of the angle between two vectors,
+// or 0 when they differ in length or either is all zeros.
+func cosineSimilarity(a, b []float32) float64 {
+	if len(a) != len(b) {
+		return 0
+	}
+	var dot, normA, normB float64
+	for i := range a {
+		dot += float64(a[i]) * float64(b[i])
+		normA += float64(a[i]) * float64(a[i])
+		normB += float64(b[i]) * float64(b[i])
+	}
+	if normA == 0 || normB == 0 {
+		return 0
+	}
+	return dot / (math.Sqrt(normA) * math.Sqrt(normB))
+}
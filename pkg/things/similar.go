@@ -0,0 +1,113 @@
+package things
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/yourusername/things3-cli/pkg/util"
+)
+
+// stopWords are common words ignored when comparing items lexically.
+var stopWords = map[string]bool{
+	"a": true, "an": true, "and": true, "are": true, "as": true, "at": true,
+	"be": true, "by": true, "for": true, "from": true, "in": true, "is": true,
+	"it": true, "of": true, "on": true, "or": true, "the": true, "to": true,
+	"with": true,
+}
+
+// SimilarItems ranks the other to-dos and projects, including completed ones
+// in the Logbook, by how many distinctive words they share with target.
+// Words are weighted by TF-IDF, and title words count twice as much as notes.
+func SimilarItems(db *DB, target Item) ([]ScoredItem, error) {
+	items, err := db.AllItems()
+	if err != nil {
+		return nil, err
+	}
+
+	docs := make([]map[string]float64, len(items))
+	docFreq := make(map[string]int)
+	for i, item := range items {
+		docs[i] = termCounts(item)
+		for term := range docs[i] {
+			docFreq[term]++
+		}
+	}
+
+	weigh := func(counts map[string]float64) map[string]float64 {
+		weights := make(map[string]float64, len(counts))
+		for term, count := range counts {
+			idf := math.Log(float64(len(items)+1) / float64(docFreq[term]+1))
+			weights[term] = count * (idf + 1)
+		}
+		return weights
+	}
+
+	query := weigh(termCounts(target))
+	results := make([]ScoredItem, 0)
+	for i, item := range items {
+		if item.ID == target.ID {
+			continue
+		}
+		if score := sparseCosine(query, weigh(docs[i])); score > 0 {
+			results = append(results, ScoredItem{Item: item, Score: score})
+		}
+	}
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].Score > results[j].Score
+	})
+	return results, nil
+}
+
+// termCounts tokenizes an item's title and notes into folded word counts.
+func termCounts(item Item) map[string]float64 {
+	counts := make(map[string]float64)
+	add := func(text string, weight float64) {
+		words := strings.FieldsFunc(text, func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+		})
+		for _, word := range words {
+			word = util.NormalizeTag(word)
+			if len(word) < 2 || stopWords[word] {
+				continue
+			}
+			counts[word] += weight
+		}
+	}
+	add(item.Title, 2)
+	add(item.Notes, 1)
+	return counts
+}
+
+func sparseCosine(a, b map[string]float64) float64 {
+	var dot, normA, normB float64
+	for term, wa := range a {
+		dot += wa * b[term]
+		normA += wa * wa
+	}
+	for _, wb := range b {
+		normB += wb * wb
+	}
+	if normA == 0 || normB == 0 {
+		return 0
+	}
+	return dot / (math.Sqrt(normA) * math.Sqrt(normB))
+}
+
+// SimilarItemsSemantic ranks open to-dos and projects by closeness in meaning
+// to target, using the semantic search index.
+func SimilarItemsSemantic(db *DB, embedder *Embedder, target Item) ([]ScoredItem, error) {
+	results, err := SemanticSearch(db, embedder, semanticText(target))
+	if err != nil {
+		return nil, err
+	}
+
+	similar := make([]ScoredItem, 0, len(results))
+	for _, result := range results {
+		if result.ID != target.ID {
+			similar = append(similar, result)
+		}
+	}
+	return similar, nil
+}
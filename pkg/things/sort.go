@@ -0,0 +1,64 @@
+package things
+
+import (
+	"fmt"
+	"strings"
+)
+
+// sortColumns maps --sort fields to the TMTask columns they order by
+var sortColumns = map[string]string{
+	"deadline": "t.deadline",
+	"created":  "t.creationDate",
+	"modified": "t.userModificationDate",
+	"title":    "t.title COLLATE NOCASE",
+}
+
+// SortFields lists the fields accepted by SetSort in help order.
+var SortFields = []string{"deadline", "created", "modified", "title"}
+
+// itemSort is the order chosen with SetSort; the zero value keeps the order
+// each query uses by default
+type itemSort struct {
+	field   string
+	reverse bool
+}
+
+var defaultSort itemSort
+
+// SetSort orders the items of every list query in databases opened
+// afterwards by field, ascending or reversed. Items without a deadline come
+// last either way, and ties are broken by ID so the order is deterministic.
+// An empty field restores the default order of each query.
+func SetSort(field string, reverse bool) error {
+	field = strings.ToLower(strings.TrimSpace(field))
+	if field == "" {
+		if reverse {
+			return fmt.Errorf("--reverse needs --sort")
+		}
+		defaultSort = itemSort{}
+		return nil
+	}
+	if _, ok := sortColumns[field]; !ok {
+		return fmt.Errorf("unknown sort field %q (use %s)", field, strings.Join(SortFields, ", "))
+	}
+	defaultSort = itemSort{field: field, reverse: reverse}
+	return nil
+}
+
+// orderBy returns the ORDER BY clause for the chosen sort, or fallback when
+// none was chosen.
+func (s itemSort) orderBy(fallback string) string {
+	column, ok := sortColumns[s.field]
+	if !ok {
+		return fallback
+	}
+	direction := "ASC"
+	if s.reverse {
+		direction = "DESC"
+	}
+	order := fmt.Sprintf("%s %s, t.uuid", column, direction)
+	if s.field == "deadline" {
+		order = "(t.deadline IS NULL OR t.deadline = 0), " + order
+	}
+	return order
+}
@@ -0,0 +1,91 @@
+package things
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+	"time"
+)
+
+// operationState describes one in-flight Execute call.
+type operationState struct {
+	action  string
+	port    int
+	started time.Time
+}
+
+// statusTracker records what the process is currently waiting on so it can
+// be reported when a command appears to hang.
+type statusTracker struct {
+	mu           sync.Mutex
+	nextID       int
+	operations   map[int]operationState
+	query        string
+	queryStarted time.Time
+}
+
+var status = &statusTracker{operations: make(map[int]operationState)}
+
+// beginOperation registers an action waiting on the callback server and
+// returns a function that removes it again.
+func (t *statusTracker) beginOperation(action string, port int) func() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.nextID++
+	id := t.nextID
+	t.operations[id] = operationState{action: action, port: port, started: time.Now()}
+
+	return func() {
+		t.mu.Lock()
+		defer t.mu.Unlock()
+		delete(t.operations, id)
+	}
+}
+
+// beginQuery records the SQL statement currently running against the database.
+func (t *statusTracker) beginQuery(sql string) func() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.query = sql
+	t.queryStarted = time.Now()
+
+	return func() {
+		t.mu.Lock()
+		defer t.mu.Unlock()
+		t.query = ""
+	}
+}
+
+// DumpStatus writes the current operation state to w.
+func DumpStatus(w io.Writer) {
+	status.mu.Lock()
+	defer status.mu.Unlock()
+
+	now := time.Now()
+	fmt.Fprintf(w, "things status at %s\n", now.Format(time.RFC3339))
+	fmt.Fprintf(w, "  operations in flight: %d\n", len(status.operations))
+
+	ids := make([]int, 0, len(status.operations))
+	for id := range status.operations {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+	for _, id := range ids {
+		op := status.operations[id]
+		elapsed := now.Sub(op.started).Round(time.Millisecond)
+		if op.port == 0 {
+			fmt.Fprintf(w, "  - %s opening URL without callback (%s)\n", op.action, elapsed)
+			continue
+		}
+		fmt.Fprintf(w, "  - %s waiting for callback on port %d (%s)\n", op.action, op.port, elapsed)
+	}
+
+	if status.query == "" {
+		fmt.Fprintln(w, "  database query: none")
+		return
+	}
+	fmt.Fprintf(w, "  database query (%s): %s\n", now.Sub(status.queryStarted).Round(time.Millisecond), status.query)
+}
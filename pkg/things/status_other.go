@@ -0,0 +1,8 @@
+//go:build !unix
+
+package things
+
+import "io"
+
+// NotifyStatusSignal is a no-op on platforms without SIGUSR1.
+func NotifyStatusSignal(w io.Writer) {}
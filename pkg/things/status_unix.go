@@ -0,0 +1,23 @@
+//go:build unix
+
+package things
+
+import (
+	"io"
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// NotifyStatusSignal dumps the operation state to w whenever the process
+// receives SIGUSR1 (kill -USR1 <pid>).
+func NotifyStatusSignal(w io.Writer) {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGUSR1)
+
+	go func() {
+		for range signals {
+			DumpStatus(w)
+		}
+	}()
+}
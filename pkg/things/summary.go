@@ -0,0 +1,210 @@
+package things
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// SummarizeAction describes a finished action in one line for people, such
+// as "Added 'Buy milk' to Inbox, scheduled today". It reads the parameters
+// the action was sent with, so it names items by title only when the title
+// was part of the request.
+func SummarizeAction(action string, params map[string]string, result ActionResult) string {
+	var summary string
+	switch action {
+	case "add":
+		summary = summarizeAdd(params)
+	case "add-project":
+		summary = "Created project " + quoteTitle(params["title"])
+		if area := firstParam(params, "area", "area-id"); area != "" {
+			summary += " in " + area
+		}
+		summary += summarizeDates(params["when"], params["deadline"])
+	case "update", "update-project":
+		summary = summarizeUpdate(params)
+	case "json":
+		summary = summarizeJSON(params["data"])
+	case "show":
+		summary = "Opened " + firstParam(params, "id", "query")
+	case "search":
+		summary = "Searched for " + quoteTitle(params["query"])
+	default:
+		summary = "Ran " + action
+	}
+
+	ids := result.ThingsIDs
+	if len(ids) == 0 && result.ThingsID != "" {
+		ids = []string{result.ThingsID}
+	}
+	if len(ids) > 0 && action != "update" && action != "update-project" {
+		summary += " (" + strings.Join(ids, ", ") + ")"
+	}
+	return summary
+}
+
+// summarizeAdd describes an add action, naming the list the to-do lands in:
+// the requested list, the list a when value files it in, or the Inbox.
+func summarizeAdd(params map[string]string) string {
+	what := quoteTitle(params["title"])
+	if titles := params["titles"]; titles != "" {
+		what = fmt.Sprintf("%d to-dos", countLines(titles))
+	}
+
+	list := firstParam(params, "list", "list-id")
+	when := params["when"]
+	if list == "" {
+		list = "Inbox"
+		if named := listForWhen(when); named != "" {
+			list, when = named, ""
+		}
+	}
+
+	summary := "Added " + what + " to " + list
+	if heading := firstParam(params, "heading", "heading-id"); heading != "" {
+		summary += " under " + heading
+	}
+	return summary + summarizeDates(when, params["deadline"])
+}
+
+// summarizeUpdate describes an update or update-project action.
+func summarizeUpdate(params map[string]string) string {
+	id := params["id"]
+	switch {
+	case params["completed"] == "true":
+		return "Completed " + id
+	case params["canceled"] == "true":
+		return "Canceled " + id
+	case params["completed"] == "false":
+		return "Reopened " + id
+	}
+
+	var changes []string
+	if title := params["title"]; title != "" {
+		changes = append(changes, "renamed to "+quoteTitle(title))
+	}
+	if _, ok := params["notes"]; ok {
+		changes = append(changes, "notes replaced")
+	}
+	if params["append-notes"] != "" || params["prepend-notes"] != "" {
+		changes = append(changes, "notes added")
+	}
+	if items := params["append-checklist-items"]; items != "" {
+		changes = append(changes, fmt.Sprintf("%d checklist items added", countLines(items)))
+	}
+	if list := firstParam(params, "list", "list-id"); list != "" {
+		changes = append(changes, "moved to "+list)
+	}
+	if tags, ok := params["tags"]; ok {
+		changes = append(changes, "tags set to "+emptyAsNone(tags))
+	}
+	if tags := params["add-tags"]; tags != "" {
+		changes = append(changes, "tagged "+tags)
+	}
+	if when := params["when"]; when != "" {
+		changes = append(changes, "scheduled "+when)
+	}
+	if deadline, ok := params["deadline"]; ok {
+		changes = append(changes, "due "+emptyAsNone(deadline))
+	}
+	if len(changes) == 0 {
+		return "Updated " + id
+	}
+	return "Updated " + id + ": " + strings.Join(changes, ", ")
+}
+
+// summarizeDates describes when a new item is scheduled and its deadline,
+// each introduced by a comma.
+func summarizeDates(when, deadline string) string {
+	var summary string
+	if when != "" {
+		summary += ", scheduled " + when
+	}
+	if deadline != "" {
+		summary += ", due " + deadline
+	}
+	return summary
+}
+
+// summarizeJSON describes a json payload by counting what it creates,
+// completes, cancels, and updates.
+func summarizeJSON(data string) string {
+	var payload []map[string]interface{}
+	if err := json.Unmarshal([]byte(data), &payload); err != nil || len(payload) == 0 {
+		return "Sent json payload"
+	}
+
+	var created, completed, canceled, updated int
+	for _, entry := range payload {
+		attrs, _ := entry["attributes"].(map[string]interface{})
+		switch {
+		case entry["operation"] != "update":
+			created++
+		case attrs["completed"] == true:
+			completed++
+		case attrs["canceled"] == true:
+			canceled++
+		default:
+			updated++
+		}
+	}
+
+	var parts []string
+	for _, count := range []struct {
+		n    int
+		verb string
+	}{{created, "Created"}, {completed, "Completed"}, {canceled, "Canceled"}, {updated, "Updated"}} {
+		if count.n == 0 {
+			continue
+		}
+		verb := count.verb
+		if len(parts) > 0 {
+			verb = strings.ToLower(verb)
+		}
+		parts = append(parts, fmt.Sprintf("%s %d %s", verb, count.n, pluralItems(count.n)))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// listForWhen returns the list a when value files a new to-do in, or ""
+// for dates, which file it in Upcoming only once they are in the future.
+func listForWhen(when string) string {
+	switch when {
+	case "today":
+		return "Today"
+	case "evening", "tonight":
+		return "This Evening"
+	case "anytime":
+		return "Anytime"
+	case "someday":
+		return "Someday"
+	}
+	return ""
+}
+
+func firstParam(params map[string]string, keys ...string) string {
+	for _, key := range keys {
+		if value := params[key]; value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+func quoteTitle(title string) string {
+	return "'" + title + "'"
+}
+
+func emptyAsNone(value string) string {
+	if value == "" {
+		return "none"
+	}
+	return value
+}
+
+func pluralItems(n int) string {
+	if n == 1 {
+		return "item"
+	}
+	return "items"
+}
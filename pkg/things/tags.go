@@ -0,0 +1,150 @@
+package things
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/yourusername/things3-cli/pkg/util"
+)
+
+// tagParams lists the URL parameters that carry comma-separated tag titles.
+var tagParams = []string{"tags", "add-tags"}
+
+// CanonicalTags normalizes user-supplied tags against the configured synonyms
+// and the tags that already exist in the database. When the database is
+// unavailable the tags are still deduplicated and synonyms applied.
+func CanonicalTags(tags []string) []string {
+	return tagCanonicalizer()(tags)
+}
+
+// tagCanonicalizer loads the synonyms and the existing tags once and returns
+// a function that canonicalizes tags against them, for callers with many
+// lists of tags to rewrite.
+func tagCanonicalizer() func([]string) []string {
+	config, err := util.LoadConfig()
+	if err != nil {
+		config = util.DefaultConfig()
+	}
+
+	var known []string
+	if db, err := OpenDB(); err == nil {
+		if existing, err := db.ListTags(); err == nil {
+			for _, tag := range existing {
+				known = append(known, tag.Title)
+			}
+		}
+	}
+
+	return func(tags []string) []string {
+		return util.CanonicalizeTags(tags, known, config.TagSynonyms)
+	}
+}
+
+// normalizeTagParams rewrites tag parameters in place using CanonicalTags,
+// including the tags and add-tags attributes in a json payload.
+func normalizeTagParams(action string, params map[string]string) {
+	for _, key := range tagParams {
+		value, ok := params[key]
+		if !ok || value == "" {
+			continue
+		}
+		params[key] = util.JoinTags(CanonicalTags(util.ParseTags(value)))
+	}
+
+	if action == "json" && params["data"] != "" {
+		var payload interface{}
+		if err := json.Unmarshal([]byte(params["data"]), &payload); err != nil {
+			// Leave malformed payloads for Things to report
+			return
+		}
+		if canonicalizePayloadTags(payload, tagCanonicalizer()) {
+			if data, err := json.Marshal(payload); err == nil {
+				params["data"] = string(data)
+			}
+		}
+	}
+}
+
+// canonicalizePayloadTags walks a decoded json payload, rewriting tag lists
+// in place with canonical. It reports whether anything changed.
+func canonicalizePayloadTags(node interface{}, canonical func([]string) []string) bool {
+	changed := false
+	switch v := node.(type) {
+	case map[string]interface{}:
+		for key, value := range v {
+			if containsString(tagParams, key) {
+				// Lists that aren't all tag names are left for Things to report
+				if tags, ok := tagNames(value); ok {
+					if rewritten := canonical(tags); !slices.Equal(rewritten, tags) {
+						v[key] = rewritten
+						changed = true
+					}
+				}
+				continue
+			}
+			if canonicalizePayloadTags(value, canonical) {
+				changed = true
+			}
+		}
+	case []interface{}:
+		for _, value := range v {
+			if canonicalizePayloadTags(value, canonical) {
+				changed = true
+			}
+		}
+	}
+	return changed
+}
+
+// tagNames returns value as a list of tag names, if it is one.
+func tagNames(value interface{}) ([]string, bool) {
+	list, ok := value.([]interface{})
+	if !ok {
+		return nil, false
+	}
+	tags := make([]string, 0, len(list))
+	for _, tag := range list {
+		s, ok := tag.(string)
+		if !ok {
+			return nil, false
+		}
+		tags = append(tags, s)
+	}
+	return tags, true
+}
+
+// EnsureTags creates the tags in names that are not in the library yet,
+// because Things silently drops unknown tags given through the URL scheme. It
+// returns the tags it created. Under --dry-run nothing is created.
+func EnsureTags(names []string) ([]string, error) {
+	db, err := OpenDB()
+	if err != nil {
+		return nil, err
+	}
+	existing, err := db.ListTags()
+	if err != nil {
+		return nil, err
+	}
+
+	var created []string
+	for _, name := range names {
+		found := false
+		for _, tag := range existing {
+			if strings.EqualFold(tag.Title, name) {
+				found = true
+				break
+			}
+		}
+		if found || dryRun {
+			continue
+		}
+		script := fmt.Sprintf("tell application \"Things3\" to make new tag with properties {name:%s}", appleScriptString(name))
+		if _, err := runAppleScript(script); err != nil {
+			return created, fmt.Errorf("creating tag %q: %w", name, err)
+		}
+		created = append(created, name)
+	}
+	return created, nil
+}
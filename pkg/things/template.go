@@ -0,0 +1,295 @@
+package things
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/yourusername/things3-cli/pkg/util"
+)
+
+// templatesDirName is the directory next to the config file holding templates.
+const templatesDirName = "templates"
+
+var (
+	templateNamePattern = regexp.MustCompile(`^[A-Za-z0-9._-]+$`)
+	placeholderPattern  = regexp.MustCompile(`\{\{\s*([A-Za-z0-9_-]+)\s*\}\}`)
+)
+
+// Template is a reusable project layout. Text fields may contain {{name}}
+// placeholders that are filled in when the template is applied.
+type Template struct {
+	Name      string         `json:"name"`
+	Title     string         `json:"title"`
+	Notes     string         `json:"notes,omitempty"`
+	Tags      []string       `json:"tags,omitempty"`
+	Items     []TemplateItem `json:"items"`
+	CreatedAt time.Time      `json:"created_at"`
+}
+
+// TemplateItem is a to-do or heading inside a template, in project order.
+// To-dos following a heading belong to it.
+type TemplateItem struct {
+	Type      string   `json:"type"`
+	Title     string   `json:"title"`
+	Notes     string   `json:"notes,omitempty"`
+	Tags      []string `json:"tags,omitempty"`
+	Checklist []string `json:"checklist,omitempty"`
+}
+
+// TemplateFromProject captures the layout of an existing project as a template.
+func TemplateFromProject(db *DB, projectID, name string) (*Template, error) {
+	project, err := db.FindItem(projectID)
+	if err != nil {
+		return nil, err
+	}
+	if project.Type != "project" {
+		return nil, fmt.Errorf("%s is a %s, not a project", projectID, project.Type)
+	}
+
+	contents, err := db.ProjectContents(project.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Things places to-dos without a heading above all headings, so they
+	// come first, followed by each heading and its to-dos.
+	var loose, headings []Item
+	byHeading := make(map[string][]Item)
+	for _, item := range contents {
+		switch {
+		case item.Type == "heading":
+			headings = append(headings, item)
+		case item.HeadingID != "":
+			byHeading[item.HeadingID] = append(byHeading[item.HeadingID], item)
+		default:
+			loose = append(loose, item)
+		}
+	}
+
+	template := &Template{
+		Name:      name,
+		Title:     project.Title,
+		Notes:     project.Notes,
+		Tags:      project.Tags,
+		CreatedAt: time.Now(),
+	}
+	addToDos := func(items []Item) error {
+		for _, item := range items {
+			checklist, err := db.Checklist(item.ID)
+			if err != nil {
+				return err
+			}
+			entry := TemplateItem{Type: "to-do", Title: item.Title, Notes: item.Notes, Tags: item.Tags}
+			for _, c := range checklist {
+				entry.Checklist = append(entry.Checklist, c.Title)
+			}
+			template.Items = append(template.Items, entry)
+		}
+		return nil
+	}
+
+	if err := addToDos(loose); err != nil {
+		return nil, err
+	}
+	for _, heading := range headings {
+		template.Items = append(template.Items, TemplateItem{Type: "heading", Title: heading.Title})
+		if err := addToDos(byHeading[heading.ID]); err != nil {
+			return nil, err
+		}
+	}
+	return template, nil
+}
+
+// SaveTemplate writes a template to the templates directory, replacing any
+// template with the same name.
+func SaveTemplate(template *Template) error {
+	path, err := templatePath(template.Name)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create templates directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(template, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal template: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		return fmt.Errorf("failed to write template: %w", err)
+	}
+	return nil
+}
+
+// LoadTemplate reads a template by name, returning ErrNotFound if it doesn't exist.
+func LoadTemplate(name string) (*Template, error) {
+	path, err := templatePath(name)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read template: %w", err)
+	}
+
+	var template Template
+	if err := json.Unmarshal(data, &template); err != nil {
+		return nil, fmt.Errorf("failed to parse template %s: %w", name, err)
+	}
+	template.Name = name
+	return &template, nil
+}
+
+// ListTemplates returns all saved templates ordered by name.
+func ListTemplates() ([]Template, error) {
+	dir, err := util.StatePath(templatesDirName)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return []Template{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read templates directory: %w", err)
+	}
+
+	templates := []Template{}
+	for _, entry := range entries {
+		name, ok := strings.CutSuffix(entry.Name(), ".json")
+		if entry.IsDir() || !ok {
+			continue
+		}
+		template, err := LoadTemplate(name)
+		if err != nil {
+			return nil, err
+		}
+		templates = append(templates, *template)
+	}
+	sort.Slice(templates, func(i, j int) bool { return templates[i].Name < templates[j].Name })
+	return templates, nil
+}
+
+// Variables returns the placeholder names used anywhere in the template.
+func (t *Template) Variables() []string {
+	seen := make(map[string]bool)
+	collect := func(s string) {
+		for _, match := range placeholderPattern.FindAllStringSubmatch(s, -1) {
+			seen[match[1]] = true
+		}
+	}
+
+	collect(t.Title)
+	collect(t.Notes)
+	for _, tag := range t.Tags {
+		collect(tag)
+	}
+	for _, item := range t.Items {
+		collect(item.Title)
+		collect(item.Notes)
+		for _, tag := range item.Tags {
+			collect(tag)
+		}
+		for _, c := range item.Checklist {
+			collect(c)
+		}
+	}
+
+	names := make([]string, 0, len(seen))
+	for name := range seen {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Build returns the json payload creating a project from the template.
+// title replaces the template's project title when set, and extra holds
+// additional project attributes such as area or when. Every placeholder
+// must have a value in vars.
+func (t *Template) Build(title string, vars map[string]string, extra map[string]interface{}) ([]JSONOperation, error) {
+	if title == "" {
+		title = t.Title
+	}
+
+	// Placeholders in an overridden title are required instead of the template's own
+	effective := *t
+	effective.Title = title
+	var missing []string
+	for _, name := range effective.Variables() {
+		if _, ok := vars[name]; !ok {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("missing values for template variables: %s", strings.Join(missing, ", "))
+	}
+
+	fill := func(s string) string {
+		return placeholderPattern.ReplaceAllStringFunc(s, func(match string) string {
+			return vars[placeholderPattern.FindStringSubmatch(match)[1]]
+		})
+	}
+	fillAll := func(values []string) []string {
+		filled := make([]string, len(values))
+		for i, v := range values {
+			filled[i] = fill(v)
+		}
+		return filled
+	}
+
+	items := make([]JSONOperation, 0, len(t.Items))
+	for _, item := range t.Items {
+		attrs := map[string]interface{}{"title": fill(item.Title)}
+		if item.Notes != "" {
+			attrs["notes"] = fill(item.Notes)
+		}
+		if len(item.Tags) > 0 {
+			attrs["tags"] = fillAll(item.Tags)
+		}
+		if len(item.Checklist) > 0 {
+			checklist := make([]JSONOperation, len(item.Checklist))
+			for i, c := range item.Checklist {
+				checklist[i] = NewChecklistEntry(fill(c), false)
+			}
+			attrs["checklist-items"] = checklist
+		}
+		items = append(items, JSONOperation{Type: item.Type, Attributes: attrs})
+	}
+
+	attrs := map[string]interface{}{"title": fill(title), "items": items}
+	if t.Notes != "" {
+		attrs["notes"] = fill(t.Notes)
+	}
+	if len(t.Tags) > 0 {
+		attrs["tags"] = fillAll(t.Tags)
+	}
+	for key, value := range extra {
+		attrs[key] = value
+	}
+
+	return []JSONOperation{{Type: "project", Attributes: attrs}}, nil
+}
+
+// templatePath returns the file path of a named template.
+func templatePath(name string) (string, error) {
+	if !templateNamePattern.MatchString(name) {
+		return "", fmt.Errorf("invalid template name %q (use letters, digits, '.', '_' or '-')", name)
+	}
+	dir, err := util.StatePath(templatesDirName)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, name+".json"), nil
+}
@@ -0,0 +1,349 @@
+package things
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/yourusername/things3-cli/pkg/util"
+)
+
+// PayloadProblem is one mistake found in a json action payload. Path points
+// at the offending value, such as [0].attributes.when.
+type PayloadProblem struct {
+	Path    string `json:"path"`
+	Message string `json:"message"`
+}
+
+func (p PayloadProblem) String() string {
+	if p.Path == "" {
+		return p.Message
+	}
+	return p.Path + ": " + p.Message
+}
+
+// attributeKind is the type of value an attribute takes.
+type attributeKind int
+
+const (
+	attrString attributeKind = iota
+	attrBool
+	attrWhen
+	attrDate
+	attrTimestamp
+	attrTags
+	attrChecklist
+	attrProjectItems
+)
+
+// payloadAttributes lists the attributes accepted by each type of entry, for
+// creating and for updating.
+var payloadAttributes = map[string]map[string]attributeKind{}
+
+func init() {
+	todo := map[string]attributeKind{
+		"title": attrString, "notes": attrString, "when": attrWhen, "deadline": attrDate,
+		"tags": attrTags, "checklist-items": attrChecklist,
+		"list-id": attrString, "list": attrString, "heading-id": attrString, "heading": attrString,
+		"completed": attrBool, "canceled": attrBool,
+		"creation-date": attrTimestamp, "completion-date": attrTimestamp,
+	}
+	project := map[string]attributeKind{
+		"title": attrString, "notes": attrString, "when": attrWhen, "deadline": attrDate,
+		"tags": attrTags, "area-id": attrString, "area": attrString, "items": attrProjectItems,
+		"completed": attrBool, "canceled": attrBool,
+		"creation-date": attrTimestamp, "completion-date": attrTimestamp,
+	}
+	payloadAttributes["create to-do"] = todo
+	payloadAttributes["create project"] = project
+	payloadAttributes["update to-do"] = withAttributes(todo, map[string]attributeKind{
+		"prepend-notes": attrString, "append-notes": attrString, "add-tags": attrTags,
+		"prepend-checklist-items": attrChecklist, "append-checklist-items": attrChecklist,
+	})
+	payloadAttributes["update project"] = withAttributes(project, map[string]attributeKind{
+		"prepend-notes": attrString, "append-notes": attrString, "add-tags": attrTags,
+	})
+	delete(payloadAttributes["update project"], "items")
+	payloadAttributes["create heading"] = map[string]attributeKind{"title": attrString, "archived": attrBool}
+	payloadAttributes["create checklist-item"] = map[string]attributeKind{"title": attrString, "completed": attrBool, "canceled": attrBool}
+}
+
+func withAttributes(base, extra map[string]attributeKind) map[string]attributeKind {
+	merged := make(map[string]attributeKind, len(base)+len(extra))
+	for key, kind := range base {
+		merged[key] = kind
+	}
+	for key, kind := range extra {
+		merged[key] = kind
+	}
+	return merged
+}
+
+// whenKeywords are the schedule values Things accepts besides dates
+var whenKeywords = []string{"today", "tomorrow", "evening", "anytime", "someday"}
+
+// reminderPattern matches the time after @ in a when value, such as 9:30,
+// 18:00, or 6pm.
+var reminderPattern = regexp.MustCompile(`^\d{1,2}(:\d{2})?\s*([ap]m)?$`)
+
+// ValidatePayload checks a json action payload against the Things JSON
+// format: an array of to-dos and projects, each with a known type and
+// operation, attributes of the right types, and dates Things understands. It
+// returns every problem found, or none when the payload is valid.
+func ValidatePayload(data string) []PayloadProblem {
+	var payload interface{}
+	if err := json.Unmarshal([]byte(data), &payload); err != nil {
+		return []PayloadProblem{{Message: "not valid JSON: " + err.Error()}}
+	}
+	entries, ok := payload.([]interface{})
+	if !ok {
+		return []PayloadProblem{{Message: "payload must be a JSON array of to-dos and projects"}}
+	}
+	if len(entries) == 0 {
+		return []PayloadProblem{{Message: "payload is empty"}}
+	}
+
+	v := &payloadValidator{}
+	for i, entry := range entries {
+		v.entry(fmt.Sprintf("[%d]", i), entry, "to-do", "project")
+	}
+	return v.problems
+}
+
+// payloadValidator collects the problems found while walking a payload.
+type payloadValidator struct {
+	problems []PayloadProblem
+}
+
+func (v *payloadValidator) add(path, format string, args ...interface{}) {
+	v.problems = append(v.problems, PayloadProblem{Path: path, Message: fmt.Sprintf(format, args...)})
+}
+
+// entry checks one object whose type must be one of types.
+func (v *payloadValidator) entry(path string, value interface{}, types ...string) {
+	fields, ok := value.(map[string]interface{})
+	if !ok {
+		v.add(path, "must be an object with type and attributes")
+		return
+	}
+	for _, key := range sortedKeys(fields) {
+		switch key {
+		case "type", "operation", "id", "attributes":
+		default:
+			v.add(path+"."+key, "unknown field (use type, operation, id, and attributes)")
+		}
+	}
+
+	itemType, _ := fields["type"].(string)
+	if !containsString(types, itemType) {
+		if fields["type"] == nil {
+			v.add(path+".type", "is required (one of %s)", strings.Join(types, ", "))
+		} else {
+			v.add(path+".type", "must be one of %s, not %s", strings.Join(types, ", "), describeJSON(fields["type"]))
+		}
+		return
+	}
+
+	operation := "create"
+	if raw, present := fields["operation"]; present {
+		op, _ := raw.(string)
+		switch {
+		case op == "create" || op == "update" && (itemType == "to-do" || itemType == "project"):
+			operation = op
+		case op == "update":
+			v.add(path+".operation", "a %s can only be created", itemType)
+			return
+		default:
+			v.add(path+".operation", "must be create or update, not %s", describeJSON(raw))
+			return
+		}
+	}
+
+	id, hasID := fields["id"]
+	switch {
+	case operation == "update" && !hasID:
+		v.add(path+".id", "is required to update a %s", itemType)
+	case operation == "update":
+		if s, ok := id.(string); !ok || strings.TrimSpace(s) == "" {
+			v.add(path+".id", "must be the ID of the %s to update", itemType)
+		}
+	case hasID:
+		v.add(path+".id", "is only used with operation update")
+	}
+
+	attributes, ok := fields["attributes"].(map[string]interface{})
+	if !ok {
+		if fields["attributes"] == nil {
+			v.add(path+".attributes", "is required")
+		} else {
+			v.add(path+".attributes", "must be an object")
+		}
+		return
+	}
+	v.attributes(path+".attributes", operation+" "+itemType, attributes)
+	if (itemType == "heading" || itemType == "checklist-item") && attributes["title"] == nil {
+		v.add(path+".attributes.title", "is required for a %s", itemType)
+	}
+	if attributes["completed"] == true && attributes["canceled"] == true {
+		v.add(path+".attributes", "cannot be both completed and canceled")
+	}
+}
+
+// attributes checks the attributes of an entry against its schema.
+func (v *payloadValidator) attributes(path, schema string, attributes map[string]interface{}) {
+	allowed := payloadAttributes[schema]
+	for _, key := range sortedKeys(attributes) {
+		value := attributes[key]
+		at := path + "." + key
+		kind, ok := allowed[key]
+		if !ok {
+			v.add(at, "is not an attribute of %s (known: %s)", strings.TrimPrefix(schema, "create "), strings.Join(sortedKeys(allowed), ", "))
+			continue
+		}
+
+		switch kind {
+		case attrString:
+			if _, ok := value.(string); !ok {
+				v.add(at, "must be a string, not %s", describeJSON(value))
+			}
+		case attrBool:
+			if _, ok := value.(bool); !ok {
+				v.add(at, "must be true or false, not %s", describeJSON(value))
+			}
+		case attrWhen:
+			s, ok := value.(string)
+			if !ok {
+				v.add(at, "must be a string, not %s", describeJSON(value))
+			} else if !validWhen(s) {
+				v.add(at, "%q is not a schedule Things accepts (use %s, YYYY-MM-DD, or either with @HH:MM for a reminder)", s, strings.Join(whenKeywords, ", "))
+			}
+		case attrDate:
+			s, ok := value.(string)
+			if !ok {
+				v.add(at, "must be a string, not %s", describeJSON(value))
+			} else if _, err := time.Parse(util.DateLayout, s); err != nil && s != "" {
+				v.add(at, "%q is not a date in YYYY-MM-DD form", s)
+			}
+		case attrTimestamp:
+			s, ok := value.(string)
+			if !ok {
+				v.add(at, "must be a string, not %s", describeJSON(value))
+			} else if _, err := time.Parse(time.RFC3339, s); err != nil {
+				v.add(at, "%q is not an ISO 8601 date and time such as 2024-05-01T09:00:00Z", s)
+			}
+		case attrTags:
+			list, ok := value.([]interface{})
+			if !ok {
+				v.add(at, "must be an array of tag names, not %s", describeJSON(value))
+				continue
+			}
+			for i, tag := range list {
+				if _, ok := tag.(string); !ok {
+					v.add(fmt.Sprintf("%s[%d]", at, i), "must be a tag name, not %s", describeJSON(tag))
+				}
+			}
+		case attrChecklist, attrProjectItems:
+			list, ok := value.([]interface{})
+			if !ok {
+				v.add(at, "must be an array of objects, not %s", describeJSON(value))
+				continue
+			}
+			types := []string{"checklist-item"}
+			if kind == attrProjectItems {
+				types = []string{"to-do", "heading"}
+			}
+			for i, entry := range list {
+				v.entry(fmt.Sprintf("%s[%d]", at, i), entry, types...)
+			}
+		}
+	}
+}
+
+// validWhen reports whether s is a keyword or date, optionally followed by
+// @ and a reminder time.
+func validWhen(s string) bool {
+	day, reminder, hasReminder := strings.Cut(s, "@")
+	if hasReminder && !reminderPattern.MatchString(strings.ToLower(strings.TrimSpace(reminder))) {
+		return false
+	}
+	if containsString(whenKeywords, day) {
+		return true
+	}
+	_, err := time.Parse(util.DateLayout, day)
+	return err == nil
+}
+
+// ResolvePayloadDates rewrites date phrases in the when and deadline
+// attributes of a payload, such as "next monday" or "+3d", into the dates
+// Things expects, including in the to-dos of projects. A payload that cannot
+// be decoded is returned unchanged for ValidatePayload to report.
+func ResolvePayloadDates(data string, now time.Time) string {
+	var payload []interface{}
+	if err := json.Unmarshal([]byte(data), &payload); err != nil {
+		return data
+	}
+	resolveEntryDates(payload, now)
+	resolved, err := json.Marshal(payload)
+	if err != nil {
+		return data
+	}
+	return string(resolved)
+}
+
+func resolveEntryDates(entries []interface{}, now time.Time) {
+	for _, entry := range entries {
+		fields, _ := entry.(map[string]interface{})
+		attributes, _ := fields["attributes"].(map[string]interface{})
+		if attributes == nil {
+			continue
+		}
+		if when, ok := attributes["when"].(string); ok {
+			attributes["when"] = util.ResolveWhen(when, now)
+		}
+		if deadline, ok := attributes["deadline"].(string); ok && deadline != "" {
+			attributes["deadline"] = util.ResolveDate(deadline, now)
+		}
+		if items, ok := attributes["items"].([]interface{}); ok {
+			resolveEntryDates(items, now)
+		}
+	}
+}
+
+// describeJSON names the JSON type of a decoded value for error messages.
+func describeJSON(v interface{}) string {
+	switch value := v.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return fmt.Sprint(value)
+	case float64:
+		return "the number " + fmt.Sprint(value)
+	case string:
+		return fmt.Sprintf("%q", value)
+	case []interface{}:
+		return "an array"
+	default:
+		return "an object"
+	}
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func containsString(list []string, s string) bool {
+	for _, entry := range list {
+		if entry == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,153 @@
+package things
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestValidatePayloadAcceptsValidPayloads(t *testing.T) {
+	payloads := map[string]string{
+		"to-do":           `[{"type": "to-do", "attributes": {"title": "Buy milk", "when": "today", "tags": ["errands"]}}]`,
+		"reminder":        `[{"type": "to-do", "attributes": {"title": "Call", "when": "2024-06-01@9:30"}}]`,
+		"evening":         `[{"type": "to-do", "attributes": {"title": "Read", "when": "evening@6pm"}}]`,
+		"cleared":         `[{"type": "to-do", "operation": "update", "id": "T1", "attributes": {"deadline": ""}}]`,
+		"checklist":       `[{"type": "to-do", "attributes": {"title": "Pack", "checklist-items": [{"type": "checklist-item", "attributes": {"title": "Socks"}}]}}]`,
+		"project":         `[{"type": "project", "attributes": {"title": "Move", "items": [{"type": "heading", "attributes": {"title": "Before"}}, {"type": "to-do", "attributes": {"title": "Boxes"}}]}}]`,
+		"update project":  `[{"type": "project", "operation": "update", "id": "P1", "attributes": {"add-tags": ["home"], "append-notes": "done"}}]`,
+		"completion date": `[{"type": "to-do", "attributes": {"title": "Old", "completed": true, "completion-date": "2024-05-01T09:00:00Z"}}]`,
+	}
+	for name, data := range payloads {
+		if problems := ValidatePayload(data); len(problems) > 0 {
+			t.Errorf("%s: ValidatePayload reported %v, want none", name, problems)
+		}
+	}
+}
+
+func TestValidatePayloadReportsProblems(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want []string // "path: start of message"
+	}{
+		{
+			name: "not JSON",
+			data: `[{"type": "to-do"`,
+			want: []string{"not valid JSON"},
+		},
+		{
+			name: "not an array",
+			data: `{"type": "to-do", "attributes": {}}`,
+			want: []string{"payload must be a JSON array"},
+		},
+		{
+			name: "empty",
+			data: `[]`,
+			want: []string{"payload is empty"},
+		},
+		{
+			name: "unknown type",
+			data: `[{"type": "task", "attributes": {"title": "x"}}]`,
+			want: []string{"[0].type: must be one of to-do, project"},
+		},
+		{
+			name: "missing type and attributes",
+			data: `[{"title": "x"}]`,
+			want: []string{"[0].title: unknown field", "[0].type: is required"},
+		},
+		{
+			name: "update without id",
+			data: `[{"type": "to-do", "operation": "update", "attributes": {"title": "x"}}]`,
+			want: []string{"[0].id: is required to update a to-do"},
+		},
+		{
+			name: "id on create",
+			data: `[{"type": "to-do", "id": "T1", "attributes": {"title": "x"}}]`,
+			want: []string{"[0].id: is only used with operation update"},
+		},
+		{
+			name: "bad dates",
+			data: `[{"type": "to-do", "attributes": {"title": "x", "when": "next monday", "deadline": "06/01/2024"}}]`,
+			want: []string{`[0].attributes.deadline: "06/01/2024" is not a date`, `[0].attributes.when: "next monday" is not a schedule`},
+		},
+		{
+			name: "bad reminder",
+			data: `[{"type": "to-do", "attributes": {"title": "x", "when": "today@noon"}}]`,
+			want: []string{`[0].attributes.when: "today@noon" is not a schedule`},
+		},
+		{
+			name: "wrong attribute types",
+			data: `[{"type": "to-do", "attributes": {"title": 3, "completed": "yes", "tags": "home"}}]`,
+			want: []string{"[0].attributes.completed: must be true or false, not", "[0].attributes.tags: must be an array of tag names", "[0].attributes.title: must be a string, not"},
+		},
+		{
+			name: "unknown attribute",
+			data: `[{"type": "to-do", "attributes": {"title": "x", "due": "2024-06-01"}}]`,
+			want: []string{"[0].attributes.due: is not an attribute of to-do"},
+		},
+		{
+			name: "completed and canceled",
+			data: `[{"type": "to-do", "attributes": {"title": "x", "completed": true, "canceled": true}}]`,
+			want: []string{"[0].attributes: cannot be both completed and canceled"},
+		},
+		{
+			name: "nested problems",
+			data: `[{"type": "project", "attributes": {"title": "P", "items": [{"type": "heading", "attributes": {}}, {"type": "project", "attributes": {"title": "Q"}}]}}]`,
+			want: []string{"[0].attributes.items[0].attributes.title: is required for a heading", "[0].attributes.items[1].type: must be one of to-do, heading"},
+		},
+		{
+			name: "heading update",
+			data: `[{"type": "project", "attributes": {"title": "P", "items": [{"type": "heading", "operation": "update", "attributes": {"title": "H"}}]}}]`,
+			want: []string{"[0].attributes.items[0].operation: a heading can only be created"},
+		},
+		{
+			name: "items on project update",
+			data: `[{"type": "project", "operation": "update", "id": "P1", "attributes": {"items": []}}]`,
+			want: []string{"[0].attributes.items: is not an attribute of update project"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			problems := ValidatePayload(tt.data)
+			got := make([]string, len(problems))
+			for i, problem := range problems {
+				got[i] = problem.String()
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("ValidatePayload = %q, want %d problems: %q", got, len(tt.want), tt.want)
+			}
+			for i := range got {
+				if !strings.HasPrefix(got[i], tt.want[i]) {
+					t.Errorf("problem %d = %q, want it to start with %q", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestResolvePayloadDatesMakesPhrasesValid(t *testing.T) {
+	now := time.Date(2024, time.May, 15, 10, 30, 0, 0, time.UTC)
+	data := `[{"type": "project", "attributes": {"title": "P", "when": "next monday", "items": [{"type": "to-do", "attributes": {"title": "x", "when": "tonight", "deadline": "+3d"}}]}}]`
+	if problems := ValidatePayload(data); len(problems) != 3 {
+		t.Fatalf("unresolved payload has problems %v, want the three phrases reported", problems)
+	}
+
+	resolved := ResolvePayloadDates(data, now)
+	if problems := ValidatePayload(resolved); len(problems) > 0 {
+		t.Fatalf("resolved payload %s has problems %v", resolved, problems)
+	}
+	var payload []map[string]interface{}
+	if err := json.Unmarshal([]byte(resolved), &payload); err != nil {
+		t.Fatal(err)
+	}
+	project := payload[0]["attributes"].(map[string]interface{})
+	todo := project["items"].([]interface{})[0].(map[string]interface{})["attributes"].(map[string]interface{})
+	got := []interface{}{project["when"], todo["when"], todo["deadline"]}
+	want := []interface{}{"2024-05-20", "evening", "2024-05-18"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("resolved when, when, deadline = %v, want %v", got, want)
+	}
+}
@@ -10,21 +10,53 @@ import (
 
 // Config represents the things3-cli configuration stored in ~/.config/things3-cli/config.json
 type Config struct {
-	AuthToken             string    `json:"auth_token"`
-	CallbackPort          int       `json:"callback_port"`
-	CallbackTimeoutSeconds int      `json:"callback_timeout_seconds"`
-	OutputFormat          string    `json:"output_format"`
-	LastUpdated           time.Time `json:"last_updated"`
+	AuthToken              string                      `json:"auth_token"`
+	CallbackPort           int                         `json:"callback_port"`
+	CallbackTimeoutSeconds int                         `json:"callback_timeout_seconds"`
+	OutputFormat           string                      `json:"output_format"`
+	TagSynonyms            map[string]string           `json:"tag_synonyms,omitempty"`
+	NamePrefixes           map[string]string           `json:"name_prefixes,omitempty"`
+	Timezone               string                      `json:"timezone,omitempty"`
+	DateFormat             string                      `json:"date_format,omitempty"`
+	FirstDayOfWeek         string                      `json:"first_day_of_week,omitempty"`
+	SafeModeThreshold      int                         `json:"safe_mode_threshold"`
+	MCPToolGuidance        map[string]string           `json:"mcp_tool_guidance,omitempty"`
+	MCPAuthToken           string                      `json:"mcp_auth_token,omitempty"`
+	MCPTools               []string                    `json:"mcp_tools,omitempty"`
+	EmbeddingURL           string                      `json:"embedding_url"`
+	EmbeddingModel         string                      `json:"embedding_model"`
+	TemplateSchedules      map[string]TemplateSchedule `json:"template_schedules,omitempty"`
+	Aliases                map[string]string           `json:"aliases,omitempty"`
+	CaptureDir             string                      `json:"capture_dir,omitempty"`
+	ReadingListProject     string                      `json:"reading_list_project,omitempty"`
+	FocusCalendar          string                      `json:"focus_calendar,omitempty"`
+	CompletionSound        string                      `json:"completion_sound,omitempty"`
+	CompletionShortcut     string                      `json:"completion_shortcut,omitempty"`
+	LastUpdated            time.Time                   `json:"last_updated"`
+}
+
+// TemplateSchedule describes when a project template is instantiated by
+// template run-due. Dates are YYYY-MM-DD in the configured timezone.
+type TemplateSchedule struct {
+	Every   string            `json:"every"`
+	Title   string            `json:"title,omitempty"`
+	Vars    map[string]string `json:"vars,omitempty"`
+	Area    string            `json:"area,omitempty"`
+	Since   string            `json:"since"`
+	LastRun string            `json:"last_run,omitempty"`
 }
 
 // DefaultConfig returns a Config with sensible defaults
 func DefaultConfig() Config {
 	return Config{
-		CallbackPort:          8765,
+		CallbackPort:           8765,
 		CallbackTimeoutSeconds: 10,
-		OutputFormat:          "json",
-		AuthToken:             "",
-		LastUpdated:           time.Now(),
+		SafeModeThreshold:      10,
+		EmbeddingURL:           "http://localhost:11434",
+		EmbeddingModel:         "nomic-embed-text",
+		OutputFormat:           "auto",
+		AuthToken:              "",
+		LastUpdated:            time.Now(),
 	}
 }
 
@@ -68,7 +100,8 @@ func LoadConfig() (Config, error) {
 		return Config{}, fmt.Errorf("failed to read config file: %w", err)
 	}
 
-	var config Config
+	// Start from defaults so fields missing from older config files keep sensible values
+	config := DefaultConfig()
 	if err := json.Unmarshal(data, &config); err != nil {
 		return Config{}, fmt.Errorf("failed to parse config file: %w", err)
 	}
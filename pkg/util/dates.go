@@ -0,0 +1,387 @@
+package util
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DateLayout is the date format accepted by the Things URL scheme
+const DateLayout = "2006-01-02"
+
+var weekdayNames = map[string]time.Weekday{
+	"sunday": time.Sunday, "sun": time.Sunday,
+	"monday": time.Monday, "mon": time.Monday,
+	"tuesday": time.Tuesday, "tue": time.Tuesday, "tues": time.Tuesday,
+	"wednesday": time.Wednesday, "wed": time.Wednesday,
+	"thursday": time.Thursday, "thu": time.Thursday, "thurs": time.Thursday,
+	"friday": time.Friday, "fri": time.Friday,
+	"saturday": time.Saturday, "sat": time.Saturday,
+}
+
+var inOffsetPattern = regexp.MustCompile(`^in (\d+|a|an) (day|week|month|year)s?$`)
+
+var signedOffsetPattern = regexp.MustCompile(`^([+-])\s*(\d+)\s*(d|day|w|week|m|month|y|year)s?$`)
+
+// offsetUnits maps the short offset units to the names used by addDateUnits
+var offsetUnits = map[string]string{"d": "day", "w": "week", "m": "month", "y": "year"}
+
+var sincePattern = regexp.MustCompile(`^(\d+)\s*([hdwmy])$`)
+
+// whenKeywords are schedule values Things understands natively
+var whenKeywords = map[string]bool{
+	"today": true, "tomorrow": true, "evening": true, "tonight": true,
+	"anytime": true, "someday": true,
+}
+
+// timezone and dateFormat override the config when set by SetTimezone and
+// SetDateFormat, for --timezone and --date-format
+var (
+	timezone   *time.Location
+	dateFormat string
+)
+
+// dateConfig holds the date settings read from the config.
+type dateConfig struct {
+	timezone   *time.Location
+	dateFormat string
+	weekStart  time.Weekday
+}
+
+// configured is loaded once, on first use, so formatting a long listing or
+// resolving a batch of dates doesn't reread the config file for every date.
+var (
+	configuredOnce sync.Once
+	configured     dateConfig
+)
+
+// configuredDates returns the config's date settings, falling back to the
+// system zone, DateLayout, and Monday where they are unset or invalid.
+func configuredDates() dateConfig {
+	configuredOnce.Do(func() {
+		configured = dateConfig{timezone: time.Local, dateFormat: DateLayout, weekStart: time.Monday}
+		config, err := LoadConfig()
+		if err != nil {
+			return
+		}
+		if config.Timezone != "" {
+			if loc, err := time.LoadLocation(config.Timezone); err == nil {
+				configured.timezone = loc
+			}
+		}
+		if config.DateFormat != "" && checkDateFormat(config.DateFormat) == nil {
+			configured.dateFormat = config.DateFormat
+		}
+		if wd, ok := weekdayNames[strings.ToLower(strings.TrimSpace(config.FirstDayOfWeek))]; ok {
+			configured.weekStart = wd
+		}
+	})
+	return configured
+}
+
+// SetTimezone makes name, an IANA zone such as Europe/Berlin or Local, the
+// timezone for the rest of the process in place of the config's timezone.
+// An empty name keeps the config's.
+func SetTimezone(name string) error {
+	if name == "" {
+		timezone = nil
+		return nil
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return fmt.Errorf("unknown timezone %q", name)
+	}
+	timezone = loc
+	return nil
+}
+
+// SetDateFormat makes layout, a Go reference layout such as 02.01.2006 or
+// "Jan 2, 2006", the format dates are displayed in, in place of the config's
+// date_format. An empty layout keeps the config's.
+func SetDateFormat(layout string) error {
+	if err := checkDateFormat(layout); err != nil {
+		return err
+	}
+	dateFormat = layout
+	return nil
+}
+
+// checkDateFormat rejects layouts that lose the day, so a date printed with
+// them can't be read back.
+func checkDateFormat(layout string) error {
+	if layout == "" {
+		return nil
+	}
+	ref := time.Date(2006, time.January, 2, 0, 0, 0, 0, time.UTC)
+	parsed, err := time.Parse(layout, ref.Format(layout))
+	if err != nil || parsed.Day() != 2 || parsed.Month() != time.January {
+		return fmt.Errorf("date format %q must show the day and month, written as Go's reference date (e.g. 02.01.2006 or \"Jan 2, 2006\")", layout)
+	}
+	return nil
+}
+
+// DateFormat returns the layout dates are displayed in: the --date-format
+// layout, else the config's date_format, else DateLayout.
+func DateFormat() string {
+	if dateFormat != "" {
+		return dateFormat
+	}
+	return configuredDates().dateFormat
+}
+
+// DisplayDate renders a YYYY-MM-DD date in the display format, leaving other
+// values as they are.
+func DisplayDate(date string) string {
+	t, err := time.Parse(DateLayout, date)
+	if err != nil {
+		return date
+	}
+	return t.Format(DateFormat())
+}
+
+// Location returns the timezone set with SetTimezone or configured, falling
+// back to the system zone
+func Location() *time.Location {
+	if timezone != nil {
+		return timezone
+	}
+	return configuredDates().timezone
+}
+
+// parseDisplayDate reads a date written in the display format. Formats
+// without a year take the next such date from today.
+func parseDisplayDate(input string, today time.Time) (time.Time, bool) {
+	layout := DateFormat()
+	if layout == DateLayout {
+		return time.Time{}, false
+	}
+	t, err := time.ParseInLocation(layout, strings.TrimSpace(input), today.Location())
+	if err != nil {
+		return time.Time{}, false
+	}
+	if t.Year() == 0 {
+		t = time.Date(today.Year(), t.Month(), t.Day(), 0, 0, 0, 0, today.Location())
+		if t.Before(today) {
+			t = t.AddDate(1, 0, 0)
+		}
+	}
+	return t, true
+}
+
+// Now returns the current time in the configured timezone
+func Now() time.Time {
+	return time.Now().In(Location())
+}
+
+// ParseDate resolves a date phrase relative to now
+// Supports YYYY-MM-DD, dates in the display format (see DateFormat), today/tomorrow/yesterday, weekday names ("friday",
+// "this friday", "next monday"), "in 3 days", offsets like "+3d", "+1w", or
+// "-2m" (days, weeks, months, years), "next week/month/year",
+// "start/end of week/month/year", and ISO weeks ("2024-W23", "week 23")
+// Weeks begin on the configured first day of the week, except ISO weeks,
+// which resolve to their Monday
+func ParseDate(input string, now time.Time) (time.Time, bool) {
+	phrase := strings.Join(strings.Fields(strings.ToLower(input)), " ")
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+
+	if t, err := time.ParseInLocation(DateLayout, phrase, now.Location()); err == nil {
+		return t, true
+	}
+	if t, ok := parseDisplayDate(input, today); ok {
+		return t, true
+	}
+
+	switch phrase {
+	case "today":
+		return today, true
+	case "tomorrow":
+		return today.AddDate(0, 0, 1), true
+	case "yesterday":
+		return today.AddDate(0, 0, -1), true
+	case "next week":
+		return nextWeekday(today, WeekStart(), false), true
+	case "next month":
+		return time.Date(today.Year(), today.Month()+1, 1, 0, 0, 0, 0, today.Location()), true
+	case "next year":
+		return time.Date(today.Year()+1, time.January, 1, 0, 0, 0, 0, today.Location()), true
+	case "start of week", "this week":
+		return StartOfWeek(today, WeekStart()), true
+	case "start of month":
+		return time.Date(today.Year(), today.Month(), 1, 0, 0, 0, 0, today.Location()), true
+	case "start of year":
+		return time.Date(today.Year(), time.January, 1, 0, 0, 0, 0, today.Location()), true
+	case "end of week":
+		return StartOfWeek(today, WeekStart()).AddDate(0, 0, 6), true
+	case "end of month":
+		return time.Date(today.Year(), today.Month()+1, 0, 0, 0, 0, 0, today.Location()), true
+	case "end of year":
+		return time.Date(today.Year(), time.December, 31, 0, 0, 0, 0, today.Location()), true
+	}
+
+	if match := inOffsetPattern.FindStringSubmatch(phrase); match != nil {
+		n := 1
+		if match[1] != "a" && match[1] != "an" {
+			n, _ = strconv.Atoi(match[1])
+		}
+		return addDateUnits(today, n, match[2]), true
+	}
+
+	if t, ok := parseISOWeek(phrase, today); ok {
+		return t, true
+	}
+
+	if match := signedOffsetPattern.FindStringSubmatch(phrase); match != nil {
+		n, _ := strconv.Atoi(match[2])
+		if match[1] == "-" {
+			n = -n
+		}
+		unit := match[3]
+		if long, ok := offsetUnits[unit]; ok {
+			unit = long
+		}
+		return addDateUnits(today, n, unit), true
+	}
+
+	words := strings.Fields(phrase)
+	switch {
+	case len(words) == 1:
+		if wd, ok := weekdayNames[words[0]]; ok {
+			return nextWeekday(today, wd, false), true
+		}
+	case len(words) == 2 && words[0] == "this":
+		if wd, ok := weekdayNames[words[1]]; ok {
+			return nextWeekday(today, wd, true), true
+		}
+	case len(words) == 2 && words[0] == "next":
+		if wd, ok := weekdayNames[words[1]]; ok {
+			return nextWeekday(today, wd, false), true
+		}
+	}
+
+	return time.Time{}, false
+}
+
+// ResolveDate converts a date phrase into YYYY-MM-DD
+// Values that can't be parsed are returned unchanged for Things to interpret
+func ResolveDate(value string, now time.Time) string {
+	if t, ok := ParseDate(value, now); ok {
+		return t.Format(DateLayout)
+	}
+	return value
+}
+
+// ResolveWhen converts a schedule value into a form Things accepts
+// Native keywords are kept as-is, "tonight" becomes "evening" (This Evening),
+// and a trailing "@time" reminder is preserved
+// Example: "next monday@9:00" → "2024-06-03@9:00"
+func ResolveWhen(value string, now time.Time) string {
+	datePart, timePart, hasTime := strings.Cut(strings.TrimSpace(value), "@")
+	if strings.EqualFold(datePart, "tonight") {
+		datePart = "evening"
+		if hasTime {
+			return datePart + "@" + timePart
+		}
+		return datePart
+	}
+	if datePart == "" || whenKeywords[strings.ToLower(datePart)] {
+		return value
+	}
+
+	t, ok := ParseDate(datePart, now)
+	if !ok {
+		return value
+	}
+	if hasTime {
+		return t.Format(DateLayout) + "@" + timePart
+	}
+	return t.Format(DateLayout)
+}
+
+// ParseSince resolves a lookback such as "12h", "3d", "1w", "2m", or "1y"
+// (hours, days, weeks, months, years) to the time that long before now.
+// Any date accepted by ParseDate is also allowed and means its midnight.
+func ParseSince(input string, now time.Time) (time.Time, error) {
+	value := strings.ToLower(strings.TrimSpace(input))
+	if match := sincePattern.FindStringSubmatch(value); match != nil {
+		n, _ := strconv.Atoi(match[1])
+		switch match[2] {
+		case "h":
+			return now.Add(-time.Duration(n) * time.Hour), nil
+		case "d":
+			return now.AddDate(0, 0, -n), nil
+		case "w":
+			return now.AddDate(0, 0, -7*n), nil
+		case "m":
+			return now.AddDate(0, -n, 0), nil
+		default:
+			return now.AddDate(-n, 0, 0), nil
+		}
+	}
+	if t, ok := ParseDate(value, now); ok {
+		return t, nil
+	}
+	return time.Time{}, fmt.Errorf("invalid lookback %q (use e.g. 12h, 3d, 1w, 2m, or a date)", input)
+}
+
+// nextWeekday returns the next date falling on wd, including from itself if inclusive
+func nextWeekday(from time.Time, wd time.Weekday, inclusive bool) time.Time {
+	days := (int(wd) - int(from.Weekday()) + 7) % 7
+	if days == 0 && !inclusive {
+		days = 7
+	}
+	return from.AddDate(0, 0, days)
+}
+
+// addDateUnits adds n days, weeks, months, or years, clamping month ends
+// Example: Jan 31 + 1 month → Feb 28 (or 29)
+func addDateUnits(from time.Time, n int, unit string) time.Time {
+	switch unit {
+	case "week":
+		return from.AddDate(0, 0, 7*n)
+	case "month":
+		return addMonthsClamped(from, n)
+	case "year":
+		return addMonthsClamped(from, 12*n)
+	default:
+		return from.AddDate(0, 0, n)
+	}
+}
+
+func addMonthsClamped(from time.Time, months int) time.Time {
+	first := time.Date(from.Year(), from.Month()+time.Month(months), 1, 0, 0, 0, 0, from.Location())
+	lastDay := first.AddDate(0, 1, -1).Day()
+	day := from.Day()
+	if day > lastDay {
+		day = lastDay
+	}
+	return time.Date(first.Year(), first.Month(), day, 0, 0, 0, 0, from.Location())
+}
+
+// LastOccurrence returns the most recent date on or before today matching a
+// recurrence: "daily", "weekdays" (Monday to Friday), "monthly" (the 1st), or
+// a weekday name such as "monday"
+func LastOccurrence(every string, today time.Time) (time.Time, error) {
+	today = time.Date(today.Year(), today.Month(), today.Day(), 0, 0, 0, 0, today.Location())
+
+	switch every = strings.ToLower(strings.TrimSpace(every)); every {
+	case "day", "daily":
+		return today, nil
+	case "weekday", "weekdays":
+		for today.Weekday() == time.Saturday || today.Weekday() == time.Sunday {
+			today = today.AddDate(0, 0, -1)
+		}
+		return today, nil
+	case "month", "monthly":
+		return time.Date(today.Year(), today.Month(), 1, 0, 0, 0, 0, today.Location()), nil
+	}
+
+	wd, ok := weekdayNames[every]
+	if !ok {
+		return time.Time{}, fmt.Errorf("unknown recurrence %q (use daily, weekdays, monthly, or a weekday name)", every)
+	}
+	days := (int(today.Weekday()) - int(wd) + 7) % 7
+	return today.AddDate(0, 0, -days), nil
+}
@@ -0,0 +1,189 @@
+package util
+
+import (
+	"os"
+	"testing"
+	"time"
+)
+
+// TestMain points HOME at an empty directory, so the date settings come from
+// the defaults rather than the config of whoever runs the tests.
+func TestMain(m *testing.M) {
+	home, err := os.MkdirTemp("", "things3-cli-util")
+	if err != nil {
+		panic(err)
+	}
+	os.Setenv("HOME", home)
+	code := m.Run()
+	os.RemoveAll(home)
+	os.Exit(code)
+}
+
+// wednesday is the "now" of the date tests: Wednesday, 15 May 2024.
+var wednesday = time.Date(2024, time.May, 15, 10, 30, 0, 0, time.UTC)
+
+func TestParseDate(t *testing.T) {
+	tests := []struct {
+		input string
+		now   time.Time
+		want  string
+	}{
+		{input: "2024-06-01", want: "2024-06-01"},
+		{input: "today", want: "2024-05-15"},
+		{input: "Tomorrow", want: "2024-05-16"},
+		{input: "yesterday", want: "2024-05-14"},
+
+		// Relative offsets
+		{input: "in 3 days", want: "2024-05-18"},
+		{input: "in a week", want: "2024-05-22"},
+		{input: "in 2 months", want: "2024-07-15"},
+		{input: "+3d", want: "2024-05-18"},
+		{input: "-2w", want: "2024-05-01"},
+		{input: "+1m", want: "2024-06-15"},
+		{input: "+1 year", want: "2025-05-15"},
+		{input: "+1m", now: time.Date(2024, time.January, 31, 9, 0, 0, 0, time.UTC), want: "2024-02-29"},
+		{input: "next month", want: "2024-06-01"},
+		{input: "start of month", want: "2024-05-01"},
+		{input: "end of month", want: "2024-05-31"},
+		{input: "end of year", want: "2024-12-31"},
+		{input: "start of week", want: "2024-05-13"},
+		{input: "end of week", want: "2024-05-19"},
+		{input: "next week", want: "2024-05-20"},
+
+		// Weekdays roll over to the next week, except with "this"
+		{input: "friday", want: "2024-05-17"},
+		{input: "wednesday", want: "2024-05-22"},
+		{input: "this wednesday", want: "2024-05-15"},
+		{input: "next  Monday", want: "2024-05-20"},
+		{input: "sun", want: "2024-05-19"},
+		{input: "friday", now: time.Date(2024, time.December, 30, 9, 0, 0, 0, time.UTC), want: "2025-01-03"},
+		{input: "monday", now: time.Date(2024, time.December, 30, 9, 0, 0, 0, time.UTC), want: "2025-01-06"},
+
+		// ISO weeks
+		{input: "2024-W23", want: "2024-06-03"},
+		{input: "week 1", want: "2024-01-01"},
+	}
+
+	for _, tt := range tests {
+		now := tt.now
+		if now.IsZero() {
+			now = wednesday
+		}
+		got, ok := ParseDate(tt.input, now)
+		if !ok {
+			t.Errorf("ParseDate(%q) failed, want %s", tt.input, tt.want)
+			continue
+		}
+		if got.Format(DateLayout) != tt.want {
+			t.Errorf("ParseDate(%q) on %s = %s, want %s", tt.input, now.Format(DateLayout), got.Format(DateLayout), tt.want)
+		}
+	}
+}
+
+func TestParseDateInvalid(t *testing.T) {
+	for _, input := range []string{"", "someday", "nextmonday", "in x days", "+3q", "2024-13-01", "2024-02-30", "2024-W53", "this month", "next blursday"} {
+		if got, ok := ParseDate(input, wednesday); ok {
+			t.Errorf("ParseDate(%q) = %s, want no date", input, got.Format(DateLayout))
+		}
+	}
+}
+
+func TestResolveWhen(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{input: "today", want: "today"},
+		{input: "someday", want: "someday"},
+		{input: "tonight", want: "evening"},
+		{input: "Tonight@18:00", want: "evening@18:00"},
+		{input: "next monday@9:00", want: "2024-05-20@9:00"},
+		{input: "in 3 days", want: "2024-05-18"},
+		{input: "whenever", want: "whenever"},
+	}
+	for _, tt := range tests {
+		if got := ResolveWhen(tt.input, wednesday); got != tt.want {
+			t.Errorf("ResolveWhen(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestDisplayDate(t *testing.T) {
+	tests := []struct {
+		layout string
+		date   string
+		want   string
+	}{
+		{layout: "", date: "2024-05-03", want: "2024-05-03"},
+		{layout: "02.01.2006", date: "2024-05-03", want: "03.05.2024"},
+		{layout: "Jan 2, 2006", date: "2024-05-03", want: "May 3, 2024"},
+		{layout: "02.01.2006", date: "someday", want: "someday"},
+		{layout: "02.01.2006", date: "", want: ""},
+	}
+	defer SetDateFormat("")
+	for _, tt := range tests {
+		if err := SetDateFormat(tt.layout); err != nil {
+			t.Fatal(err)
+		}
+		if got := DisplayDate(tt.date); got != tt.want {
+			t.Errorf("DisplayDate(%q) with %q = %q, want %q", tt.date, tt.layout, got, tt.want)
+		}
+	}
+}
+
+func TestSetDateFormatRejectsLayoutsWithoutDay(t *testing.T) {
+	defer SetDateFormat("")
+	for _, layout := range []string{"2006", "January 2006", "15:04", "dd.mm.yyyy"} {
+		if err := SetDateFormat(layout); err == nil {
+			t.Errorf("SetDateFormat(%q) accepted a layout without the day and month", layout)
+		}
+	}
+}
+
+func TestParseDisplayDate(t *testing.T) {
+	tests := []struct {
+		layout string
+		input  string
+		want   string
+		ok     bool
+	}{
+		{layout: "", input: "03.05.2024", ok: false},
+		{layout: "02.01.2006", input: "03.05.2024", want: "2024-05-03", ok: true},
+		{layout: "02.01.2006", input: " 03.05.2024 ", want: "2024-05-03", ok: true},
+		{layout: "02.01.2006", input: "2024-05-03", ok: false},
+		{layout: "02.01.2006", input: "32.05.2024", ok: false},
+
+		// Without a year the next such date is taken, today included
+		{layout: "Jan 2", input: "May 20", want: "2024-05-20", ok: true},
+		{layout: "Jan 2", input: "May 15", want: "2024-05-15", ok: true},
+		{layout: "Jan 2", input: "Mar 1", want: "2025-03-01", ok: true},
+	}
+	defer SetDateFormat("")
+	today := time.Date(wednesday.Year(), wednesday.Month(), wednesday.Day(), 0, 0, 0, 0, time.UTC)
+	for _, tt := range tests {
+		if err := SetDateFormat(tt.layout); err != nil {
+			t.Fatal(err)
+		}
+		got, ok := parseDisplayDate(tt.input, today)
+		if ok != tt.ok {
+			t.Errorf("parseDisplayDate(%q) with %q ok = %v, want %v", tt.input, tt.layout, ok, tt.ok)
+			continue
+		}
+		if ok && got.Format(DateLayout) != tt.want {
+			t.Errorf("parseDisplayDate(%q) with %q = %s, want %s", tt.input, tt.layout, got.Format(DateLayout), tt.want)
+		}
+	}
+}
+
+func TestParseDateReadsDisplayFormat(t *testing.T) {
+	defer SetDateFormat("")
+	if err := SetDateFormat("02.01.2006"); err != nil {
+		t.Fatal(err)
+	}
+	for input, want := range map[string]string{"03.05.2024": "2024-05-03", "2024-05-03": "2024-05-03", "tomorrow": "2024-05-16"} {
+		got, ok := ParseDate(input, wednesday)
+		if !ok || got.Format(DateLayout) != want {
+			t.Errorf("ParseDate(%q) = %s, %v, want %s", input, got.Format(DateLayout), ok, want)
+		}
+	}
+}
@@ -0,0 +1,41 @@
+package util
+
+import (
+	"strings"
+	"unicode"
+)
+
+// StripEmojiPrefix removes leading emoji and decorative symbols from a list name
+// Example: "🏠 Home" → "Home", "✈️ Travel" → "Travel"
+func StripEmojiPrefix(name string) string {
+	trimmed := strings.TrimLeftFunc(name, func(r rune) bool {
+		return unicode.IsSpace(r) ||
+			unicode.In(r, unicode.So, unicode.Sk, unicode.Cs) ||
+			r == '\u200d' || // zero width joiner
+			(r >= '\ufe00' && r <= '\ufe0f') // variation selectors
+	})
+	if trimmed == "" {
+		return strings.TrimSpace(name)
+	}
+	return trimmed
+}
+
+// NamesMatch reports whether two project or area names refer to the same list,
+// ignoring emoji prefixes, case, and diacritics
+func NamesMatch(a, b string) bool {
+	return NormalizeTag(StripEmojiPrefix(a)) == NormalizeTag(StripEmojiPrefix(b))
+}
+
+// ApplyNamePrefix adds the configured emoji prefix to a plain list name
+// Names that already carry a prefix are returned unchanged
+func ApplyNamePrefix(name string, prefixes map[string]string) string {
+	if StripEmojiPrefix(name) != strings.TrimSpace(name) {
+		return name
+	}
+	for plain, prefix := range prefixes {
+		if NamesMatch(plain, name) {
+			return prefix + " " + name
+		}
+	}
+	return name
+}
@@ -0,0 +1,73 @@
+package util
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// StatePath returns the path of a state file stored next to the config file
+// Example: StatePath("last_created.json") → ~/.config/things3-cli/last_created.json
+func StatePath(name string) (string, error) {
+	path, err := ConfigPath()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(filepath.Dir(path), name), nil
+}
+
+// LoadState reads a JSON state file into v, reporting false if it doesn't exist
+func LoadState(name string, v interface{}) (bool, error) {
+	path, err := StatePath(name)
+	if err != nil {
+		return false, err
+	}
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to read state file: %w", err)
+	}
+
+	if err := json.Unmarshal(data, v); err != nil {
+		return false, fmt.Errorf("failed to parse state file: %w", err)
+	}
+	return true, nil
+}
+
+// SaveState writes v as JSON to a state file
+func SaveState(name string, v interface{}) error {
+	if err := EnsureConfigDir(); err != nil {
+		return err
+	}
+
+	path, err := StatePath(name)
+	if err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal state: %w", err)
+	}
+
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		return fmt.Errorf("failed to write state file: %w", err)
+	}
+	return nil
+}
+
+// ClearState removes a state file if it exists
+func ClearState(name string) error {
+	path, err := StatePath(name)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove state file: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,96 @@
+package util
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/cases"
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+// NormalizeTag folds case and strips diacritics so equivalent spellings compare equal
+// Example: "Éducation", "education", "EDUCATION" → "education"
+func NormalizeTag(tag string) string {
+	stripped, _, err := transform.String(
+		transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC),
+		strings.TrimSpace(tag),
+	)
+	if err != nil {
+		stripped = strings.TrimSpace(tag)
+	}
+	return cases.Fold().String(stripped)
+}
+
+// ApplyTagSynonym maps a tag to its configured canonical spelling
+// Synonym keys are compared in normalized form, so "Edu" matches a key of "edu".
+// An exact key wins over a normalized one, keys that normalize alike are tried
+// in sorted order, and synonyms don't chain: the canonical spelling is used as is.
+func ApplyTagSynonym(tag string, synonyms map[string]string) string {
+	if to, ok := synonyms[tag]; ok {
+		return to
+	}
+	froms := make([]string, 0, len(synonyms))
+	for from := range synonyms {
+		froms = append(froms, from)
+	}
+	sort.Strings(froms)
+
+	key := NormalizeTag(tag)
+	for _, from := range froms {
+		if NormalizeTag(from) == key {
+			return synonyms[from]
+		}
+	}
+	return tag
+}
+
+// DedupeTags removes tags that normalize to the same value, keeping the first spelling
+func DedupeTags(tags []string) []string {
+	seen := make(map[string]bool)
+	var result []string
+	for _, tag := range tags {
+		key := NormalizeTag(tag)
+		if key == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		result = append(result, tag)
+	}
+	return result
+}
+
+// CanonicalizeTags applies synonyms, replaces each tag with the matching known tag
+// title (Things ignores tags that don't exist verbatim), and removes duplicates
+func CanonicalizeTags(tags []string, known []string, synonyms map[string]string) []string {
+	byKey := make(map[string]string, len(known))
+	for _, title := range known {
+		key := NormalizeTag(title)
+		if _, exists := byKey[key]; !exists {
+			byKey[key] = title
+		}
+	}
+
+	result := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		tag = ApplyTagSynonym(tag, synonyms)
+		if title, ok := byKey[NormalizeTag(tag)]; ok {
+			tag = title
+		}
+		result = append(result, tag)
+	}
+	return DedupeTags(result)
+}
+
+// TagsContain reports whether tags includes target after normalization
+func TagsContain(tags []string, target string) bool {
+	key := NormalizeTag(target)
+	for _, tag := range tags {
+		if NormalizeTag(tag) == key {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,52 @@
+package util
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"time"
+)
+
+// isoWeekPattern matches ISO week references: "2024-W23", "2024w23", "w23",
+// and "week 23" (in the current ISO year)
+var isoWeekPattern = regexp.MustCompile(`^(?:(\d{4})-?w|w|week )(\d{1,2})$`)
+
+// WeekStart returns the configured first day of the week, Monday by default.
+// Set first_day_of_week in the config to "sunday" (or any weekday) to change it.
+func WeekStart() time.Weekday {
+	return configuredDates().weekStart
+}
+
+// StartOfWeek returns midnight on the first day of the week containing t.
+func StartOfWeek(t time.Time, start time.Weekday) time.Time {
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	return day.AddDate(0, 0, -((int(day.Weekday()) - int(start) + 7) % 7))
+}
+
+// ISOWeekLabel formats the ISO 8601 week containing t, e.g. "2024-W23".
+// ISO weeks always run Monday to Sunday and week 1 holds the year's first
+// Thursday, whatever the configured first day of the week.
+func ISOWeekLabel(t time.Time) string {
+	year, week := t.ISOWeek()
+	return fmt.Sprintf("%04d-W%02d", year, week)
+}
+
+// parseISOWeek resolves an ISO week reference to the Monday starting it.
+func parseISOWeek(phrase string, today time.Time) (time.Time, bool) {
+	match := isoWeekPattern.FindStringSubmatch(phrase)
+	if match == nil {
+		return time.Time{}, false
+	}
+	year, _ := today.ISOWeek()
+	if match[1] != "" {
+		year, _ = strconv.Atoi(match[1])
+	}
+	week, _ := strconv.Atoi(match[2])
+
+	// January 4th is always in week 1
+	monday := StartOfWeek(time.Date(year, time.January, 4, 0, 0, 0, 0, today.Location()), time.Monday).AddDate(0, 0, 7*(week-1))
+	if _, w := monday.ISOWeek(); week < 1 || w != week {
+		return time.Time{}, false
+	}
+	return monday, true
+}